@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/containerd/containerd/mount"
@@ -26,6 +27,7 @@ import (
 	"github.com/containerd/log"
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
+	"golang.org/x/sys/unix"
 )
 
 const (
@@ -51,7 +53,8 @@ type drive struct {
 
 type driveHandler struct {
 	// drives is a mapping to all the stub drives
-	drives map[string]drive
+	drives   map[string]drive
+	drivesMu sync.RWMutex
 	// BlockPath contains the location of the block subdirectory under the sysfs
 	// mount point.
 	BlockPath string
@@ -76,21 +79,47 @@ func newDriveHandler(blockPath, drivePath string) (*driveHandler, error) {
 	return d, nil
 }
 
-func (dh driveHandler) GetDrive(id string) (drive, bool) {
+func (dh *driveHandler) GetDrive(id string) (drive, bool) {
+	dh.drivesMu.RLock()
+	defer dh.drivesMu.RUnlock()
 	v, ok := dh.drives[id]
 	return v, ok
 }
 
+// Drives returns the stub drives discovered so far.
+func (dh *driveHandler) Drives() []drive {
+	dh.drivesMu.RLock()
+	defer dh.drivesMu.RUnlock()
+
+	drives := make([]drive, 0, len(dh.drives))
+	for _, d := range dh.drives {
+		drives = append(drives, d)
+	}
+	return drives
+}
+
 // discoverDrives will iterate the block path in the sys directory to retrieve all
-// stub block devices.
+// stub block devices. Block devices which were already discovered are skipped.
 func (dh *driveHandler) discoverDrives() error {
 	names, err := getListOfBlockDeviceNames(dh.BlockPath)
 	if err != nil {
 		return err
 	}
 
+	dh.drivesMu.Lock()
+	defer dh.drivesMu.Unlock()
+
+	known := map[string]struct{}{}
+	for _, d := range dh.drives {
+		known[d.Name] = struct{}{}
+	}
+
 	drives := map[string]drive{}
 	for _, name := range names {
+		if _, ok := known[name]; ok {
+			continue
+		}
+
 		d, err := dh.buildDrive(name)
 		if err != nil {
 			return err
@@ -113,7 +142,9 @@ func (dh *driveHandler) discoverDrives() error {
 		drives[d.DriveID] = d
 	}
 
-	dh.drives = drives
+	for id, d := range drives {
+		dh.drives[id] = d
+	}
 	return nil
 }
 
@@ -137,7 +168,7 @@ func getListOfBlockDeviceNames(path string) ([]string, error) {
 
 // buildDrive uses the /sys/block folder to check a given name's block major
 // and minor, and block size.
-func (dh driveHandler) buildDrive(name string) (drive, error) {
+func (dh *driveHandler) buildDrive(name string) (drive, error) {
 	d := drive{
 		Name:      name,
 		DrivePath: dh.DrivePath,
@@ -160,10 +191,25 @@ func isStubDrive(d drive) bool {
 	}
 	defer f.Close()
 
+	// A drive may have been read while it was backed by a different file, so drop
+	// any cached contents. This fails for anything but block devices, which is fine.
+	_ = unix.IoctlSetInt(int(f.Fd()), unix.BLKFLSBUF, 0)
+
 	return internal.IsStubDrive(f)
 }
 
-func (dh driveHandler) MountDrive(ctx context.Context, req *drivemount.MountDriveRequest) (*types.Empty, error) {
+// DiscoverDrives looks for stub drives which were provisioned by the shim after the agent
+// started, by patching drives which were backed by placeholder files.
+func (dh *driveHandler) DiscoverDrives(ctx context.Context, _ *drivemount.DiscoverDrivesRequest) (*types.Empty, error) {
+	if err := dh.discoverDrives(); err != nil {
+		log.G(ctx).WithError(err).Error("failed to discover drives")
+		return nil, fmt.Errorf("failed to discover drives: %w", err)
+	}
+
+	return &types.Empty{}, nil
+}
+
+func (dh *driveHandler) MountDrive(ctx context.Context, req *drivemount.MountDriveRequest) (*types.Empty, error) {
 	logger := log.G(ctx)
 	logger.Debugf("%+v", req.String())
 	logger = logger.WithField("drive_id", req.DriveID)
@@ -214,7 +260,7 @@ func (dh driveHandler) MountDrive(ctx context.Context, req *drivemount.MountDriv
 	return nil, fmt.Errorf("exhausted retries mounting drive from %q to %q", drive.Path(), req.DestinationPath)
 }
 
-func (dh driveHandler) UnmountDrive(_ context.Context, req *drivemount.UnmountDriveRequest) (*types.Empty, error) {
+func (dh *driveHandler) UnmountDrive(_ context.Context, req *drivemount.UnmountDriveRequest) (*types.Empty, error) {
 	drive, ok := dh.GetDrive(req.DriveID)
 	if !ok {
 		return nil, fmt.Errorf("drive %q could not be found", req.DriveID)
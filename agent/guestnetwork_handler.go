@@ -0,0 +1,193 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	guestnetwork "github.com/firecracker-microvm/firecracker-containerd/proto/service/guestnetwork/ttrpc"
+)
+
+// netlinkHandle is the subset of netlink.Handle used to configure the network interfaces of the VM.
+type netlinkHandle interface {
+	LinkList() ([]netlink.Link, error)
+	LinkSetUp(link netlink.Link) error
+	AddrReplace(link netlink.Link, addr *netlink.Addr) error
+	RouteReplace(route *netlink.Route) error
+}
+
+// guestNetworkHandler implements GuestNetworkService by configuring the network interfaces
+// of the VM over netlink and writing its DNS configuration to resolv.conf.
+type guestNetworkHandler struct {
+	netlink        netlinkHandle
+	resolvConfPath string
+}
+
+var _ guestnetwork.GuestNetworkService = &guestNetworkHandler{}
+
+func newGuestNetworkHandler() *guestNetworkHandler {
+	return &guestNetworkHandler{
+		netlink:        &netlink.Handle{},
+		resolvConfPath: "/etc/resolv.conf",
+	}
+}
+
+// ConfigureNetwork assigns the addresses, IPv6 gateway and routes of the requested network interfaces
+// and writes the requested DNS configuration.
+func (h *guestNetworkHandler) ConfigureNetwork(_ context.Context, req *guestnetwork.ConfigureNetworkRequest) (*empty.Empty, error) {
+	for _, iface := range req.NetworkInterfaces {
+		if err := h.configureInterface(iface); err != nil {
+			return nil, fmt.Errorf("failed to configure network interface %q: %w", iface.MacAddress, err)
+		}
+	}
+
+	if req.DNS != nil {
+		if err := h.writeResolvConf(req.DNS); err != nil {
+			return nil, fmt.Errorf("failed to write %q: %w", h.resolvConfPath, err)
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (h *guestNetworkHandler) configureInterface(iface *guestnetwork.GuestNetworkInterface) error {
+	link, err := h.findLink(iface.MacAddress)
+	if err != nil {
+		return err
+	}
+
+	if err := h.netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("failed to set link up: %w", err)
+	}
+
+	for _, cidr := range iface.Addrs {
+		addr, err := netlink.ParseAddr(cidr)
+		if err != nil {
+			return fmt.Errorf("failed to parse address %q: %w", cidr, err)
+		}
+		// The VM is the only user of the interface, so skip the duplicate address detection
+		// which would leave the IPv6 addresses unusable for a few seconds.
+		if addr.IP.To4() == nil {
+			addr.Flags |= unix.IFA_F_NODAD
+		}
+		if err := h.netlink.AddrReplace(link, addr); err != nil {
+			return fmt.Errorf("failed to add address %q: %w", cidr, err)
+		}
+	}
+
+	if iface.IPv6GatewayAddr != "" {
+		gateway := net.ParseIP(iface.IPv6GatewayAddr)
+		if gateway == nil {
+			return fmt.Errorf("invalid IPv6 gateway %q", iface.IPv6GatewayAddr)
+		}
+		_, defaultDst, _ := net.ParseCIDR("::/0")
+		err := h.netlink.RouteReplace(&netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Dst:       defaultDst,
+			Gw:        gateway,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add IPv6 default route via %q: %w", iface.IPv6GatewayAddr, err)
+		}
+	}
+
+	for _, r := range iface.Routes {
+		_, dst, err := net.ParseCIDR(r.Destination)
+		if err != nil {
+			return fmt.Errorf("failed to parse route destination %q: %w", r.Destination, err)
+		}
+
+		route := &netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Dst:       dst,
+			Priority:  int(r.Metric),
+		}
+		if r.GatewayAddr != "" {
+			route.Gw = net.ParseIP(r.GatewayAddr)
+			if route.Gw == nil {
+				return fmt.Errorf("invalid route gateway %q", r.GatewayAddr)
+			}
+		} else {
+			route.Scope = netlink.SCOPE_LINK
+		}
+
+		if err := h.netlink.RouteReplace(route); err != nil {
+			return fmt.Errorf("failed to add route to %q: %w", r.Destination, err)
+		}
+	}
+
+	return nil
+}
+
+// findLink returns the link with the provided MAC address.
+func (h *guestNetworkHandler) findLink(macAddress string) (netlink.Link, error) {
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MAC address: %w", err)
+	}
+
+	links, err := h.netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	for _, link := range links {
+		if bytes.Equal(link.Attrs().HardwareAddr, mac) {
+			return link, nil
+		}
+	}
+	return nil, fmt.Errorf("no link with MAC address %q", macAddress)
+}
+
+// writeResolvConf replaces resolv.conf, which is a symlink to /proc/net/pnp in the default rootfs,
+// with the provided DNS configuration.
+func (h *guestNetworkHandler) writeResolvConf(dns *guestnetwork.GuestDNSConfiguration) error {
+	var b strings.Builder
+	for _, nameserver := range dns.Nameservers {
+		fmt.Fprintf(&b, "nameserver %s\n", nameserver)
+	}
+	if len(dns.SearchDomains) > 0 {
+		fmt.Fprintf(&b, "search %s\n", strings.Join(dns.SearchDomains, " "))
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(h.resolvConfPath), ".resolv.conf")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), h.resolvConfPath)
+}
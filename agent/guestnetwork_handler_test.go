@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	guestnetwork "github.com/firecracker-microvm/firecracker-containerd/proto/service/guestnetwork/ttrpc"
+)
+
+// fakeNetlink records the changes made to its links instead of applying them.
+type fakeNetlink struct {
+	links  []netlink.Link
+	up     []string
+	addrs  []*netlink.Addr
+	routes []*netlink.Route
+}
+
+func (f *fakeNetlink) LinkList() ([]netlink.Link, error) {
+	return f.links, nil
+}
+
+func (f *fakeNetlink) LinkSetUp(link netlink.Link) error {
+	f.up = append(f.up, link.Attrs().Name)
+	return nil
+}
+
+func (f *fakeNetlink) AddrReplace(_ netlink.Link, addr *netlink.Addr) error {
+	f.addrs = append(f.addrs, addr)
+	return nil
+}
+
+func (f *fakeNetlink) RouteReplace(route *netlink.Route) error {
+	f.routes = append(f.routes, route)
+	return nil
+}
+
+func newFakeNetlink(t *testing.T) *fakeNetlink {
+	var links []netlink.Link
+	for i, mac := range []string{"AA:FC:00:00:00:01", "AA:FC:00:00:00:02"} {
+		hardwareAddr, err := net.ParseMAC(mac)
+		require.NoError(t, err)
+		links = append(links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{
+			Index:        i + 2,
+			Name:         "eth" + string(rune('0'+i)),
+			HardwareAddr: hardwareAddr,
+		}})
+	}
+	return &fakeNetlink{links: links}
+}
+
+func TestConfigureNetwork(t *testing.T) {
+	fake := newFakeNetlink(t)
+	resolvConfPath := filepath.Join(t.TempDir(), "resolv.conf")
+	require.NoError(t, os.Symlink("/proc/net/pnp", resolvConfPath))
+
+	h := &guestNetworkHandler{netlink: fake, resolvConfPath: resolvConfPath}
+	_, err := h.ConfigureNetwork(context.Background(), &guestnetwork.ConfigureNetworkRequest{
+		NetworkInterfaces: []*guestnetwork.GuestNetworkInterface{{
+			MacAddress:      "aa:fc:00:00:00:02",
+			Addrs:           []string{"198.51.100.3/24", "2001:db8::2/64"},
+			IPv6GatewayAddr: "2001:db8::1",
+			Routes: []*proto.IPRoute{
+				{Destination: "203.0.113.0/24", GatewayAddr: "198.51.100.254", Metric: 10},
+				{Destination: "192.0.2.0/24"},
+			},
+		}},
+		DNS: &guestnetwork.GuestDNSConfiguration{
+			Nameservers:   []string{"2001:db8::53", "192.0.2.1"},
+			SearchDomains: []string{"example.com", "example.org"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"eth1"}, fake.up)
+
+	require.Len(t, fake.addrs, 2)
+	assert.Equal(t, "198.51.100.3/24", fake.addrs[0].IPNet.String())
+	assert.Zero(t, fake.addrs[0].Flags&unix.IFA_F_NODAD)
+	assert.Equal(t, "2001:db8::2/64", fake.addrs[1].IPNet.String())
+	assert.NotZero(t, fake.addrs[1].Flags&unix.IFA_F_NODAD, "duplicate address detection should be skipped")
+
+	require.Len(t, fake.routes, 3)
+	assert.Equal(t, "::/0", fake.routes[0].Dst.String())
+	assert.Equal(t, "2001:db8::1", fake.routes[0].Gw.String())
+	assert.Equal(t, 3, fake.routes[0].LinkIndex)
+	assert.Equal(t, "203.0.113.0/24", fake.routes[1].Dst.String())
+	assert.Equal(t, "198.51.100.254", fake.routes[1].Gw.String())
+	assert.Equal(t, 10, fake.routes[1].Priority)
+	assert.Equal(t, "192.0.2.0/24", fake.routes[2].Dst.String())
+	assert.Nil(t, fake.routes[2].Gw)
+	assert.Equal(t, netlink.SCOPE_LINK, fake.routes[2].Scope)
+
+	info, err := os.Lstat(resolvConfPath)
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular(), "the resolv.conf symlink should be replaced")
+
+	resolvConf, err := os.ReadFile(resolvConfPath)
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 2001:db8::53\nnameserver 192.0.2.1\nsearch example.com example.org\n", string(resolvConf))
+}
+
+func TestConfigureNetworkUnknownLink(t *testing.T) {
+	resolvConfPath := filepath.Join(t.TempDir(), "resolv.conf")
+	h := &guestNetworkHandler{netlink: newFakeNetlink(t), resolvConfPath: resolvConfPath}
+
+	_, err := h.ConfigureNetwork(context.Background(), &guestnetwork.ConfigureNetworkRequest{
+		NetworkInterfaces: []*guestnetwork.GuestNetworkInterface{{
+			MacAddress: "AA:FC:00:00:00:03",
+			Addrs:      []string{"198.51.100.3/24"},
+		}},
+	})
+	assert.Error(t, err)
+
+	_, err = os.Stat(resolvConfPath)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "resolv.conf should be left as is without DNS configuration")
+}
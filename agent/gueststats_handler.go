@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gueststats "github.com/firecracker-microvm/firecracker-containerd/proto/service/gueststats/ttrpc"
+)
+
+// guestStatsHandler implements GuestStatsService by reading the memory usage
+// and load of the guest kernel from procfs.
+type guestStatsHandler struct {
+	procPath string
+}
+
+var _ gueststats.GuestStatsService = &guestStatsHandler{}
+
+func newGuestStatsHandler() *guestStatsHandler {
+	return &guestStatsHandler{procPath: "/proc"}
+}
+
+// GetGuestStats returns the values of /proc/meminfo and /proc/loadavg.
+func (h *guestStatsHandler) GetGuestStats(_ context.Context, _ *gueststats.GetGuestStatsRequest) (*gueststats.GetGuestStatsResponse, error) {
+	meminfo, err := readMeminfo(filepath.Join(h.procPath, "meminfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	loadavg, err := readLoadavg(filepath.Join(h.procPath, "loadavg"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gueststats.GetGuestStatsResponse{
+		MemTotalBytes:     meminfo["MemTotal"],
+		MemFreeBytes:      meminfo["MemFree"],
+		MemAvailableBytes: meminfo["MemAvailable"],
+		BuffersBytes:      meminfo["Buffers"],
+		CachedBytes:       meminfo["Cached"],
+		SwapTotalBytes:    meminfo["SwapTotal"],
+		SwapFreeBytes:     meminfo["SwapFree"],
+		Load1:             loadavg[0],
+		Load5:             loadavg[1],
+		Load15:            loadavg[2],
+	}, nil
+}
+
+// readMeminfo returns the values of a meminfo file in bytes, keyed by name.
+func readMeminfo(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// lines are formatted as "MemTotal:       1012012 kB"
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s in %q: %w", name, path, err)
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n *= 1024
+		}
+		values[name] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// readLoadavg returns the 1, 5 and 15 minutes load averages of a loadavg file.
+func readLoadavg(path string) ([3]float64, error) {
+	var loadavg [3]float64
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return loadavg, err
+	}
+
+	// the file is formatted as "0.20 0.18 0.12 1/80 11206"
+	fields := strings.Fields(string(data))
+	if len(fields) < len(loadavg) {
+		return loadavg, fmt.Errorf("invalid load averages in %q: %q", path, data)
+	}
+	for i := range loadavg {
+		loadavg[i], err = strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return loadavg, fmt.Errorf("invalid load averages in %q: %w", path, err)
+		}
+	}
+	return loadavg, nil
+}
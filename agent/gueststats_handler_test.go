@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gueststats "github.com/firecracker-microvm/firecracker-containerd/proto/service/gueststats/ttrpc"
+)
+
+const testMeminfo = `MemTotal:        1012012 kB
+MemFree:          803456 kB
+MemAvailable:     890112 kB
+Buffers:            2048 kB
+Cached:            99328 kB
+SwapCached:            0 kB
+SwapTotal:             0 kB
+SwapFree:              0 kB
+HugePages_Total:       0
+`
+
+func TestGetGuestStats(t *testing.T) {
+	procPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(procPath, "meminfo"), []byte(testMeminfo), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(procPath, "loadavg"), []byte("0.20 0.18 0.12 1/80 11206\n"), 0644))
+
+	h := &guestStatsHandler{procPath: procPath}
+	resp, err := h.GetGuestStats(context.Background(), &gueststats.GetGuestStatsRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, &gueststats.GetGuestStatsResponse{
+		MemTotalBytes:     1012012 * 1024,
+		MemFreeBytes:      803456 * 1024,
+		MemAvailableBytes: 890112 * 1024,
+		BuffersBytes:      2048 * 1024,
+		CachedBytes:       99328 * 1024,
+		Load1:             0.20,
+		Load5:             0.18,
+		Load15:            0.12,
+	}, resp)
+}
+
+func TestReadLoadavgInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loadavg")
+	require.NoError(t, os.WriteFile(path, []byte("0.20\n"), 0644))
+
+	_, err := readLoadavg(path)
+	assert.Error(t, err)
+}
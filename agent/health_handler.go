@@ -0,0 +1,149 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	health "github.com/firecracker-microvm/firecracker-containerd/proto/service/health/ttrpc"
+)
+
+// healthHandler implements HealthService by reporting the state of the agent and of the guest
+// kernel, read from procfs.
+type healthHandler struct {
+	procPath     string
+	startedAt    time.Time
+	driveHandler *driveHandler
+	taskManager  vm.TaskManager
+}
+
+var _ health.HealthService = &healthHandler{}
+
+func newHealthHandler(dh *driveHandler, taskManager vm.TaskManager) *healthHandler {
+	return &healthHandler{
+		procPath:     "/proc",
+		startedAt:    time.Now(),
+		driveHandler: dh,
+		taskManager:  taskManager,
+	}
+}
+
+// GetHealth returns the uptime and version of the agent and of the guest kernel, along with
+// the load, memory, mounted drives and tasks of the guest.
+func (h *healthHandler) GetHealth(_ context.Context, _ *health.GetHealthRequest) (*health.GetHealthResponse, error) {
+	uptime, err := readUptime(filepath.Join(h.procPath, "uptime"))
+	if err != nil {
+		return nil, err
+	}
+
+	osrelease, err := os.ReadFile(filepath.Join(h.procPath, "sys", "kernel", "osrelease"))
+	if err != nil {
+		return nil, err
+	}
+
+	loadavg, err := readLoadavg(filepath.Join(h.procPath, "loadavg"))
+	if err != nil {
+		return nil, err
+	}
+
+	meminfo, err := readMeminfo(filepath.Join(h.procPath, "meminfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	mountedDrives, err := h.mountedDrives()
+	if err != nil {
+		return nil, err
+	}
+
+	return &health.GetHealthResponse{
+		AgentUptimeSeconds: time.Since(h.startedAt).Seconds(),
+		UptimeSeconds:      uptime,
+		AgentRevision:      revision,
+		KernelVersion:      strings.TrimSpace(string(osrelease)),
+		Load1:              loadavg[0],
+		Load5:              loadavg[1],
+		Load15:             loadavg[2],
+		MemTotalBytes:      meminfo["MemTotal"],
+		MemAvailableBytes:  meminfo["MemAvailable"],
+		MountedDrives:      mountedDrives,
+		TaskCount:          uint32(h.taskManager.TaskCount()),
+	}, nil
+}
+
+// mountedDrives returns the stub drives which are the source of a mount of the guest.
+func (h *healthHandler) mountedDrives() ([]*health.MountedDrive, error) {
+	driveIDs := make(map[string]string)
+	for _, d := range h.driveHandler.Drives() {
+		driveIDs[d.Path()] = d.DriveID
+	}
+
+	path := filepath.Join(h.procPath, "mounts")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounted []*health.MountedDrive
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// lines are formatted as "/dev/vdb /container/rootfs ext4 rw,relatime 0 0"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		driveID, ok := driveIDs[fields[0]]
+		if !ok {
+			continue
+		}
+		mounted = append(mounted, &health.MountedDrive{DriveID: driveID, MountPoint: fields[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(mounted, func(i, j int) bool {
+		return mounted[i].MountPoint < mounted[j].MountPoint
+	})
+	return mounted, nil
+}
+
+// readUptime returns the uptime in seconds of an uptime file.
+func readUptime(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	// the file is formatted as "350735.47 234388.90"
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid uptime in %q: %q", path, data)
+	}
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid uptime in %q: %w", path, err)
+	}
+	return uptime, nil
+}
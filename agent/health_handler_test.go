@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	health "github.com/firecracker-microvm/firecracker-containerd/proto/service/health/ttrpc"
+)
+
+const testMounts = `proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/vda / ext4 rw,relatime 0 0
+/dev/vdc /container/b/rootfs ext4 rw,relatime 0 0
+/dev/vdb /container/a/rootfs ext4 rw,relatime 0 0
+`
+
+func TestGetHealth(t *testing.T) {
+	procPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(procPath, "sys", "kernel"), 0755))
+	for name, content := range map[string]string{
+		"uptime":               "350.47 234.90\n",
+		"sys/kernel/osrelease": "5.10.186\n",
+		"loadavg":              "0.20 0.18 0.12 1/80 11206\n",
+		"meminfo":              testMeminfo,
+		"mounts":               testMounts,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(procPath, name), []byte(content), 0644))
+	}
+
+	dh := &driveHandler{
+		drives: map[string]drive{
+			"drive-a":   {Name: "vdb", DriveID: "drive-a", DrivePath: "/dev"},
+			"drive-b":   {Name: "vdc", DriveID: "drive-b", DrivePath: "/dev"},
+			"unmounted": {Name: "vdd", DriveID: "unmounted", DrivePath: "/dev"},
+		},
+	}
+	h := &healthHandler{
+		procPath:     procPath,
+		startedAt:    time.Now().Add(-time.Minute),
+		driveHandler: dh,
+		taskManager:  vm.NewTaskManager(context.Background(), logrus.NewEntry(logrus.New())),
+	}
+
+	resp, err := h.GetHealth(context.Background(), &health.GetHealthRequest{})
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, resp.AgentUptimeSeconds, 60.0)
+	assert.Equal(t, 350.47, resp.UptimeSeconds)
+	assert.Equal(t, "5.10.186", resp.KernelVersion)
+	assert.Equal(t, 0.20, resp.Load1)
+	assert.Equal(t, 0.12, resp.Load15)
+	assert.Equal(t, uint64(1012012*1024), resp.MemTotalBytes)
+	assert.Equal(t, uint64(890112*1024), resp.MemAvailableBytes)
+	require.Len(t, resp.MountedDrives, 2)
+	assert.Equal(t, "drive-a", resp.MountedDrives[0].DriveID)
+	assert.Equal(t, "/container/a/rootfs", resp.MountedDrives[0].MountPoint)
+	assert.Equal(t, "drive-b", resp.MountedDrives[1].DriveID)
+	assert.Equal(t, uint32(0), resp.TaskCount)
+}
+
+func TestReadUptimeInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uptime")
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0644))
+
+	_, err := readUptime(path)
+	assert.Error(t, err)
+}
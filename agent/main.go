@@ -35,8 +35,12 @@ import (
 
 	"github.com/firecracker-microvm/firecracker-containerd/eventbridge"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/event"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
+	guestnetwork "github.com/firecracker-microvm/firecracker-containerd/proto/service/guestnetwork/ttrpc"
+	gueststats "github.com/firecracker-microvm/firecracker-containerd/proto/service/gueststats/ttrpc"
+	health "github.com/firecracker-microvm/firecracker-containerd/proto/service/health/ttrpc"
 	ioproxy "github.com/firecracker-microvm/firecracker-containerd/proto/service/ioproxy/ttrpc"
 )
 
@@ -55,14 +59,19 @@ var (
 
 func main() {
 	var (
-		port    int
-		debug   bool
-		version bool
+		port       int
+		debug      bool
+		version    bool
+		tracingCfg tracing.Config
 	)
 
 	flag.IntVar(&port, "port", defaultPort, "Vsock port to listen to")
 	flag.BoolVar(&debug, "debug", false, "Turn on debug mode")
 	flag.BoolVar(&version, "version", false, "Show the version")
+	flag.StringVar(&tracingCfg.Exporter, "trace-exporter", "", `Export spans to an OTLP collector ("otlp") or to a file ("file")`)
+	flag.StringVar(&tracingCfg.Endpoint, "trace-endpoint", "", "host:port of the OTLP collector spans are exported to")
+	flag.BoolVar(&tracingCfg.Insecure, "trace-insecure", false, "Connect to the OTLP collector without TLS")
+	flag.StringVar(&tracingCfg.Path, "trace-file", "", "Path of the file spans are appended to")
 	flag.Parse()
 
 	if debug {
@@ -90,9 +99,14 @@ func main() {
 	// This can be wrapped to add missing functionality (like
 	// running multiple containers inside one Firecracker VM)
 
+	shutdownTracing, err := tracing.Init(shimCtx, "firecracker-containerd-agent", tracingCfg)
+	if err != nil {
+		log.G(shimCtx).WithError(err).Fatal("failed to set up tracing")
+	}
+
 	log.G(shimCtx).Info("creating task service")
 
-	server, err := ttrpc.NewServer()
+	server, err := ttrpc.NewServer(ttrpc.WithUnaryServerInterceptor(tracing.UnaryServerInterceptor))
 	if err != nil {
 		log.G(shimCtx).WithError(err).Fatal("failed to create ttrpc server")
 	}
@@ -117,6 +131,10 @@ func main() {
 		taskManager: taskService.taskManager,
 	})
 
+	gueststats.RegisterGuestStatsService(server, newGuestStatsHandler())
+	guestnetwork.RegisterGuestNetworkService(server, newGuestNetworkHandler())
+	health.RegisterHealthService(server, newHealthHandler(dh, taskService.taskManager))
+
 	// Run ttrpc over vsock
 
 	vsockLogger := log.G(shimCtx).WithField("port", port)
@@ -168,6 +186,10 @@ func main() {
 	err = group.Wait()
 	log.G(shimCtx).Info("shutting down agent")
 
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.G(shimCtx).WithError(err).Warn("failed to flush spans")
+	}
+
 	if err != nil && err != context.Canceled {
 		log.G(shimCtx).WithError(err).Error("shim error")
 		panic(err)
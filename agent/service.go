@@ -32,9 +32,11 @@ import (
 	"github.com/containerd/log"
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sys/unix"
 
 	"github.com/firecracker-microvm/firecracker-containerd/internal/bundle"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 )
@@ -151,6 +153,9 @@ func (ts *TaskService) doCleanup(taskExecID string) error {
 
 // Create creates a new initial process and container using runc
 func (ts *TaskService) Create(requestCtx context.Context, req *taskAPI.CreateTaskRequest) (_ *taskAPI.CreateTaskResponse, err error) {
+	requestCtx, span := tracing.StartSpan(requestCtx, "Create", attribute.String("task_id", req.ID))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	logger := log.G(requestCtx).WithFields(logrus.Fields{
 		"name":    "Create",
 		"task_id": req.ID,
@@ -202,6 +207,17 @@ func (ts *TaskService) Create(requestCtx context.Context, req *taskAPI.CreateTas
 		if err := os.MkdirAll(bundleDir.RootfsPath(), 0700); err != nil {
 			return nil, fmt.Errorf("Failed to create bundle's rootfs path from inside the vm %q: %w", bundleDir.RootfsPath(), err)
 		}
+	} else if len(req.Rootfs) > 0 && vm.IsOverlayMount(req.Rootfs[0]) {
+		// The layers of an overlay rootfs were mounted by previous MountDrive calls. Assemble
+		// them into the bundle's rootfs here.
+		overlay, err := vm.ParseOverlayMount(req.Rootfs[0])
+		if err != nil {
+			return nil, err
+		}
+		if err := mountOverlayRootfs(bundleDir, overlay); err != nil {
+			return nil, err
+		}
+		req.Rootfs = nil
 	}
 
 	// check the rootfs dir has been created (presumed to be by a previous MountDrive call)
@@ -296,6 +312,31 @@ func (ts *TaskService) Create(requestCtx context.Context, req *taskAPI.CreateTas
 	return resp, nil
 }
 
+// mountOverlayRootfs mounts the overlay of the given layers at the bundle's rootfs. The upper
+// and work dirs of the overlay are created on the upper layer's drive if they don't exist yet.
+// The mount is undone by the cleanup of the bundle's rootfs.
+func mountOverlayRootfs(bundleDir bundle.Dir, overlay *vm.OverlayMount) error {
+	for _, dir := range []string{overlay.UpperDir, overlay.WorkDir, bundleDir.RootfsPath()} {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("failed to create overlay dir %q: %w", dir, err)
+		}
+	}
+
+	rootfsMount := overlay.Mount()
+	err := mount.All([]mount.Mount{{
+		Type:    rootfsMount.Type,
+		Source:  rootfsMount.Source,
+		Options: rootfsMount.Options,
+	}}, bundleDir.RootfsPath())
+	if err != nil {
+		return fmt.Errorf("failed to mount overlay rootfs at %q: %w", bundleDir.RootfsPath(), err)
+	}
+	return nil
+}
+
 // State returns process state information
 func (ts *TaskService) State(requestCtx context.Context, req *taskAPI.StateRequest) (*taskAPI.StateResponse, error) {
 	logger := log.G(requestCtx).WithFields(logrus.Fields{
@@ -443,6 +484,14 @@ func (ts *TaskService) Checkpoint(requestCtx context.Context, req *taskAPI.Check
 	defer logPanicAndDie(logger)
 	logger.Debug("checkpoint")
 
+	// The path is on a scratch drive mounted by the runtime shim, which copies the
+	// checkpoint out of the VM once it is written.
+	if err := os.MkdirAll(req.Path, 0700); err != nil {
+		err = fmt.Errorf("failed to create checkpoint dir %q: %w", req.Path, err)
+		logger.WithError(err).Error("checkpoint failed")
+		return nil, err
+	}
+
 	resp, err := ts.runcService.Checkpoint(requestCtx, req)
 	if err != nil {
 		logger.WithError(err).Error("checkpoint failed")
@@ -22,6 +22,7 @@ import (
 
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/debug"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
 )
@@ -38,6 +39,12 @@ const (
 	defaultCPUTemplate = models.CPUTemplateT2
 	defaultShimBaseDir = "/var/lib/firecracker-containerd/shim-base"
 	runcConfigPath     = "/etc/containerd/firecracker-runc-config.json"
+	jailerBinaryPath   = "jailer"
+
+	defaultOverlayFilesystemType = "ext4"
+
+	defaultHealthCheckTimeoutSeconds   = 5
+	defaultHealthCheckFailureThreshold = 3
 )
 
 // Config represents runtime configuration parameters
@@ -58,6 +65,16 @@ type Config struct {
 	// directory.
 	ShimBaseDir  string       `json:"shim_base_dir"`
 	JailerConfig JailerConfig `json:"jailer"`
+	// WarmPools configures pools of idle VMs which the firecracker-control plugin boots ahead
+	// of time. A CreateVM call matching the profile of a pool is served by one of its VMs.
+	WarmPools []WarmPoolConfig `json:"warm_pools"`
+	// Tracing configures how the runtime shims export the spans of the VMs they manage.
+	Tracing tracing.Config `json:"tracing"`
+	// HealthCheck configures how the runtime shims poll the agents of the VMs they manage.
+	HealthCheck HealthCheckConfig `json:"health_check"`
+	// OverlayFilesystemType is the filesystem of the images backing the layers of overlay
+	// rootfs mounts.
+	OverlayFilesystemType string `json:"overlay_filesystem_type"`
 
 	DebugHelper *debug.Helper `json:"-"`
 }
@@ -65,8 +82,61 @@ type Config struct {
 // JailerConfig houses a set of configurable values for jailing
 // TODO: Add netns field
 type JailerConfig struct {
+	// Backend is the jailer used by CreateVM requests which don't select one,
+	// either RuncJailerBackend (the default) or FirecrackerJailerBackend.
+	Backend        string `json:"backend"`
 	RuncBinaryPath string `json:"runc_binary_path"`
 	RuncConfigPath string `json:"runc_config_path"`
+	// JailerBinaryPath is the path to Firecracker's jailer binary used by the
+	// FirecrackerJailerBackend.
+	JailerBinaryPath string `json:"jailer_binary_path"`
+	// CgroupVersion is the version of the cgroup filesystem, "1" or "2", used by
+	// Firecracker's jailer. The jailer's default is used if unset.
+	CgroupVersion string `json:"cgroup_version"`
+}
+
+const (
+	// RuncJailerBackend runs the Firecracker VMM in a runc container.
+	RuncJailerBackend = "runc"
+	// FirecrackerJailerBackend runs the Firecracker VMM with Firecracker's own jailer binary.
+	FirecrackerJailerBackend = "firecracker"
+)
+
+// HealthCheckConfig configures the health checks of the VM agents. A VM is marked unhealthy
+// once FailureThreshold consecutive health checks failed, and healthy again once one succeeds.
+type HealthCheckConfig struct {
+	// IntervalSeconds is how often the agent is polled. Health checks are disabled if 0, which
+	// is the default as agents without the Health service would be marked unhealthy.
+	IntervalSeconds int `json:"interval_seconds"`
+	// TimeoutSeconds bounds how long a health check may take before it fails.
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// FailureThreshold is the number of consecutive failed health checks after which the VM
+	// is marked unhealthy.
+	FailureThreshold int `json:"failure_threshold"`
+}
+
+// WarmPoolConfig configures a pool of idle VMs booted ahead of time for a profile. The profile
+// fields have the same meaning as the CreateVMRequest fields of the same name. A CreateVM call
+// matches the profile if those fields are equal and it requests no other VM configuration;
+// its VMID, metadata, ExitAfterAllTasksDeleted and TimeoutSeconds may be set freely.
+type WarmPoolConfig struct {
+	// Name identifies the pool. It is used in the VMIDs of the pool's idle VMs.
+	Name string `json:"name"`
+	// Namespace is the containerd namespace the pool's VMs are created in. Defaults to "default".
+	Namespace string `json:"namespace"`
+	// Size is the number of idle VMs the pool keeps booted.
+	Size int `json:"size"`
+	// MaxIdleSeconds is how long a VM may stay idle before it is replaced by a new one. Idle
+	// VMs are never replaced if unset.
+	MaxIdleSeconds int `json:"max_idle_seconds"`
+
+	KernelImagePath   string                                 `json:"kernel_image_path"`
+	KernelArgs        string                                 `json:"kernel_args"`
+	RootDrive         *proto.FirecrackerRootDrive            `json:"root_drive"`
+	MachineCfg        *proto.FirecrackerMachineConfiguration `json:"machine_cfg"`
+	ContainerCount    int32                                  `json:"container_count"`
+	MinContainerCount int32                                  `json:"min_container_count"`
+	MaxContainerCount int32                                  `json:"max_container_count"`
 }
 
 // LoadConfig loads configuration from JSON file at 'path'
@@ -90,7 +160,14 @@ func LoadConfig(path string) (*Config, error) {
 		RootDrive:       defaultRootfsPath,
 		ShimBaseDir:     defaultShimBaseDir,
 		JailerConfig: JailerConfig{
-			RuncConfigPath: runcConfigPath,
+			Backend:          RuncJailerBackend,
+			RuncConfigPath:   runcConfigPath,
+			JailerBinaryPath: jailerBinaryPath,
+		},
+		OverlayFilesystemType: defaultOverlayFilesystemType,
+		HealthCheck: HealthCheckConfig{
+			TimeoutSeconds:   defaultHealthCheckTimeoutSeconds,
+			FailureThreshold: defaultHealthCheckFailureThreshold,
 		},
 	}
 
@@ -106,6 +183,15 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config from %q: %w", path, err)
 	}
 
+	hc := cfg.HealthCheck
+	if hc.IntervalSeconds < 0 {
+		return nil, fmt.Errorf("invalid health check config in %q: interval_seconds cannot be negative", path)
+	}
+	if hc.IntervalSeconds > 0 && (hc.TimeoutSeconds <= 0 || hc.FailureThreshold <= 0) {
+		return nil, fmt.Errorf("invalid health check config in %q: "+
+			"timeout_seconds and failure_threshold must be positive", path)
+	}
+
 	cfg.DebugHelper, err = debug.New(cfg.LogLevels...)
 	if err != nil {
 		return nil, err
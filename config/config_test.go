@@ -32,6 +32,34 @@ func TestLoadConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultKernelArgs, cfg.KernelArgs, "expected default kernel args")
 	assert.Equal(t, defaultKernelPath, cfg.KernelImagePath, "expected default kernel path")
 	assert.Equal(t, defaultRootfsPath, cfg.RootDrive, "expected default rootfs path")
+	assert.Equal(t, RuncJailerBackend, cfg.JailerConfig.Backend, "expected default jailer backend")
+	assert.Equal(t, "ext4", cfg.OverlayFilesystemType, "expected default overlay filesystem type")
+	assert.Equal(t, HealthCheckConfig{IntervalSeconds: 0, TimeoutSeconds: 5, FailureThreshold: 3}, cfg.HealthCheck,
+		"expected health checks to be disabled by default")
+}
+
+func TestLoadConfigHealthCheck(t *testing.T) {
+	configFile, cleanup := createTempConfig(t, `{"health_check": {"interval_seconds": 10}}`)
+	defer cleanup()
+	cfg, err := LoadConfig(configFile)
+	assert.NoError(t, err, "failed to load config")
+	assert.Equal(t, HealthCheckConfig{IntervalSeconds: 10, TimeoutSeconds: 5, FailureThreshold: 3}, cfg.HealthCheck)
+
+	configFile, cleanup = createTempConfig(t, `{"health_check": {"interval_seconds": 10, "failure_threshold": 0}}`)
+	defer cleanup()
+	_, err = LoadConfig(configFile)
+	assert.Error(t, err, "expected invalid failure threshold error")
+
+	configFile, cleanup = createTempConfig(t, `{"health_check": {"timeout_seconds": 0}}`)
+	defer cleanup()
+	cfg, err = LoadConfig(configFile)
+	assert.NoError(t, err, "the timeout of disabled health checks should not be validated")
+	assert.Equal(t, 0, cfg.HealthCheck.IntervalSeconds, "health checks should be disabled")
+
+	configFile, cleanup = createTempConfig(t, `{"health_check": {"interval_seconds": -1}}`)
+	defer cleanup()
+	_, err = LoadConfig(configFile)
+	assert.Error(t, err, "expected invalid interval error")
 }
 
 func TestLoadConfigOverrides(t *testing.T) {
@@ -63,6 +91,32 @@ func TestLoadConfigOverrides(t *testing.T) {
 	assert.True(t, cfg.DebugHelper.LogFirecrackerOutput())
 }
 
+func TestLoadConfigWarmPools(t *testing.T) {
+	configContent := `{
+		"warm_pools": [{
+			"name": "small",
+			"size": 2,
+			"max_idle_seconds": 600,
+			"root_drive": {"HostPath": "/rootfs.img"},
+			"machine_cfg": {"VcpuCount": 1, "MemSizeMib": 256},
+			"container_count": 1
+		}]
+	}`
+	configFile, cleanup := createTempConfig(t, configContent)
+	defer cleanup()
+	cfg, err := LoadConfig(configFile)
+	assert.NoError(t, err, "failed to load config")
+
+	assert.Len(t, cfg.WarmPools, 1)
+	pool := cfg.WarmPools[0]
+	assert.Equal(t, "small", pool.Name)
+	assert.Equal(t, 2, pool.Size)
+	assert.Equal(t, 600, pool.MaxIdleSeconds)
+	assert.Equal(t, "/rootfs.img", pool.RootDrive.HostPath)
+	assert.Equal(t, uint32(256), pool.MachineCfg.MemSizeMib)
+	assert.Equal(t, int32(1), pool.ContainerCount)
+}
+
 func createTempConfig(t *testing.T, contents string) (string, func()) {
 	t.Helper()
 	configFile, err := os.CreateTemp("", "config")
@@ -20,7 +20,9 @@ import (
 	"fmt"
 
 	"github.com/containerd/containerd/pkg/ttrpcutil"
+	"github.com/containerd/ttrpc"
 
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 	fccontrol "github.com/firecracker-microvm/firecracker-containerd/proto/service/fccontrol/ttrpc"
 )
 
@@ -33,7 +35,7 @@ type Client struct {
 
 // New creates a new firecracker-control service client
 func New(ttrpcAddress string) (*Client, error) {
-	ttrpcClient, err := ttrpcutil.NewClient(ttrpcAddress)
+	ttrpcClient, err := ttrpcutil.NewClient(ttrpcAddress, ttrpc.WithUnaryClientInterceptor(tracing.UnaryClientInterceptor))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ttrpc client: %w", err)
 	}
@@ -52,6 +52,7 @@ import (
 	_ "github.com/containerd/containerd/services/transfer"
 	_ "github.com/containerd/containerd/services/version"
 	_ "github.com/containerd/containerd/services/warning"
+	_ "github.com/containerd/containerd/tracing/plugin"
 
 	// Linux specific builtins
 	// See https://github.com/containerd/containerd/blob/main/cmd/containerd/builtins_linux.go
@@ -15,11 +15,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -32,7 +36,10 @@ import (
 	"github.com/containerd/containerd/sys"
 	"github.com/containerd/log"
 	"github.com/hashicorp/go-multierror"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/process"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -46,9 +53,14 @@ import (
 )
 
 var (
-	_               fccontrolTtrpc.FirecrackerService = (*local)(nil)
-	ttrpcAddressEnv                                   = "TTRPC_ADDRESS"
-	stopVMInterval                                    = 10 * time.Millisecond
+	_                  fccontrolTtrpc.FirecrackerService = (*local)(nil)
+	ttrpcAddressEnv                                      = "TTRPC_ADDRESS"
+	stopVMInterval                                       = 10 * time.Millisecond
+	listVMsShimTimeout                                   = 5 * time.Second
+	listVMsConcurrency                                   = 16
+	watchShimInterval                                    = time.Second
+
+	errShimNotRunning = errors.New("shim is not running")
 )
 
 func init() {
@@ -69,6 +81,11 @@ type local struct {
 
 	processesMu sync.Mutex
 	processes   map[string]int32
+	// boundVMIDs maps the VMIDs of warm pool VMs which have been handed out to the VMIDs they
+	// were bound to.
+	boundVMIDs map[string]string
+
+	warmPools []*warmPool
 }
 
 func newLocal(ic *plugin.InitContext) (*local, error) {
@@ -81,18 +98,187 @@ func newLocal(ic *plugin.InitContext) (*local, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &local{
+	s := &local{
 		containerdAddress: ic.Address,
 		logger:            log.G(ic.Context),
 		config:            cfg,
 		processes:         make(map[string]int32),
-	}, nil
+		boundVMIDs:        make(map[string]string),
+	}
+
+	for _, poolCfg := range cfg.WarmPools {
+		pool, err := newWarmPool(poolCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure warm pool: %w", err)
+		}
+		s.warmPools = append(s.warmPools, pool)
+	}
+
+	// The metrics of the shims are served by containerd's metrics server, which serves the
+	// default Prometheus registry.
+	if err := prometheus.Register(newShimCollector(cfg.ShimBaseDir, s.logger)); err != nil {
+		s.logger.WithError(err).Warn("failed to register shim metrics collector")
+	}
+
+	// Shims outlive containerd, so pick up any that were started before containerd restarted.
+	if err := s.reconcileShims(ic.Context); err != nil {
+		s.logger.WithError(err).Warn("failed to reconcile existing shims")
+	}
+
+	for _, pool := range s.warmPools {
+		go s.runWarmPool(ic.Context, pool)
+	}
+
+	return s, nil
+}
+
+// reconcileShims reconciles the shim directories in the shim base directory with the shims
+// still running. Running shims are added to the set of known shim processes and cleaned up after
+// once they exit. The leftovers of shims whose pid file names a process which is no longer
+// running, including their sockets, jails and VMs, are removed. Shim directories without a pid
+// file are left alone.
+func (s *local) reconcileShims(ctx context.Context) error {
+	entries, err := vm.ListShimDirs(s.config.ShimBaseDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		logger := s.logger.WithField("vmID", entry.VMID)
+
+		pid, err := s.shimPid(ctx, entry)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Shims started before pid files were written, or still starting, have none. They
+			// may well be running, so only shims known to have exited are cleaned up.
+			logger.WithError(err).Info("skipping shim without pid file")
+			continue
+		} else if errors.Is(err, errShimNotRunning) {
+			logger.WithError(err).Info("cleaning up after shim")
+			if err := s.cleanupShim(ctx, entry); err != nil {
+				logger.WithError(err).Warn("failed to clean up after shim")
+			}
+			continue
+		} else if err != nil {
+			logger.WithError(err).Warn("skipping shim")
+			continue
+		}
+
+		logger.WithField("pid", pid).Debug("adopting shim")
+		go s.watchShim(ctx, entry, pid)
+
+		if entry.WarmPool != "" {
+			s.adoptWarmVM(ctx, entry)
+		}
+	}
+
+	return nil
+}
+
+// watchShim cleans up after a shim started before containerd restarted once it exits.
+func (s *local) watchShim(ctx context.Context, entry vm.ShimDirEntry, pid int32) {
+	logger := s.logger.WithField("vmID", entry.VMID)
+
+	if err := internal.WaitForPidToExit(ctx, watchShimInterval, pid); err != nil {
+		logger.WithError(err).Debug("stopped watching shim")
+		return
+	}
+
+	logger.Debug("shim has exited")
+	if err := s.cleanupShim(ctx, entry); err != nil {
+		logger.WithError(err).Warn("failed to clean up after shim")
+	}
+}
+
+// cleanupShim removes what is left of a shim that is no longer running: its VM and jail as
+// described by its state file, its sockets and its shim directory.
+func (s *local) cleanupShim(ctx context.Context, entry vm.ShimDirEntry) error {
+	var result *multierror.Error
+
+	// the shim directory is named after the VMID the shim was started with, which differs
+	// from the entry's VMID for warm pool VMs which have been handed out
+	_, shimVMID, _ := strings.Cut(filepath.Base(entry.Dir.RootPath()), "#")
+
+	state, err := entry.Dir.ReadState()
+	if err != nil && !os.IsNotExist(err) {
+		result = multierror.Append(result, err)
+	}
+
+	if state != nil {
+		if err := fcShim.TeardownVM(ctx, entry.Dir, state); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if err := s.removeSockets(entry.Namespace, shimVMID); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if entry.VMID != shimVMID {
+		if err := s.removeSockets(entry.Namespace, entry.VMID); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if err := os.RemoveAll(entry.Dir.RootPath()); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// shimPid returns the PID of the running shim managing the VM in the provided shim directory.
+// Shims not already known are looked up from their pid file and added to the set of known
+// shim processes.
+func (s *local) shimPid(ctx context.Context, entry vm.ShimDirEntry) (int32, error) {
+	socketAddr, err := shim.SocketAddress(namespaces.WithNamespace(ctx, entry.Namespace), s.containerdAddress, entry.VMID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtain shim socket address: %w", err)
+	}
+
+	s.processesMu.Lock()
+	pid, ok := s.processes[socketAddr]
+	s.processesMu.Unlock()
+
+	if !ok {
+		filePid, err := entry.Dir.ReadPidFile()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read shim pid file: %w", err)
+		}
+		pid = int32(filePid)
+	}
+
+	exists, err := process.PidExistsWithContext(ctx, pid)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check if shim process %d exists: %w", pid, err)
+	}
+
+	s.processesMu.Lock()
+	defer s.processesMu.Unlock()
+
+	if !exists {
+		// the shim may have been restarted while its pid was being checked
+		if s.processes[socketAddr] == pid {
+			delete(s.processes, socketAddr)
+		}
+		return 0, fmt.Errorf("%w: process %d", errShimNotRunning, pid)
+	}
+
+	if _, ok := s.processes[socketAddr]; !ok {
+		s.processes[socketAddr] = pid
+	}
+	return pid, nil
 }
 
 // CreateVM creates new Firecracker VM instance. It creates a runtime shim for the VM and the forwards
 // the CreateVM request to that shim. If there is already a VM created with the provided VMID, then
-// AlreadyExists is returned.
+// AlreadyExists is returned. Requests matching a configured warm pool are served by one of the
+// pool's idle VMs when there is one.
 func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
+	return s.createVM(requestCtx, req, "")
+}
+
+// createVM creates a new VM and its shim. When warmPool is set, the VM is booted as an idle VM of
+// that warm pool.
+func (s *local) createVM(requestCtx context.Context, req *proto.CreateVMRequest, warmPool string) (*proto.CreateVMResponse, error) {
 	var err error
 
 	id := req.GetVMID()
@@ -129,6 +315,16 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 		return nil, err
 	}
 
+	if warmPool == "" {
+		resp, ok, err := s.createFromWarmPool(requestCtx, ns, req, shimSocket)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return resp, nil
+		}
+	}
+
 	// If we're here, there is no pre-existing shim for this VMID, so we spawn a new one
 	if err := os.Mkdir(s.config.ShimBaseDir, 0700); err != nil && !os.IsExist(err) {
 		s.logger.WithError(err).Error()
@@ -158,6 +354,15 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 		}
 	}()
 
+	if warmPool != "" {
+		err = shimDir.WriteWarmPool(warmPool)
+		if err != nil {
+			err = fmt.Errorf("failed to write warm pool file: %w", err)
+			s.logger.WithError(err).Error()
+			return nil, err
+		}
+	}
+
 	// TODO we have to create separate listeners for the fccontrol service and shim service because
 	// containerd does not currently expose the shim server for us to register the fccontrol service with too.
 	// This is likely addressable through some relatively small upstream contributions; the following is a stop-gap
@@ -176,7 +381,7 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 		return nil, err
 	}
 
-	cmd, err := s.newShim(ns, id, s.containerdAddress, shimSocket, fcSocket)
+	cmd, err := s.newShim(ns, id, s.containerdAddress, shimSocket, fcSocket, warmPool != "")
 	if err != nil {
 		return nil, err
 	}
@@ -283,6 +488,60 @@ func (s *local) ResumeVM(ctx context.Context, req *proto.ResumeVMRequest) (*type
 	return resp, nil
 }
 
+// CreateSnapshot pauses a VM and writes a snapshot of it
+func (s *local) CreateSnapshot(ctx context.Context, req *proto.CreateSnapshotRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.CreateSnapshot(ctx, req)
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AttachDrive attaches a drive to a running VM and mounts it inside the VM
+func (s *local) AttachDrive(ctx context.Context, req *proto.AttachDriveRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.AttachDrive(ctx, req)
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DetachDrive unmounts and detaches a drive attached with AttachDrive
+func (s *local) DetachDrive(ctx context.Context, req *proto.DetachDriveRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.DetachDrive(ctx, req)
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (s *local) waitForShimToExit(ctx context.Context, vmID string) error {
 	socketAddr, err := shim.SocketAddress(ctx, s.containerdAddress, vmID)
 	if err != nil {
@@ -320,6 +579,97 @@ func (s *local) GetVMInfo(requestCtx context.Context, req *proto.GetVMInfoReques
 	return resp, nil
 }
 
+// ListVMs returns the VMs in the request's namespace. VMs are found from the shim directories
+// in the configured shim base directory, so VMs created before containerd restarted are included.
+func (s *local) ListVMs(requestCtx context.Context, req *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	ns, err := namespaces.NamespaceRequired(requestCtx)
+	if err != nil {
+		err = fmt.Errorf("error retrieving namespace of request: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	entries, err := vm.ListShimDirs(s.config.ShimBaseDir)
+	if err != nil {
+		err = fmt.Errorf("failed to list shim directories: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	var listed []vm.ShimDirEntry
+	for _, entry := range entries {
+		// idle warm pool VMs only become visible once they have been handed out
+		if entry.Namespace != ns || entry.WarmPool != "" {
+			continue
+		}
+		listed = append(listed, entry)
+	}
+
+	// Shims are queried concurrently so that a slow shim only delays the listing by listVMsShimTimeout.
+	resp := &proto.ListVMsResponse{VMs: make([]*proto.VMInfo, len(listed))}
+	var group errgroup.Group
+	group.SetLimit(listVMsConcurrency)
+	for i, entry := range listed {
+		i, entry := i, entry
+		group.Go(func() error {
+			resp.VMs[i] = s.vmInfo(requestCtx, entry)
+			return nil
+		})
+	}
+	group.Wait()
+
+	return resp, nil
+}
+
+// vmInfo builds the VMInfo of the VM in the provided shim directory. If the shim is not running,
+// the VM is reported as stopped. If the shim cannot be reached, only the information available
+// from the shim directory is returned and the state of the VM is unknown.
+func (s *local) vmInfo(requestCtx context.Context, entry vm.ShimDirEntry) *proto.VMInfo {
+	logger := s.logger.WithField("vmID", entry.VMID)
+
+	info := &proto.VMInfo{
+		VMID:       entry.VMID,
+		SocketPath: entry.Dir.FirecrackerSockPath(),
+		VSockPath:  entry.Dir.FirecrackerVSockPath(),
+		State:      proto.VMState_VM_STATE_UNKNOWN,
+	}
+
+	ctx, cancel := context.WithTimeout(requestCtx, listVMsShimTimeout)
+	defer cancel()
+
+	pid, err := s.shimPid(ctx, entry)
+	if err != nil {
+		logger.WithError(err).Debug("shim is not running")
+		info.State = proto.VMState_VM_STATE_STOPPED
+		return info
+	}
+	info.ShimPID = uint32(pid)
+
+	client, err := s.shimFirecrackerClient(ctx, entry.VMID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to create firecracker shim client")
+		return info
+	}
+	defer client.Close()
+
+	// Unlike GetVMInfo, the ListVMs call of a shim doesn't wait for its VM to be created.
+	listResp, err := client.ListVMs(ctx, &proto.ListVMsRequest{})
+	if err != nil || len(listResp.VMs) == 0 {
+		logger.WithError(err).Warn("shim client failed to list vms")
+		return info
+	}
+	resp := listResp.VMs[0]
+
+	info.SocketPath = resp.SocketPath
+	info.VSockPath = resp.VSockPath
+	info.LogFifoPath = resp.LogFifoPath
+	info.MetricsFifoPath = resp.MetricsFifoPath
+	info.CgroupPath = resp.CgroupPath
+	info.State = resp.State
+
+	return info
+}
+
 // SetVMMetadata sets Firecracker instance metadata for the VM with the given VMID.
 func (s *local) SetVMMetadata(requestCtx context.Context, req *proto.SetVMMetadataRequest) (*types.Empty, error) {
 	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
@@ -448,7 +798,97 @@ func (s *local) UpdateBalloonStats(requestCtx context.Context, req *proto.Update
 	return resp, nil
 }
 
-func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.UnixListener, fcSocket *net.UnixListener) (*exec.Cmd, error) {
+// GetVMStats returns the resource usage of a VM as a whole.
+func (s *local) GetVMStats(requestCtx context.Context, req *proto.GetVMStatsRequest) (*proto.GetVMStatsResponse, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+	resp, err := client.GetVMStats(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to get VM stats: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UpdateRateLimiters updates the rate limiters of drives and network interfaces of a running VM.
+func (s *local) UpdateRateLimiters(requestCtx context.Context, req *proto.UpdateRateLimitersRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+	resp, err := client.UpdateRateLimiters(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to update rate limiters: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetVMNetwork returns the network interfaces of a VM as they were resolved when the VM started.
+func (s *local) GetVMNetwork(requestCtx context.Context, req *proto.GetVMNetworkRequest) (*proto.GetVMNetworkResponse, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+	resp, err := client.GetVMNetwork(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to get VM network: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AddNetworkInterface always fails, as Firecracker cannot add network interfaces to a running VM.
+func (s *local) AddNetworkInterface(requestCtx context.Context, req *proto.AddNetworkInterfaceRequest) (*proto.AddNetworkInterfaceResponse, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+	resp, err := client.AddNetworkInterface(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to add network interface: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RemoveNetworkInterface always fails, as Firecracker cannot remove network interfaces from a running VM.
+func (s *local) RemoveNetworkInterface(requestCtx context.Context, req *proto.RemoveNetworkInterfaceRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+	resp, err := client.RemoveNetworkInterface(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to remove network interface: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.UnixListener, fcSocket *net.UnixListener, warmPool bool) (*exec.Cmd, error) {
 	logger := s.logger.WithField("vmID", vmID)
 
 	args := []string{
@@ -495,6 +935,9 @@ func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.Unix
 		fmt.Sprintf("%s=%s", ttrpcAddressEnv, ttrpc),
 		fmt.Sprintf("%s=%s", internal.VMIDEnvVarKey, vmID),
 		fmt.Sprintf("%s=%s", internal.FCSocketFDEnvKey, strconv.Itoa(fcSocketFDNum))) // TODO remove after containerd is updated to expose ttrpc server to shim
+	if warmPool {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=1", internal.WarmPoolEnvVarKey))
+	}
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
@@ -537,11 +980,34 @@ func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.Unix
 			logger.WithError(err).Errorf("failed to remove sockets")
 		}
 
+		// the sockets of a warm pool VM which was handed out are linked from the bound VMID's
+		if boundVMID, ok := s.takeBoundVMID(vmID); ok {
+			if err := s.removeSockets(ns, boundVMID); err != nil {
+				logger.WithError(err).Errorf("failed to remove sockets of %q", boundVMID)
+			}
+		}
+
+		// shims remove their state file once they have cleaned up after their VM, so it is only
+		// left behind by shims which died
+		if state, err := shimDir.ReadState(); err == nil {
+			if err := fcShim.TeardownVM(context.Background(), shimDir, state); err != nil {
+				logger.WithError(err).Error("failed to tear down VM of shim")
+			}
+		}
+
 		if err := os.RemoveAll(shimDir.RootPath()); err != nil {
 			logger.WithError(err).Errorf("failed to remove %q", shimDir.RootPath())
 		}
 	}()
 
+	err = shimDir.WritePidFile(cmd.Process.Pid)
+	if err != nil {
+		err = fmt.Errorf("failed to write shim pid file: %w", err)
+		logger.WithError(err).Error()
+		cmd.Process.Kill()
+		return nil, err
+	}
+
 	err = setShimOOMScore(cmd.Process.Pid)
 	if err != nil {
 		logger.WithError(err).Error()
@@ -562,7 +1028,7 @@ func (s *local) removeSockets(ns string, vmID string) error {
 		result = multierror.Append(result, err)
 	} else {
 		err := shim.RemoveSocket(address)
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			result = multierror.Append(result, err)
 		}
 	}
@@ -572,7 +1038,7 @@ func (s *local) removeSockets(ns string, vmID string) error {
 		result = multierror.Append(result, err)
 	} else {
 		err = shim.RemoveSocket(address)
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			result = multierror.Append(result, err)
 		}
 	}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+func TestReconcileShims(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shimBaseDir := t.TempDir()
+	s := &local{
+		containerdAddress: filepath.Join(t.TempDir(), "containerd.sock"),
+		logger:            log.G(ctx),
+		config:            &config.Config{ShimBaseDir: shimBaseDir},
+		processes:         make(map[string]int32),
+		boundVMIDs:        make(map[string]string),
+	}
+
+	// a shim which exited while containerd was down
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+
+	dead, err := vm.ShimDir(shimBaseDir, "ns", "dead")
+	require.NoError(t, err)
+	require.NoError(t, dead.Mkdir())
+	require.NoError(t, dead.WritePidFile(exited.Process.Pid))
+	require.NoError(t, dead.WriteState(&vm.ShimState{
+		Namespace: "ns",
+		VMID:      "dead",
+		ShimPID:   exited.Process.Pid,
+	}))
+
+	// a shim started by a version of the runtime which did not write pid files, which may
+	// still be running
+	legacy, err := vm.ShimDir(shimBaseDir, "ns", "legacy")
+	require.NoError(t, err)
+	require.NoError(t, legacy.Mkdir())
+
+	live, err := vm.ShimDir(shimBaseDir, "ns", "live")
+	require.NoError(t, err)
+	require.NoError(t, live.Mkdir())
+	require.NoError(t, live.WritePidFile(os.Getpid()))
+
+	require.NoError(t, s.reconcileShims(ctx))
+
+	assert.NoDirExists(t, dead.RootPath())
+	assert.DirExists(t, legacy.RootPath(), "shims without pid file must not be cleaned up")
+	assert.DirExists(t, live.RootPath())
+
+	s.processesMu.Lock()
+	defer s.processesMu.Unlock()
+	assert.Len(t, s.processes, 1)
+	for _, pid := range s.processes {
+		assert.Equal(t, int32(os.Getpid()), pid)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+const (
+	// shimMetricsPrefix is the prefix of the names of the shim metrics exported by the plugin. The
+	// Go runtime and process metrics of the shims are left out as they would clash with containerd's.
+	shimMetricsPrefix = "firecracker_"
+
+	shimMetricsTimeout        = 2 * time.Second
+	maxConcurrentShimScrapes  = 16
+	shimMetricsNamespaceLabel = "namespace"
+	shimMetricsVMIDLabel      = "vm_id"
+)
+
+// shimCollector is a prometheus.Collector exporting the metrics of every running shim through
+// containerd's metrics server. It scrapes the metrics socket of each shim when collected and
+// labels the metrics with the namespace and VMID of the shim's VM.
+type shimCollector struct {
+	shimBaseDir string
+	logger      *logrus.Entry
+}
+
+func newShimCollector(shimBaseDir string, logger *logrus.Entry) *shimCollector {
+	return &shimCollector{shimBaseDir: shimBaseDir, logger: logger}
+}
+
+// Describe implements prometheus.Collector. Nothing is described as the metrics depend on
+// the shims running, which makes the collector unchecked.
+func (c *shimCollector) Describe(_ chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector
+func (c *shimCollector) Collect(ch chan<- prometheus.Metric) {
+	entries, err := vm.ListShimDirs(c.shimBaseDir)
+	if err != nil {
+		c.logger.WithError(err).Warn("failed to list shims to collect metrics from")
+		return
+	}
+
+	var (
+		mu    sync.Mutex
+		group errgroup.Group
+	)
+	group.SetLimit(maxConcurrentShimScrapes)
+	for _, entry := range entries {
+		entry := entry
+		group.Go(func() error {
+			families, err := scrapeShim(entry.Dir.MetricsSockPath())
+			if err != nil {
+				// Shims which are starting, exiting or predate the metrics socket are expected to fail.
+				c.logger.WithError(err).WithField("vmID", entry.VMID).Debug("failed to scrape shim metrics")
+				return nil
+			}
+
+			labels := map[string]string{
+				shimMetricsNamespaceLabel: entry.Namespace,
+				shimMetricsVMIDLabel:      entry.VMID,
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, family := range families {
+				if !strings.HasPrefix(family.GetName(), shimMetricsPrefix) {
+					continue
+				}
+				for _, metric := range family.Metric {
+					ch <- newShimMetric(family, metric, labels)
+				}
+			}
+			return nil
+		})
+	}
+	group.Wait()
+}
+
+// scrapeShim gets the metrics served by a shim at the provided socket path.
+func scrapeShim(socketPath string) (map[string]*dto.MetricFamily, error) {
+	client := &http.Client{
+		Timeout: shimMetricsTimeout,
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://shim/metrics")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+// shimMetric is a prometheus.Metric exporting a metric scraped from a shim with additional labels.
+type shimMetric struct {
+	desc   *prometheus.Desc
+	metric *dto.Metric
+}
+
+func newShimMetric(family *dto.MetricFamily, metric *dto.Metric, labels map[string]string) *shimMetric {
+	pairs := make(map[string]string, len(metric.Label)+len(labels))
+	for _, pair := range metric.Label {
+		pairs[pair.GetName()] = pair.GetValue()
+	}
+	for k, v := range labels {
+		pairs[k] = v
+	}
+
+	labelPairs := make([]*dto.LabelPair, 0, len(pairs))
+	for k, v := range pairs {
+		labelPairs = append(labelPairs, &dto.LabelPair{Name: protobuf.String(k), Value: protobuf.String(v)})
+	}
+	sort.Slice(labelPairs, func(i, j int) bool {
+		return labelPairs[i].GetName() < labelPairs[j].GetName()
+	})
+
+	labelNames := make([]string, 0, len(labelPairs))
+	for _, pair := range labelPairs {
+		labelNames = append(labelNames, pair.GetName())
+	}
+
+	return &shimMetric{
+		desc: prometheus.NewDesc(family.GetName(), family.GetHelp(), labelNames, nil),
+		metric: &dto.Metric{
+			Label:       labelPairs,
+			Gauge:       metric.Gauge,
+			Counter:     metric.Counter,
+			Summary:     metric.Summary,
+			Untyped:     metric.Untyped,
+			Histogram:   metric.Histogram,
+			TimestampMs: metric.TimestampMs,
+		},
+	}
+}
+
+// Desc implements prometheus.Metric
+func (m *shimMetric) Desc() *prometheus.Desc {
+	return m.desc
+}
+
+// Write implements prometheus.Metric
+func (m *shimMetric) Write(out *dto.Metric) error {
+	out.Label = m.metric.Label
+	out.Gauge = m.metric.Gauge
+	out.Counter = m.metric.Counter
+	out.Summary = m.metric.Summary
+	out.Untyped = m.metric.Untyped
+	out.Histogram = m.metric.Histogram
+	out.TimestampMs = m.metric.TimestampMs
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/containerd/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+const shimMetricsResponse = `# HELP firecracker_containerd_shim_stub_drive_reservations_total Stub drives reserved for container rootfs and drive mounts
+# TYPE firecracker_containerd_shim_stub_drive_reservations_total counter
+firecracker_containerd_shim_stub_drive_reservations_total 2
+# HELP firecracker_block_read_count Firecracker metric block_read_count
+# TYPE firecracker_block_read_count gauge
+firecracker_block_read_count 7
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 12
+`
+
+// serveShimMetrics serves metricsResponse at the metrics socket of a new shim dir.
+func serveShimMetrics(t *testing.T, shimBaseDir, namespace, vmID string) {
+	dir, err := vm.ShimDir(shimBaseDir, namespace, vmID)
+	require.NoError(t, err)
+	require.NoError(t, dir.Mkdir())
+
+	listener, err := net.Listen("unix", dir.MetricsSockPath())
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, shimMetricsResponse)
+	})}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+}
+
+func TestShimCollector(t *testing.T) {
+	shimBaseDir := t.TempDir()
+	serveShimMetrics(t, shimBaseDir, "ns", "vm-1")
+	serveShimMetrics(t, shimBaseDir, "ns", "vm-2")
+
+	// a shim which doesn't serve metrics
+	stopped, err := vm.ShimDir(shimBaseDir, "ns", "stopped")
+	require.NoError(t, err)
+	require.NoError(t, stopped.Mkdir())
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(newShimCollector(shimBaseDir, log.G(context.Background()))))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]map[string]float64)
+	for _, family := range families {
+		values[family.GetName()] = make(map[string]float64)
+		for _, metric := range family.Metric {
+			labels := make(map[string]string)
+			for _, pair := range metric.Label {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			assert.Equal(t, "ns", labels[shimMetricsNamespaceLabel])
+			value := metric.GetGauge().GetValue() + metric.GetCounter().GetValue()
+			values[family.GetName()][labels[shimMetricsVMIDLabel]] = value
+		}
+	}
+
+	assert.Equal(t, map[string]map[string]float64{
+		"firecracker_containerd_shim_stub_drive_reservations_total": {"vm-1": 2, "vm-2": 2},
+		"firecracker_block_read_count":                              {"vm-1": 7, "vm-2": 7},
+	}, values, "only the firecracker metrics of the shims serving metrics should be collected")
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/containerd/log"
 	"github.com/containerd/ttrpc"
 
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	fccontrol "github.com/firecracker-microvm/firecracker-containerd/proto/service/fccontrol/ttrpc"
 )
@@ -67,62 +68,116 @@ func (s *service) RegisterTTRPC(server *ttrpc.Server) error {
 	return nil
 }
 
+// traced serves a request in a span, as a child of the span propagated by the client. containerd's
+// ttrpc server, which this service is registered on, doesn't accept interceptors to do so.
+func traced[Req, Resp any](ctx context.Context, method string, req Req, handler func(context.Context, Req) (Resp, error)) (Resp, error) {
+	ctx, span := tracing.StartServerSpan(ctx, "Firecracker/"+method)
+	resp, err := handler(ctx, req)
+	tracing.EndSpan(span, err)
+	return resp, err
+}
+
 func (s *service) CreateVM(ctx context.Context, req *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
 	log.G(ctx).Debugf("create VM request: %+v", req)
-	return s.local.CreateVM(ctx, req)
+	return traced(ctx, "CreateVM", req, s.local.CreateVM)
 }
 
 func (s *service) PauseVM(ctx context.Context, req *proto.PauseVMRequest) (*types.Empty, error) {
 	log.G(ctx).Debugf("pause VM request: %+v", req)
-	return s.local.PauseVM(ctx, req)
+	return traced(ctx, "PauseVM", req, s.local.PauseVM)
 }
 
 func (s *service) ResumeVM(ctx context.Context, req *proto.ResumeVMRequest) (*types.Empty, error) {
 	log.G(ctx).Debugf("resume VM request: %+v", req)
-	return s.local.ResumeVM(ctx, req)
+	return traced(ctx, "ResumeVM", req, s.local.ResumeVM)
+}
+
+func (s *service) CreateSnapshot(ctx context.Context, req *proto.CreateSnapshotRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("create snapshot request: %+v", req)
+	return traced(ctx, "CreateSnapshot", req, s.local.CreateSnapshot)
+}
+
+func (s *service) AttachDrive(ctx context.Context, req *proto.AttachDriveRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("attach drive request: %+v", req)
+	return traced(ctx, "AttachDrive", req, s.local.AttachDrive)
+}
+
+func (s *service) DetachDrive(ctx context.Context, req *proto.DetachDriveRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("detach drive request: %+v", req)
+	return traced(ctx, "DetachDrive", req, s.local.DetachDrive)
 }
 
 func (s *service) StopVM(ctx context.Context, req *proto.StopVMRequest) (*types.Empty, error) {
 	log.G(ctx).Debugf("stop VM: %+v", req)
-	return s.local.StopVM(ctx, req)
+	return traced(ctx, "StopVM", req, s.local.StopVM)
 }
 
 func (s *service) GetVMInfo(ctx context.Context, req *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
 	log.G(ctx).Debugf("get VM info: %+v", req)
-	return s.local.GetVMInfo(ctx, req)
+	return traced(ctx, "GetVMInfo", req, s.local.GetVMInfo)
+}
+
+func (s *service) ListVMs(ctx context.Context, req *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	log.G(ctx).Debug("list VMs")
+	return traced(ctx, "ListVMs", req, s.local.ListVMs)
 }
 
 func (s *service) SetVMMetadata(ctx context.Context, req *proto.SetVMMetadataRequest) (*types.Empty, error) {
 	log.G(ctx).Debug("Setting vm metadata")
-	return s.local.SetVMMetadata(ctx, req)
+	return traced(ctx, "SetVMMetadata", req, s.local.SetVMMetadata)
 }
 
 func (s *service) UpdateVMMetadata(ctx context.Context, req *proto.UpdateVMMetadataRequest) (*types.Empty, error) {
 	log.G(ctx).Debug("Updating vm metadata")
-	return s.local.UpdateVMMetadata(ctx, req)
+	return traced(ctx, "UpdateVMMetadata", req, s.local.UpdateVMMetadata)
 }
 
 func (s *service) GetVMMetadata(ctx context.Context, req *proto.GetVMMetadataRequest) (*proto.GetVMMetadataResponse, error) {
 	log.G(ctx).Debug("Getting vm metadata")
-	return s.local.GetVMMetadata(ctx, req)
+	return traced(ctx, "GetVMMetadata", req, s.local.GetVMMetadata)
 }
 
 func (s *service) GetBalloonConfig(ctx context.Context, req *proto.GetBalloonConfigRequest) (*proto.GetBalloonConfigResponse, error) {
 	log.G(ctx).Debug("Getting balloon configuration")
-	return s.local.GetBalloonConfig(ctx, req)
+	return traced(ctx, "GetBalloonConfig", req, s.local.GetBalloonConfig)
 }
 
 func (s *service) UpdateBalloon(ctx context.Context, req *proto.UpdateBalloonRequest) (*types.Empty, error) {
 	log.G(ctx).Debug("Updating balloon memory size")
-	return s.local.UpdateBalloon(ctx, req)
+	return traced(ctx, "UpdateBalloon", req, s.local.UpdateBalloon)
 }
 
 func (s *service) GetBalloonStats(ctx context.Context, req *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error) {
 	log.G(ctx).Debug("Getting balloon statistics")
-	return s.local.GetBalloonStats(ctx, req)
+	return traced(ctx, "GetBalloonStats", req, s.local.GetBalloonStats)
 }
 
 func (s *service) UpdateBalloonStats(ctx context.Context, req *proto.UpdateBalloonStatsRequest) (*types.Empty, error) {
 	log.G(ctx).Debug("Updating balloon device statistics polling interval")
-	return s.local.UpdateBalloonStats(ctx, req)
+	return traced(ctx, "UpdateBalloonStats", req, s.local.UpdateBalloonStats)
+}
+
+func (s *service) GetVMStats(ctx context.Context, req *proto.GetVMStatsRequest) (*proto.GetVMStatsResponse, error) {
+	log.G(ctx).Debugf("get VM stats: %+v", req)
+	return traced(ctx, "GetVMStats", req, s.local.GetVMStats)
+}
+
+func (s *service) UpdateRateLimiters(ctx context.Context, req *proto.UpdateRateLimitersRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("update rate limiters: %+v", req)
+	return traced(ctx, "UpdateRateLimiters", req, s.local.UpdateRateLimiters)
+}
+
+func (s *service) GetVMNetwork(ctx context.Context, req *proto.GetVMNetworkRequest) (*proto.GetVMNetworkResponse, error) {
+	log.G(ctx).Debugf("get VM network: %+v", req)
+	return traced(ctx, "GetVMNetwork", req, s.local.GetVMNetwork)
+}
+
+func (s *service) AddNetworkInterface(ctx context.Context, req *proto.AddNetworkInterfaceRequest) (*proto.AddNetworkInterfaceResponse, error) {
+	log.G(ctx).Debugf("add network interface: %+v", req)
+	return traced(ctx, "AddNetworkInterface", req, s.local.AddNetworkInterface)
+}
+
+func (s *service) RemoveNetworkInterface(ctx context.Context, req *proto.RemoveNetworkInterfaceRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("remove network interface: %+v", req)
+	return traced(ctx, "RemoveNetworkInterface", req, s.local.RemoveNetworkInterface)
 }
@@ -0,0 +1,440 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	metrics "github.com/docker/go-metrics"
+	"github.com/gofrs/uuid"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+var (
+	warmPoolCheckInterval = 5 * time.Second
+
+	warmPoolMetrics      = metrics.NewNamespace("firecracker_containerd", "warm_pool", nil)
+	warmPoolHits         = warmPoolMetrics.NewLabeledCounter("hits", "CreateVM calls served by an idle warm pool VM", "pool")
+	warmPoolMisses       = warmPoolMetrics.NewLabeledCounter("misses", "CreateVM calls matching a warm pool without idle VMs", "pool")
+	warmPoolExpired      = warmPoolMetrics.NewLabeledCounter("expired", "Idle warm pool VMs stopped after reaching their maximum idle age", "pool")
+	warmPoolBootFailures = warmPoolMetrics.NewLabeledCounter("boot_failures", "Warm pool VMs which failed to boot", "pool")
+	warmPoolBootTime     = warmPoolMetrics.NewLabeledTimer("boot", "Time taken to boot warm pool VMs", "pool")
+	warmPoolIdle         = warmPoolMetrics.NewLabeledGauge("idle", "Idle VMs in the warm pool", metrics.Total, "pool")
+)
+
+func init() {
+	metrics.Register(warmPoolMetrics)
+}
+
+// warmPool keeps a number of idle VMs booted ahead of time for a profile.
+type warmPool struct {
+	name      string
+	namespace string
+	size      int
+	maxIdle   time.Duration
+	// request is the CreateVMRequest the pool's VMs are created with, less the VMID.
+	request *proto.CreateVMRequest
+
+	mu   sync.Mutex
+	idle []warmVM
+	// refill is signaled whenever an idle VM is handed out.
+	refill chan struct{}
+}
+
+type warmVM struct {
+	vmID     string
+	bootedAt time.Time
+}
+
+func newWarmPool(cfg config.WarmPoolConfig) (*warmPool, error) {
+	if err := identifiers.Validate(cfg.Name); err != nil {
+		return nil, fmt.Errorf("invalid warm pool name: %w", err)
+	}
+
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = namespaces.Default
+	}
+	if err := identifiers.Validate(namespace); err != nil {
+		return nil, fmt.Errorf("invalid namespace of warm pool %q: %w", cfg.Name, err)
+	}
+
+	if cfg.Size < 1 {
+		return nil, fmt.Errorf("size of warm pool %q must be positive", cfg.Name)
+	}
+
+	return &warmPool{
+		name:      cfg.Name,
+		namespace: namespace,
+		size:      cfg.Size,
+		maxIdle:   time.Duration(cfg.MaxIdleSeconds) * time.Second,
+		request: normalizeWarmPoolRequest(&proto.CreateVMRequest{
+			KernelImagePath:   cfg.KernelImagePath,
+			KernelArgs:        cfg.KernelArgs,
+			RootDrive:         cfg.RootDrive,
+			MachineCfg:        cfg.MachineCfg,
+			ContainerCount:    cfg.ContainerCount,
+			MinContainerCount: cfg.MinContainerCount,
+			MaxContainerCount: cfg.MaxContainerCount,
+		}),
+		refill: make(chan struct{}, 1),
+	}, nil
+}
+
+// normalizeWarmPoolRequest returns a copy of req without the fields which can be set
+// freely when a VM is handed out by a warm pool.
+func normalizeWarmPoolRequest(req *proto.CreateVMRequest) *proto.CreateVMRequest {
+	normalized := protobuf.Clone(req).(*proto.CreateVMRequest)
+	normalized.VMID = ""
+	normalized.Metadata = ""
+	normalized.ExitAfterAllTasksDeleted = false
+	normalized.TimeoutSeconds = 0
+
+	// ContainerCount is equivalent to setting both MinContainerCount and MaxContainerCount,
+	// and the runtime always reserves at least one container drive
+	if normalized.MinContainerCount == 0 && normalized.MaxContainerCount == 0 {
+		normalized.MinContainerCount = normalized.ContainerCount
+		normalized.MaxContainerCount = normalized.ContainerCount
+	}
+	normalized.ContainerCount = 0
+	if normalized.MinContainerCount < 1 {
+		normalized.MinContainerCount = 1
+	}
+	if normalized.MaxContainerCount == 0 {
+		normalized.MaxContainerCount = normalized.MinContainerCount
+	}
+
+	return normalized
+}
+
+// matches returns whether a VM of the pool can serve the provided request.
+func (p *warmPool) matches(namespace string, req *proto.CreateVMRequest) bool {
+	return namespace == p.namespace && protobuf.Equal(p.request, normalizeWarmPoolRequest(req))
+}
+
+// newVMID returns a VMID for a new idle VM of the pool.
+func (p *warmPool) newVMID() (string, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("warm-pool-%s-%s", p.name, id), nil
+}
+
+// add adds an idle VM to the pool.
+func (p *warmPool) add(vmID string, bootedAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle = append(p.idle, warmVM{vmID: vmID, bootedAt: bootedAt})
+	warmPoolIdle.WithValues(p.name).Set(float64(len(p.idle)))
+}
+
+// take removes the idle VM which was booted first from the pool.
+func (p *warmPool) take() (warmVM, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+
+	if len(p.idle) == 0 {
+		return warmVM{}, false
+	}
+
+	idle := p.idle[0]
+	p.idle = p.idle[1:]
+	warmPoolIdle.WithValues(p.name).Set(float64(len(p.idle)))
+	return idle, true
+}
+
+// expire removes the VMs which have been idle for longer than the pool's maximum idle age.
+func (p *warmPool) expire(now time.Time) []warmVM {
+	if p.maxIdle == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var expired, idle []warmVM
+	for _, vm := range p.idle {
+		if now.Sub(vm.bootedAt) > p.maxIdle {
+			expired = append(expired, vm)
+		} else {
+			idle = append(idle, vm)
+		}
+	}
+
+	p.idle = idle
+	warmPoolIdle.WithValues(p.name).Set(float64(len(p.idle)))
+	return expired
+}
+
+// missing returns the number of idle VMs needed to fill the pool.
+func (p *warmPool) missing() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size - len(p.idle)
+}
+
+// runWarmPool keeps the pool filled with idle VMs until ctx is done.
+func (s *local) runWarmPool(ctx context.Context, pool *warmPool) {
+	ctx = namespaces.WithNamespace(ctx, pool.namespace)
+	logger := s.logger.WithField("warmPool", pool.name)
+
+	ticker := time.NewTicker(warmPoolCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, idle := range pool.expire(time.Now()) {
+			logger.WithField("vmID", idle.vmID).Debug("stopping expired warm pool VM")
+			warmPoolExpired.WithValues(pool.name).Inc()
+			if _, err := s.StopVM(ctx, &proto.StopVMRequest{VMID: idle.vmID}); err != nil {
+				logger.WithError(err).WithField("vmID", idle.vmID).Warn("failed to stop expired warm pool VM")
+			}
+		}
+
+		for i := pool.missing(); i > 0; i-- {
+			if err := s.bootWarmVM(ctx, pool); err != nil {
+				// try again on the next tick
+				logger.WithError(err).Error("failed to boot warm pool VM")
+				warmPoolBootFailures.WithValues(pool.name).Inc()
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-pool.refill:
+		}
+	}
+}
+
+// bootWarmVM boots a new idle VM for the pool.
+func (s *local) bootWarmVM(ctx context.Context, pool *warmPool) error {
+	vmID, err := pool.newVMID()
+	if err != nil {
+		return fmt.Errorf("failed to generate VMID: %w", err)
+	}
+
+	req := protobuf.Clone(pool.request).(*proto.CreateVMRequest)
+	req.VMID = vmID
+
+	start := time.Now()
+	if _, err := s.createVM(ctx, req, pool.name); err != nil {
+		return err
+	}
+	warmPoolBootTime.WithValues(pool.name).UpdateSince(start)
+
+	pool.add(vmID, time.Now())
+	return nil
+}
+
+// adoptWarmVM adds an idle VM booted before containerd restarted back to its pool.
+// VMs whose pool is no longer configured are stopped.
+func (s *local) adoptWarmVM(ctx context.Context, entry vm.ShimDirEntry) {
+	logger := s.logger.WithField("vmID", entry.VMID)
+
+	for _, pool := range s.warmPools {
+		if pool.name == entry.WarmPool && pool.namespace == entry.Namespace {
+			bootedAt := time.Now()
+			if info, err := os.Stat(entry.Dir.WarmPoolFilePath()); err == nil {
+				bootedAt = info.ModTime()
+			}
+
+			logger.Debug("adopting warm pool VM")
+			pool.add(entry.VMID, bootedAt)
+			return
+		}
+	}
+
+	logger.Info("stopping VM of removed warm pool")
+	ctx = namespaces.WithNamespace(ctx, entry.Namespace)
+	if _, err := s.StopVM(ctx, &proto.StopVMRequest{VMID: entry.VMID}); err != nil {
+		logger.WithError(err).Warn("failed to stop VM of removed warm pool")
+	}
+}
+
+// createFromWarmPool hands out an idle VM of a warm pool matching the request, if any. The
+// provided shimSocket must be the socket reserved for the requested VMID; it is replaced by a
+// link to the socket of the idle VM's shim once the VM has been handed out.
+func (s *local) createFromWarmPool(
+	requestCtx context.Context,
+	ns string,
+	req *proto.CreateVMRequest,
+	shimSocket *net.UnixListener,
+) (*proto.CreateVMResponse, bool, error) {
+	for _, pool := range s.warmPools {
+		if !pool.matches(ns, req) {
+			continue
+		}
+
+		idle, ok := pool.take()
+		if !ok {
+			warmPoolMisses.WithValues(pool.name).Inc()
+			return nil, false, nil
+		}
+
+		logger := s.logger.WithField("vmID", idle.vmID)
+
+		resp, err := s.bindWarmVM(requestCtx, ns, idle.vmID, req)
+		if err != nil {
+			// The requested VMID is still free, so fall back to booting a new VM
+			logger.WithError(err).Warn("failed to bind warm pool VM")
+			warmPoolMisses.WithValues(pool.name).Inc()
+			s.stopWarmVM(requestCtx, idle.vmID)
+			return nil, false, nil
+		}
+
+		err = s.linkWarmVM(requestCtx, idle.vmID, req.VMID, shimSocket)
+		if err != nil {
+			err = fmt.Errorf("failed to link warm pool VM: %w", err)
+			logger.WithError(err).Error()
+			s.stopWarmVM(requestCtx, idle.vmID)
+			return nil, false, err
+		}
+
+		warmPoolHits.WithValues(pool.name).Inc()
+		return resp, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// bindWarmVM binds the idle warm pool VM with the provided VMID to the VMID of the request. The
+// shim of the VM keeps its shim directory, which records the VMID it was bound to.
+func (s *local) bindWarmVM(requestCtx context.Context, ns, warmVMID string, req *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
+	shimDir, err := vm.ShimDir(s.config.ShimBaseDir, ns, warmVMID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := shimDir.WriteBoundVMID(req.VMID); err != nil {
+		return nil, fmt.Errorf("failed to record bound VMID: %w", err)
+	}
+
+	client, err := s.shimFirecrackerClient(requestCtx, warmVMID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firecracker shim client: %w", err)
+	}
+	defer client.Close()
+
+	// The second CreateVM call received by the shim of a warm pool VM binds the VM
+	return client.CreateVM(requestCtx, req)
+}
+
+// linkWarmVM makes the sockets of the requested VMID links to the sockets of the shim of the bound
+// warm pool VM. The reserved shimSocket is replaced without the address ever being free, so
+// concurrent CreateVM calls for the same VMID still get AlreadyExists.
+func (s *local) linkWarmVM(requestCtx context.Context, warmVMID, vmID string, shimSocket *net.UnixListener) error {
+	defer shimSocket.Close()
+
+	s.processesMu.Lock()
+	s.boundVMIDs[warmVMID] = vmID
+	s.processesMu.Unlock()
+
+	warmShimAddr, err := shim.SocketAddress(requestCtx, s.containerdAddress, warmVMID)
+	if err != nil {
+		return err
+	}
+	shimAddr, err := shim.SocketAddress(requestCtx, s.containerdAddress, vmID)
+	if err != nil {
+		return err
+	}
+	if err := replaceWithSymlink(socketPath(warmShimAddr), socketPath(shimAddr)); err != nil {
+		return fmt.Errorf("failed to link shim socket: %w", err)
+	}
+	shimSocket.SetUnlinkOnClose(false)
+
+	warmFCSocketAddr, err := fcShim.FCControlSocketAddress(requestCtx, s.containerdAddress, warmVMID)
+	if err != nil {
+		return err
+	}
+	fcSocketAddr, err := fcShim.FCControlSocketAddress(requestCtx, s.containerdAddress, vmID)
+	if err != nil {
+		return err
+	}
+	if err := replaceWithSymlink(socketPath(warmFCSocketAddr), socketPath(fcSocketAddr)); err != nil {
+		return fmt.Errorf("failed to link shim fccontrol socket: %w", err)
+	}
+
+	s.processesMu.Lock()
+	defer s.processesMu.Unlock()
+	if pid, ok := s.processes[warmShimAddr]; ok {
+		delete(s.processes, warmShimAddr)
+		s.processes[shimAddr] = pid
+	}
+
+	return nil
+}
+
+// stopWarmVM stops a warm pool VM which could not be handed out.
+func (s *local) stopWarmVM(ctx context.Context, warmVMID string) {
+	if _, err := s.StopVM(ctx, &proto.StopVMRequest{VMID: warmVMID}); err != nil {
+		s.logger.WithError(err).WithField("vmID", warmVMID).Warn("failed to stop warm pool VM")
+	}
+}
+
+// takeBoundVMID returns and forgets the VMID the warm pool VM with the provided VMID was bound to.
+func (s *local) takeBoundVMID(warmVMID string) (string, bool) {
+	s.processesMu.Lock()
+	defer s.processesMu.Unlock()
+
+	vmID, ok := s.boundVMIDs[warmVMID]
+	delete(s.boundVMIDs, warmVMID)
+	return vmID, ok
+}
+
+func socketPath(address string) string {
+	return strings.TrimPrefix(address, "unix://")
+}
+
+// replaceWithSymlink atomically replaces path with a symlink to target.
+func replaceWithSymlink(target, path string) error {
+	tmpPath := path + ".tmp"
+	if err := os.Remove(tmpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Symlink(target, tmpPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestWarmPoolMatches(t *testing.T) {
+	pool, err := newWarmPool(config.WarmPoolConfig{
+		Name:            "small",
+		Size:            2,
+		KernelImagePath: "/var/lib/firecracker-containerd/vmlinux",
+		MachineCfg:      &proto.FirecrackerMachineConfiguration{VcpuCount: 1, MemSizeMib: 128},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, namespaces.Default, pool.namespace)
+
+	req := &proto.CreateVMRequest{
+		VMID:                     "vm",
+		KernelImagePath:          "/var/lib/firecracker-containerd/vmlinux",
+		MachineCfg:               &proto.FirecrackerMachineConfiguration{VcpuCount: 1, MemSizeMib: 128},
+		ExitAfterAllTasksDeleted: true,
+		TimeoutSeconds:           10,
+		Metadata:                 `{"foo": "bar"}`,
+		ContainerCount:           1,
+	}
+	assert.True(t, pool.matches(namespaces.Default, req))
+	assert.False(t, pool.matches("other", req))
+
+	req.MachineCfg.MemSizeMib = 256
+	assert.False(t, pool.matches(namespaces.Default, req))
+
+	vmID, err := pool.newVMID()
+	require.NoError(t, err)
+	assert.NoError(t, identifiers.Validate(vmID))
+
+	_, err = newWarmPool(config.WarmPoolConfig{Name: "empty"})
+	assert.Error(t, err)
+}
+
+func TestWarmPoolIdleVMs(t *testing.T) {
+	pool, err := newWarmPool(config.WarmPoolConfig{
+		Name:           "pool",
+		Size:           2,
+		MaxIdleSeconds: 60,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	pool.add("old", now.Add(-2*time.Minute))
+	pool.add("new", now)
+	assert.Equal(t, 0, pool.missing())
+
+	expired := pool.expire(now)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "old", expired[0].vmID)
+	assert.Equal(t, 1, pool.missing())
+
+	idle, ok := pool.take()
+	require.True(t, ok)
+	assert.Equal(t, "new", idle.vmID)
+
+	_, ok = pool.take()
+	assert.False(t, ok)
+	assert.Equal(t, 2, pool.missing())
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/runtime/firecrackeroci"
@@ -61,6 +62,26 @@ func (d Dir) RootfsPath() string {
 	return filepath.Join(d.RootPath(), internal.BundleRootfsName)
 }
 
+// LowerLayerPath returns the path to the i-th lower layer of an overlay rootfs in the bundle
+func (d Dir) LowerLayerPath(i int) string {
+	return filepath.Join(d.RootPath(), internal.BundleLayersName, strconv.Itoa(i))
+}
+
+// UpperLayerPath returns the path to the writable upper layer of an overlay rootfs in the bundle
+func (d Dir) UpperLayerPath() string {
+	return filepath.Join(d.RootPath(), internal.BundleLayersName, "upper")
+}
+
+// CheckpointPath returns the path to the image of a checkpoint of the container being taken
+func (d Dir) CheckpointPath() string {
+	return filepath.Join(d.RootPath(), internal.BundleCheckpointsName, "dump")
+}
+
+// RestorePath returns the path to the image of the checkpoint the container is restored from
+func (d Dir) RestorePath() string {
+	return filepath.Join(d.RootPath(), internal.BundleCheckpointsName, "restore")
+}
+
 // OCIConfigPath returns the path to the bundle's config.json
 func (d Dir) OCIConfigPath() string {
 	return filepath.Join(d.RootPath(), internal.OCIConfigName)
@@ -49,6 +49,27 @@ const (
 	// containerd to reconnect after it restarts
 	ShimAddrFileName = "address"
 
+	// ShimPidFileName is the name of the file in which the PID of a shim managing a VM can be found,
+	// used by the firecracker-control plugin to find running shims after containerd restarts
+	ShimPidFileName = "shim.pid"
+
+	// ShimBoundVMIDFileName is the name of the file in a warm pool VM's shim dir holding the VMID
+	// the VM was handed out as
+	ShimBoundVMIDFileName = "vmid"
+
+	// ShimWarmPoolFileName is the name of the file marking a shim dir as belonging to an idle warm
+	// pool VM. It holds the name of the pool.
+	ShimWarmPoolFileName = "warm-pool"
+
+	// ShimStateFileName is the name of the file in which a shim describes the VM it manages, used
+	// by the firecracker-control plugin to reattach to or clean up after shims when containerd
+	// restarts
+	ShimStateFileName = "state.json"
+
+	// ShimMetricsSockName is the name of the unix socket at which a shim serves the Prometheus metrics
+	// of its VM, scraped by the firecracker-control plugin
+	ShimMetricsSockName = "metrics.sock"
+
 	// ShimLogFifoName is the name of the FIFO created by containerd for a shim to write its logs to
 	ShimLogFifoName = "log"
 
@@ -58,12 +79,24 @@ const (
 	// BundleRootfsName is the name of the bundle's directory for holding the container's rootfs
 	BundleRootfsName = "rootfs"
 
+	// BundleLayersName is the name of the bundle's directory for holding the layers of a
+	// container's overlay rootfs
+	BundleLayersName = "layers"
+
+	// BundleCheckpointsName is the name of the bundle's directory for holding the images of
+	// checkpoints of the container being taken or restored
+	BundleCheckpointsName = "checkpoints"
+
 	// VMIDEnvVarKey is the environment variable key used to provide a VMID to a shim process
 	VMIDEnvVarKey = "FIRECRACKER_VM_ID"
 
 	// FCSocketFDEnvKey is the environment variable key used to provide the FD of the fccontrol listening socket to a shim
 	FCSocketFDEnvKey = "FCCONTROL_SOCKET_FD"
 
+	// WarmPoolEnvVarKey is the environment variable key used to tell a shim that its VM is booted
+	// ahead of time for a warm pool, and will be bound to a different VMID when it is handed out
+	WarmPoolEnvVarKey = "FIRECRACKER_WARM_POOL"
+
 	// ShimBinaryName is the name of the runtime shim binary
 	ShimBinaryName = "containerd-shim-aws-firecracker"
 )
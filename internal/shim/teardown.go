@@ -0,0 +1,300 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package shim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containerd/go-runc"
+	"github.com/containernetworking/cni/libcni"
+	"github.com/hashicorp/go-multierror"
+	"github.com/shirou/gopsutil/process"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+const (
+	firecrackerExitTimeout  = 5 * time.Second
+	firecrackerExitInterval = 10 * time.Millisecond
+)
+
+// TeardownVM tears down what is left of the VM described by the state of a shim which exited
+// without cleaning up after it, usually because it crashed: the runc container or chroot the VM
+// is jailed in along with the files bind mounted to it, its firecracker process, its CNI networks
+// and its FIFOs. The shim directory itself is left to the caller to remove.
+func TeardownVM(ctx context.Context, dir vm.Dir, state *vm.ShimState) error {
+	var result *multierror.Error
+
+	if state.Jailer != nil {
+		if err := removeJail(ctx, state.Jailer, int32(state.FirecrackerPID)); err != nil {
+			result = multierror.Append(result, err)
+		}
+	} else if state.FirecrackerPID != 0 {
+		if err := killFirecracker(ctx, dir, int32(state.FirecrackerPID)); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	// the networks are torn down once the VM is gone, as their tap devices are in use until then
+	if len(state.CNI) > 0 {
+		if err := teardownCNI(ctx, state.CNI); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	for _, path := range state.FIFOs {
+		if err := removeFIFO(path); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// ShimRunning returns whether the shim described by the provided state is still running. A
+// process with the shim's PID is only the shim if it started when the shim did, as the PID
+// may have been reused since the shim exited.
+func ShimRunning(ctx context.Context, state *vm.ShimState) (bool, error) {
+	exists, err := process.PidExistsWithContext(ctx, int32(state.ShimPID))
+	if err != nil || !exists {
+		return false, err
+	}
+
+	// state files written before start times were recorded only have the PID to go on
+	if state.ShimStartTime == 0 {
+		return true, nil
+	}
+
+	startTime, err := ProcessStartTime(ctx, int32(state.ShimPID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return startTime == state.ShimStartTime, nil
+}
+
+// ProcessStartTime returns when the process with the provided PID started, in milliseconds
+// since the epoch.
+func ProcessStartTime(ctx context.Context, pid int32) (int64, error) {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return 0, err
+	}
+
+	return proc.CreateTimeWithContext(ctx)
+}
+
+// removeJail deletes the runc container a jailed VM runs in, or kills the firecracker process
+// jailed by Firecracker's jailer, along with its bundle.
+func removeJail(ctx context.Context, jail *vm.JailerState, pid int32) error {
+	if jail.Backend == config.FirecrackerJailerBackend {
+		if err := killJailedFirecracker(ctx, jail.ContainerID, pid); err != nil {
+			return err
+		}
+
+		// the files bind mounted to the jail must be unmounted before the jail is removed
+		for _, mount := range jail.BindMounts {
+			err := unix.Unmount(mount, unix.MNT_DETACH)
+			if err != nil && err != unix.EINVAL && err != unix.ENOENT {
+				return fmt.Errorf("failed to unmount %q: %w", mount, err)
+			}
+		}
+	} else {
+		client := runc.Runc{Command: jail.RuncBinaryPath}
+		err := client.Delete(ctx, jail.ContainerID, &runc.DeleteOpts{Force: true})
+		if err != nil && !strings.Contains(err.Error(), "does not exist") {
+			return fmt.Errorf("failed to delete jail container %q: %w", jail.ContainerID, err)
+		}
+	}
+
+	if err := os.RemoveAll(jail.BundlePath); err != nil {
+		return fmt.Errorf("failed to remove jail bundle %q: %w", jail.BundlePath, err)
+	}
+
+	return nil
+}
+
+// killFirecracker kills the firecracker process of an unjailed VM and waits for it to exit. As
+// the PID may have been reused, the process is only killed if it runs in the VM's shim directory
+// like the firecracker processes started by shims do.
+func killFirecracker(ctx context.Context, dir vm.Dir, pid int32) error {
+	exists, err := process.PidExistsWithContext(ctx, pid)
+	if err != nil || !exists {
+		return err
+	}
+
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := proc.CwdWithContext(ctx)
+	if err != nil || cwd != dir.RootPath() {
+		return nil
+	}
+
+	return killProcess(ctx, proc)
+}
+
+// killJailedFirecracker kills the firecracker process of a VM jailed by Firecracker's jailer
+// and waits for it to exit. The process runs in its jail, so unlike killFirecracker, it is
+// only killed if it was started with the VM's ID.
+func killJailedFirecracker(ctx context.Context, vmID string, pid int32) error {
+	if pid == 0 {
+		return nil
+	}
+
+	exists, err := process.PidExistsWithContext(ctx, pid)
+	if err != nil || !exists {
+		return err
+	}
+
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	args, err := proc.CmdlineSliceWithContext(ctx)
+	if err != nil || !hasArg(args, "--id", vmID) {
+		return nil
+	}
+
+	return killProcess(ctx, proc)
+}
+
+// hasArg returns whether the command line args have the flag with the provided value.
+func hasArg(args []string, flag, value string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag && args[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// killProcess kills a firecracker process and waits for it to exit.
+func killProcess(ctx context.Context, proc *process.Process) error {
+	pid := proc.Pid
+	if err := proc.KillWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to kill firecracker process %d: %w", pid, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, firecrackerExitTimeout)
+	defer cancel()
+
+	if err := internal.WaitForPidToExit(ctx, firecrackerExitInterval, pid); err != nil {
+		return fmt.Errorf("failed to wait for firecracker process %d to exit: %w", pid, err)
+	}
+
+	return nil
+}
+
+// removeFIFO removes a FIFO created for a VM. Other files which may have replaced it since are
+// left alone.
+func removeFIFO(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove FIFO %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// teardownCNI deletes the CNI networks of a VM, then the network namespace they were set up in
+// if it was created for the VM.
+func teardownCNI(ctx context.Context, networks []vm.CNIState) error {
+	var result *multierror.Error
+	ownedNetNS := make(map[string]struct{})
+	for i := range networks {
+		cni := &networks[i]
+		if err := deleteCNINetwork(ctx, cni); err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		if cni.OwnsNetNS {
+			ownedNetNS[cni.NetNS] = struct{}{}
+		}
+	}
+
+	// the namespace is kept if a network could not be deleted, so it can be retried
+	if result.ErrorOrNil() != nil {
+		return result.ErrorOrNil()
+	}
+
+	for netNS := range ownedNetNS {
+		if err := removeNetNS(netNS); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// deleteCNINetwork deletes a CNI network of a VM. The CNI plugins are provided the result cached
+// when the network was set up, so they can release what was allocated for the VM.
+func deleteCNINetwork(ctx context.Context, cni *vm.CNIState) error {
+	networkConf, err := libcni.LoadConfList(cni.ConfDir, cni.NetworkName)
+	if err != nil {
+		return fmt.Errorf("failed to load CNI configuration from dir %q for network %q: %w",
+			cni.ConfDir, cni.NetworkName, err)
+	}
+
+	cniPlugin := libcni.NewCNIConfigWithCacheDir(cni.BinPath, cni.CacheDir, nil)
+	err = cniPlugin.DelNetworkList(ctx, networkConf, &libcni.RuntimeConf{
+		ContainerID: cni.ContainerID,
+		NetNS:       cni.NetNS,
+		IfName:      cni.IfName,
+		Args:        cni.Args,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete CNI network list %q: %w", cni.NetworkName, err)
+	}
+
+	return nil
+}
+
+// removeNetNS unmounts and removes a network namespace created for a VM.
+func removeNetNS(netNS string) error {
+	err := unix.Unmount(netNS, unix.MNT_DETACH)
+	if err != nil && err != unix.EINVAL && err != unix.ENOENT {
+		return fmt.Errorf("failed to unmount netns at %q: %w", netNS, err)
+	}
+
+	if err := os.Remove(netNS); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove netns path %q: %w", netNS, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package shim
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+func startProcess(t *testing.T, dir string) (*exec.Cmd, <-chan struct{}) {
+	cmd := exec.Command("sleep", "60")
+	cmd.Dir = dir
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() { cmd.Process.Kill() })
+
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(exited)
+	}()
+
+	return cmd, exited
+}
+
+func TestTeardownVMKillsFirecracker(t *testing.T) {
+	ctx := context.Background()
+	shimDir := vm.Dir(t.TempDir())
+
+	// firecracker processes run in their shim dir
+	firecracker, firecrackerExited := startProcess(t, shimDir.RootPath())
+	err := TeardownVM(ctx, shimDir, &vm.ShimState{FirecrackerPID: firecracker.Process.Pid})
+	require.NoError(t, err)
+
+	select {
+	case <-firecrackerExited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("firecracker process was not killed")
+	}
+
+	// processes which reused the PID of a firecracker process are left alone
+	other, otherExited := startProcess(t, t.TempDir())
+	err = TeardownVM(ctx, shimDir, &vm.ShimState{FirecrackerPID: other.Process.Pid})
+	require.NoError(t, err)
+
+	select {
+	case <-otherExited:
+		t.Fatal("unrelated process was killed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.NoError(t, other.Process.Kill())
+}
+
+func TestTeardownVMKillsJailedFirecracker(t *testing.T) {
+	ctx := context.Background()
+	shimDir := vm.Dir(t.TempDir())
+	jail := &vm.JailerState{
+		Backend:     config.FirecrackerJailerBackend,
+		ContainerID: "vm-id",
+		BundlePath:  filepath.Join(shimDir.RootPath(), "firecracker"),
+	}
+	require.NoError(t, os.MkdirAll(jail.BundlePath, 0700))
+
+	// jailed firecracker processes are started with the VM's ID
+	firecracker := exec.Command("sh", "-c", "sleep 60; true", "firecracker", "--id", "vm-id")
+	require.NoError(t, firecracker.Start())
+	t.Cleanup(func() { firecracker.Process.Kill() })
+	firecrackerExited := make(chan struct{})
+	go func() {
+		firecracker.Wait()
+		close(firecrackerExited)
+	}()
+
+	other, otherExited := startProcess(t, t.TempDir())
+	err := TeardownVM(ctx, shimDir, &vm.ShimState{Jailer: jail, FirecrackerPID: other.Process.Pid})
+	require.NoError(t, err)
+
+	select {
+	case <-otherExited:
+		t.Fatal("unrelated process was killed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = TeardownVM(ctx, shimDir, &vm.ShimState{Jailer: jail, FirecrackerPID: firecracker.Process.Pid})
+	require.NoError(t, err)
+
+	select {
+	case <-firecrackerExited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("firecracker process was not killed")
+	}
+
+	_, err = os.Stat(jail.BundlePath)
+	assert.True(t, os.IsNotExist(err), "the jail should be removed")
+}
+
+func TestTeardownVMUnmountsJailBindMounts(t *testing.T) {
+	internal.RequiresRoot(t)
+
+	ctx := context.Background()
+	shimDir := vm.Dir(t.TempDir())
+	jail := &vm.JailerState{
+		Backend:     config.FirecrackerJailerBackend,
+		ContainerID: "vm-id",
+		BundlePath:  filepath.Join(shimDir.RootPath(), "firecracker"),
+	}
+	require.NoError(t, os.MkdirAll(jail.BundlePath, 0700))
+
+	src := filepath.Join(t.TempDir(), "drive.img")
+	require.NoError(t, os.WriteFile(src, []byte("drive"), 0600))
+	dst := filepath.Join(jail.BundlePath, "drive.img")
+	require.NoError(t, os.WriteFile(dst, nil, 0600))
+	require.NoError(t, unix.Mount(src, dst, "", unix.MS_BIND, ""))
+	t.Cleanup(func() { unix.Unmount(dst, unix.MNT_DETACH) })
+	jail.BindMounts = []string{dst, filepath.Join(jail.BundlePath, "gone.img")}
+
+	err := TeardownVM(ctx, shimDir, &vm.ShimState{Jailer: jail})
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, jail.BundlePath)
+	contents, err := os.ReadFile(src)
+	require.NoError(t, err)
+	assert.Equal(t, "drive", string(contents), "bind mounted files must be left intact")
+}
+
+func TestTeardownVMRemovesFIFOs(t *testing.T) {
+	dir := t.TempDir()
+	fifo := filepath.Join(dir, "fc-logs.fifo")
+	require.NoError(t, unix.Mkfifo(fifo, 0700))
+	file := filepath.Join(dir, "fc-metrics.fifo")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+
+	err := TeardownVM(context.Background(), vm.Dir(dir), &vm.ShimState{
+		FIFOs: []string{fifo, file, filepath.Join(dir, "missing.fifo")},
+	})
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, fifo)
+	assert.FileExists(t, file, "files which are not FIFOs must be left alone")
+}
+
+func TestShimRunning(t *testing.T) {
+	ctx := context.Background()
+
+	startTime, err := ProcessStartTime(ctx, int32(os.Getpid()))
+	require.NoError(t, err)
+
+	running, err := ShimRunning(ctx, &vm.ShimState{ShimPID: os.Getpid(), ShimStartTime: startTime})
+	require.NoError(t, err)
+	assert.True(t, running)
+
+	// state files without start time only have the PID to go on
+	running, err = ShimRunning(ctx, &vm.ShimState{ShimPID: os.Getpid()})
+	require.NoError(t, err)
+	assert.True(t, running)
+
+	// the PID of the shim was reused by another process
+	running, err = ShimRunning(ctx, &vm.ShimState{ShimPID: os.Getpid(), ShimStartTime: startTime - 1000})
+	require.NoError(t, err)
+	assert.False(t, running)
+
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+	running, err = ShimRunning(ctx, &vm.ShimState{ShimPID: exited.Process.Pid, ShimStartTime: startTime})
+	require.NoError(t, err)
+	assert.False(t, running)
+}
@@ -0,0 +1,157 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package tracing propagates OpenTelemetry trace context through the metadata of
+// ttrpc requests and provides helpers to record spans.
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/containerd/ttrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+const instrumentationName = "github.com/firecracker-microvm/firecracker-containerd"
+
+// propagator carries the trace context over ttrpc. It doesn't depend on the global
+// propagator so that the trace context is propagated even when spans aren't exported.
+var propagator = propagation.TraceContext{}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartSpan starts a span with the provided name as a child of the span in ctx, if any.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServerSpan starts a span for a ttrpc request served under the provided name,
+// as a child of the span propagated in the metadata of the request.
+func StartServerSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(Extract(ctx), name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, recording err on it if not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract returns a copy of ctx with the trace context propagated in the ttrpc metadata
+// of ctx, if any.
+func Extract(ctx context.Context) context.Context {
+	md, ok := ttrpc.GetMetadata(ctx)
+	if !ok {
+		return ctx
+	}
+	return propagator.Extract(ctx, mdCarrier(md))
+}
+
+// UnaryClientInterceptor records a span for every request sent by a ttrpc client and
+// propagates its trace context in the metadata of the request.
+func UnaryClientInterceptor(ctx context.Context, req *ttrpc.Request, resp *ttrpc.Response, info *ttrpc.UnaryClientInfo, invoker ttrpc.Invoker) error {
+	ctx, span := tracer().Start(ctx, spanName(info.FullMethod), trace.WithSpanKind(trace.SpanKindClient))
+	propagator.Inject(ctx, &requestCarrier{req: req})
+
+	err := invoker(ctx, req, resp)
+
+	spanErr := err
+	if spanErr == nil && resp.Status != nil {
+		// failures of the remote method are returned in the status of the response
+		spanErr = status.FromProto(resp.Status).Err()
+	}
+	EndSpan(span, spanErr)
+	return err
+}
+
+// UnaryServerInterceptor records a span for every request served by a ttrpc server,
+// as a child of the span propagated in the metadata of the request.
+func UnaryServerInterceptor(ctx context.Context, unmarshal ttrpc.Unmarshaler, info *ttrpc.UnaryServerInfo, method ttrpc.Method) (interface{}, error) {
+	ctx, span := StartServerSpan(ctx, spanName(info.FullMethod))
+	resp, err := method(ctx, unmarshal)
+	EndSpan(span, err)
+	return resp, err
+}
+
+// spanName returns the name of the span of a ttrpc method, "package.Service/Method".
+func spanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// mdCarrier adapts the ttrpc metadata of a context to propagation.TextMapCarrier.
+type mdCarrier ttrpc.MD
+
+func (c mdCarrier) Get(key string) string {
+	values, ok := ttrpc.MD(c).Get(key)
+	if !ok || len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c mdCarrier) Set(key, value string) {
+	ttrpc.MD(c).Set(key, value)
+}
+
+func (c mdCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// requestCarrier adapts the metadata of a ttrpc request to propagation.TextMapCarrier.
+// The metadata of the request is built from the metadata of the context before client
+// interceptors are called, so the trace context has to be set on the request itself.
+type requestCarrier struct {
+	req *ttrpc.Request
+}
+
+func (c *requestCarrier) Get(key string) string {
+	for _, kv := range c.req.Metadata {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return ""
+}
+
+func (c *requestCarrier) Set(key, value string) {
+	metadata := c.req.Metadata[:0]
+	for _, kv := range c.req.Metadata {
+		if kv.Key != key {
+			metadata = append(metadata, kv)
+		}
+	}
+	c.req.Metadata = append(metadata, &ttrpc.KeyValue{Key: key, Value: value})
+}
+
+func (c *requestCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.req.Metadata))
+	for _, kv := range c.req.Metadata {
+		keys = append(keys, kv.Key)
+	}
+	return keys
+}
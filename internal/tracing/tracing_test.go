@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/containerd/ttrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/status"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// serve passes a request sent by the client interceptor to the server interceptor,
+// the way a ttrpc server would.
+func serve(method ttrpc.Method) ttrpc.Invoker {
+	return func(ctx context.Context, req *ttrpc.Request, resp *ttrpc.Response) error {
+		md := ttrpc.MD{}
+		for _, kv := range req.Metadata {
+			md.Append(kv.Key, kv.Value)
+		}
+		serverCtx := ttrpc.WithMetadata(context.Background(), md)
+
+		_, err := UnaryServerInterceptor(serverCtx, func(interface{}) error { return nil },
+			&ttrpc.UnaryServerInfo{FullMethod: "/" + req.Service + "/" + req.Method}, method)
+		if err != nil {
+			resp.Status = status.Convert(err).Proto()
+		}
+		return nil
+	}
+}
+
+func TestInterceptorsPropagateTraceContext(t *testing.T) {
+	recorder := newRecorder(t)
+
+	ctx, parent := StartSpan(context.Background(), "parent")
+	// metadata already in the context, such as the trace context of the request being
+	// served, must be replaced by the trace context of the client span
+	ctx = ttrpc.WithMetadata(ctx, ttrpc.MD{"traceparent": []string{"00-0123456789abcdef0123456789abcdef-0123456789abcdef-01"}})
+	req := &ttrpc.Request{
+		Service:  "aws.firecracker.containerd.eventbridge.getter",
+		Method:   "GetEvent",
+		Metadata: []*ttrpc.KeyValue{{Key: "traceparent", Value: "stale"}},
+	}
+
+	err := UnaryClientInterceptor(ctx, req, &ttrpc.Response{},
+		&ttrpc.UnaryClientInfo{FullMethod: "/aws.firecracker.containerd.eventbridge.getter/GetEvent"},
+		serve(func(context.Context, func(interface{}) error) (interface{}, error) {
+			return nil, nil
+		}))
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	server, client := spans[0], spans[1]
+	assert.Equal(t, "aws.firecracker.containerd.eventbridge.getter/GetEvent", server.Name())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), server.SpanContext().TraceID())
+
+	var traceparents int
+	for _, kv := range req.Metadata {
+		if kv.Key == "traceparent" {
+			traceparents++
+		}
+	}
+	assert.Equal(t, 1, traceparents, "trace context should be set once in the request metadata")
+}
+
+func TestInterceptorsRecordErrors(t *testing.T) {
+	recorder := newRecorder(t)
+
+	err := UnaryClientInterceptor(context.Background(), &ttrpc.Request{}, &ttrpc.Response{},
+		&ttrpc.UnaryClientInfo{FullMethod: "/DriveMounter/MountDrive"},
+		serve(func(context.Context, func(interface{}) error) (interface{}, error) {
+			return nil, errors.New("mock mount failure")
+		}))
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, codes.Error, span.Status().Code, "span %s should have failed", span.Name())
+		assert.Contains(t, span.Status().Description, "mock mount failure")
+	}
+}
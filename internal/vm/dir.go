@@ -15,10 +15,14 @@ package vm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/containerd/containerd/identifiers"
 	"github.com/containerd/containerd/runtime/v2/shim"
@@ -52,6 +56,70 @@ func shimDir(varRunDir, namespace, vmID string) (Dir, error) {
 	return Dir(filepath.Join(resolvedVarRunDir, namespace+"#"+vmID)), nil
 }
 
+// ShimDirEntry is a shim directory found under a shim base directory along
+// with the namespace and VMID encoded in its name.
+type ShimDirEntry struct {
+	Namespace string
+	VMID      string
+	Dir       Dir
+
+	// WarmPool is the name of the warm pool of an idle warm pool VM, or empty
+	// if the VM has been handed out. The VMID of a warm pool VM that has been
+	// handed out is the one it was bound to rather than the one in the name
+	// of its shim directory.
+	WarmPool string
+}
+
+// ListShimDirs returns all of the shim directories found in shimBaseDir. Entries whose names
+// do not match the format used by ShimDir are skipped. If shimBaseDir does not exist, no
+// entries are returned.
+func ListShimDirs(shimBaseDir string) ([]ShimDirEntry, error) {
+	resolvedBaseDir, err := filepath.EvalSymlinks(shimBaseDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed evaluating any symlinks in path %q: %w", shimBaseDir, err)
+	}
+
+	dirEntries, err := os.ReadDir(resolvedBaseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shim base directory %q: %w", resolvedBaseDir, err)
+	}
+
+	var entries []ShimDirEntry
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+
+		namespace, vmID, ok := strings.Cut(dirEntry.Name(), "#")
+		if !ok || identifiers.Validate(namespace) != nil || identifiers.Validate(vmID) != nil {
+			continue
+		}
+
+		dir := Dir(filepath.Join(resolvedBaseDir, dirEntry.Name()))
+		if boundVMID, err := dir.ReadBoundVMID(); err == nil {
+			vmID = boundVMID
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		warmPool, err := dir.ReadWarmPool()
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		entries = append(entries, ShimDirEntry{
+			Namespace: namespace,
+			VMID:      vmID,
+			Dir:       dir,
+			WarmPool:  warmPool,
+		})
+	}
+
+	return entries, nil
+}
+
 // Dir represents the root of a firecracker-containerd VM directory, which
 // holds various files, sockets and FIFOs used during VM runtime.
 type Dir string
@@ -73,6 +141,199 @@ func (d Dir) AddrFilePath() string {
 	return filepath.Join(d.RootPath(), internal.ShimAddrFileName)
 }
 
+// PidFilePath returns the path to the shim pid file as found in the vmDir. The pid file
+// holds the PID of the shim process managing the VM.
+func (d Dir) PidFilePath() string {
+	return filepath.Join(d.RootPath(), internal.ShimPidFileName)
+}
+
+// WritePidFile will write the pid file in the VM dir.
+func (d Dir) WritePidFile(pid int) error {
+	return shim.WritePidFile(d.PidFilePath(), pid)
+}
+
+// ReadPidFile returns the PID found in the pid file of the VM dir.
+func (d Dir) ReadPidFile() (int, error) {
+	contents, err := os.ReadFile(d.PidFilePath())
+	if err != nil {
+		return 0, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse pid file %q: %w", d.PidFilePath(), err)
+	}
+
+	return pid, nil
+}
+
+// BoundVMIDFilePath returns the path to the file holding the VMID a warm pool VM
+// was handed out as.
+func (d Dir) BoundVMIDFilePath() string {
+	return filepath.Join(d.RootPath(), internal.ShimBoundVMIDFileName)
+}
+
+// WriteBoundVMID records that the warm pool VM of the VM dir has been handed out
+// as vmID.
+func (d Dir) WriteBoundVMID(vmID string) error {
+	if err := identifiers.Validate(vmID); err != nil {
+		return fmt.Errorf("invalid vm id: %w", err)
+	}
+
+	if err := os.WriteFile(d.BoundVMIDFilePath(), []byte(vmID), 0600); err != nil {
+		return err
+	}
+
+	err := os.Remove(d.WarmPoolFilePath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// ReadBoundVMID returns the VMID the warm pool VM of the VM dir was handed out as.
+func (d Dir) ReadBoundVMID() (string, error) {
+	contents, err := os.ReadFile(d.BoundVMIDFilePath())
+	if err != nil {
+		return "", err
+	}
+
+	vmID := strings.TrimSpace(string(contents))
+	if err := identifiers.Validate(vmID); err != nil {
+		return "", fmt.Errorf("invalid vm id in %q: %w", d.BoundVMIDFilePath(), err)
+	}
+
+	return vmID, nil
+}
+
+// WarmPoolFilePath returns the path to the file marking the VM dir as belonging
+// to an idle warm pool VM.
+func (d Dir) WarmPoolFilePath() string {
+	return filepath.Join(d.RootPath(), internal.ShimWarmPoolFileName)
+}
+
+// WriteWarmPool marks the VM dir as belonging to an idle VM of the named warm pool.
+func (d Dir) WriteWarmPool(name string) error {
+	return os.WriteFile(d.WarmPoolFilePath(), []byte(name), 0600)
+}
+
+// ReadWarmPool returns the name of the warm pool the idle VM of the VM dir belongs to.
+func (d Dir) ReadWarmPool() (string, error) {
+	contents, err := os.ReadFile(d.WarmPoolFilePath())
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
+
+// ShimState describes the VM managed by a shim, as recorded in the state file of its VM dir.
+type ShimState struct {
+	// Namespace and VMID are the ones of the shim dir, which for a warm pool VM is not the
+	// VMID the VM was handed out as.
+	Namespace string `json:"namespace"`
+	VMID      string `json:"vm_id"`
+
+	ShimPID        int `json:"shim_pid"`
+	FirecrackerPID int `json:"firecracker_pid,omitempty"`
+
+	// ShimStartTime is when the shim process started, in milliseconds since the epoch. As the
+	// PID of an exited shim may be reused, a process with ShimPID is only the shim if it
+	// started at that time.
+	ShimStartTime int64 `json:"shim_start_time,omitempty"`
+
+	// FIFOs are the paths of the log and metrics FIFOs created for the VM.
+	FIFOs []string `json:"fifos,omitempty"`
+
+	// Jailer describes the jail the VM runs in, or is nil if the VM isn't jailed.
+	Jailer *JailerState `json:"jailer,omitempty"`
+
+	// CNI describes the CNI networks of the VM's network interfaces.
+	CNI []CNIState `json:"cni,omitempty"`
+
+	// TaskIDs are the IDs of the tasks running in the VM.
+	TaskIDs []string `json:"task_ids,omitempty"`
+
+	// TaskExits holds the exit status of the tasks whose init process exited but which have
+	// not been deleted yet, keyed by task ID.
+	TaskExits map[string]TaskExit `json:"task_exits,omitempty"`
+}
+
+// TaskExit is the exit status of the init process of a task.
+type TaskExit struct {
+	ExitStatus uint32    `json:"exit_status"`
+	ExitedAt   time.Time `json:"exited_at"`
+}
+
+// JailerState describes the jail a jailed VM runs in: a runc container, or the chroot
+// Firecracker's jailer created under BundlePath.
+type JailerState struct {
+	// Backend is the jailer backend of the runtime config the VM was jailed with. The VM
+	// runs in a runc container if unset.
+	Backend        string `json:"backend,omitempty"`
+	RuncBinaryPath string `json:"runc_binary_path,omitempty"`
+	ContainerID    string `json:"container_id"`
+	BundlePath     string `json:"bundle_path"`
+
+	// BindMounts are the paths in the chroot of Firecracker's jailer which files were bind
+	// mounted to. The mounts are made outside of the jail, so they outlive the VM.
+	BindMounts []string `json:"bind_mounts,omitempty"`
+}
+
+// CNIState describes the CNI network a VM's network interface is attached to. The fields
+// match the ones of the CNI configuration the network was set up with.
+type CNIState struct {
+	NetworkName string      `json:"network_name"`
+	IfName      string      `json:"if_name"`
+	BinPath     []string    `json:"bin_path"`
+	ConfDir     string      `json:"conf_dir"`
+	CacheDir    string      `json:"cache_dir"`
+	Args        [][2]string `json:"args,omitempty"`
+	ContainerID string      `json:"container_id"`
+	NetNS       string      `json:"netns"`
+
+	// OwnsNetNS is true if the network namespace was created for the VM, in which case it is
+	// removed along with the network.
+	OwnsNetNS bool `json:"owns_netns,omitempty"`
+}
+
+// StateFilePath returns the path to the file in which the shim describes the VM it manages.
+func (d Dir) StateFilePath() string {
+	return filepath.Join(d.RootPath(), internal.ShimStateFileName)
+}
+
+// WriteState replaces the state file of the VM dir. The file is replaced atomically so
+// readers never see a partially written state.
+func (d Dir) WriteState(state *ShimState) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal shim state: %w", err)
+	}
+
+	tmpPath := d.StateFilePath() + ".tmp"
+	if err := os.WriteFile(tmpPath, contents, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, d.StateFilePath())
+}
+
+// ReadState returns the state found in the state file of the VM dir.
+func (d Dir) ReadState() (*ShimState, error) {
+	contents, err := os.ReadFile(d.StateFilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	state := &ShimState{}
+	if err := json.Unmarshal(contents, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %q: %w", d.StateFilePath(), err)
+	}
+
+	return state, nil
+}
+
 // LogFifoPath returns the path to the FIFO for writing shim logs
 func (d Dir) LogFifoPath() string {
 	return filepath.Join(d.RootPath(), internal.ShimLogFifoName)
@@ -119,6 +380,12 @@ func (d Dir) FirecrackerMetricsFifoPath() string {
 	return filepath.Join(d.RootPath(), internal.FirecrackerMetricsFifoName)
 }
 
+// MetricsSockPath returns the path to the unix socket at which the shim serves the Prometheus
+// metrics of the VM
+func (d Dir) MetricsSockPath() string {
+	return filepath.Join(d.RootPath(), internal.ShimMetricsSockName)
+}
+
 // BundleLink returns the path to the symlink to the bundle dir for a given container running
 // inside the VM of this vm dir.
 func (d Dir) BundleLink(containerID string) (bundle.Dir, error) {
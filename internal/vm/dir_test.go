@@ -14,11 +14,13 @@
 package vm
 
 import (
+	"os"
 	"path"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var invalidContainerIDs = []string{"", "id?", "*", "id/1", "id\\"}
@@ -89,3 +91,91 @@ func TestBundleLinkInvalidID(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestListShimDirs(t *testing.T) {
+	runDir := t.TempDir()
+
+	for _, name := range []string{"ns#1", "test-123#123-456", "no-separator", "ns#", "#id", "ns#id#extra"} {
+		require.NoError(t, os.Mkdir(path.Join(runDir, name), 0700))
+	}
+	require.NoError(t, os.WriteFile(path.Join(runDir, "file#1"), nil, 0600))
+
+	entries, err := ListShimDirs(runDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ShimDirEntry{
+		{Namespace: "ns", VMID: "1", Dir: Dir(path.Join(runDir, "ns#1"))},
+		{Namespace: "test-123", VMID: "123-456", Dir: Dir(path.Join(runDir, "test-123#123-456"))},
+	}, entries)
+
+	entries, err = ListShimDirs(path.Join(runDir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestPidFile(t *testing.T) {
+	dir := Dir(t.TempDir())
+
+	_, err := dir.ReadPidFile()
+	assert.True(t, os.IsNotExist(err), "expected not exist error, got %v", err)
+
+	require.NoError(t, dir.WritePidFile(1234))
+	pid, err := dir.ReadPidFile()
+	assert.NoError(t, err)
+	assert.Equal(t, 1234, pid)
+
+	require.NoError(t, os.WriteFile(dir.PidFilePath(), []byte("not a pid"), 0600))
+	_, err = dir.ReadPidFile()
+	assert.Error(t, err)
+}
+
+func TestListShimDirsWarmPool(t *testing.T) {
+	runDir := t.TempDir()
+
+	idle := Dir(path.Join(runDir, "ns#pool-1"))
+	require.NoError(t, idle.Mkdir())
+	require.NoError(t, idle.WriteWarmPool("pool"))
+
+	bound := Dir(path.Join(runDir, "ns#pool-2"))
+	require.NoError(t, bound.Mkdir())
+	require.NoError(t, bound.WriteWarmPool("pool"))
+	require.NoError(t, bound.WriteBoundVMID("requested"))
+	assert.NoFileExists(t, bound.WarmPoolFilePath())
+
+	entries, err := ListShimDirs(runDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ShimDirEntry{
+		{Namespace: "ns", VMID: "pool-1", Dir: idle, WarmPool: "pool"},
+		{Namespace: "ns", VMID: "requested", Dir: bound},
+	}, entries)
+
+	assert.Error(t, bound.WriteBoundVMID("invalid/id"))
+}
+
+func TestStateFile(t *testing.T) {
+	dir := Dir(t.TempDir())
+
+	_, err := dir.ReadState()
+	assert.True(t, os.IsNotExist(err), "expected not exist error, got %v", err)
+
+	state := &ShimState{
+		Namespace:      "ns",
+		VMID:           "vm",
+		ShimPID:        1234,
+		FirecrackerPID: 5678,
+		Jailer: &JailerState{
+			ContainerID: "vm",
+			BundlePath:  dir.RootPath(),
+		},
+		TaskIDs: []string{"task-1", "task-2"},
+	}
+	require.NoError(t, dir.WriteState(state))
+	assert.NoFileExists(t, dir.StateFilePath()+".tmp")
+
+	read, err := dir.ReadState()
+	require.NoError(t, err)
+	assert.Equal(t, state, read)
+
+	require.NoError(t, os.WriteFile(dir.StateFilePath(), []byte("{"), 0600))
+	_, err = dir.ReadState()
+	assert.Error(t, err)
+}
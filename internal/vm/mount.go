@@ -14,13 +14,21 @@
 package vm
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/mount"
 )
 
-const vmLocalMountTypePrefix = "vm:"
+const (
+	vmLocalMountTypePrefix = "vm:"
+	overlayMountType       = "overlay"
+
+	overlayLowerDirOption = "lowerdir="
+	overlayUpperDirOption = "upperdir="
+	overlayWorkDirOption  = "workdir="
+)
 
 // IsLocalMount returns true if the mount source is inside the VM as opposed to the
 // default assumption that the mount source is a block device on the host.
@@ -51,3 +59,62 @@ func StripLocalMountIdentifier(mnt *types.Mount) *types.Mount {
 		Options: options,
 	}
 }
+
+// OverlayMount is an overlay mount broken down into its layers.
+type OverlayMount struct {
+	// LowerDirs are the read-only layers, from the topmost to the bottommost.
+	LowerDirs []string
+	// UpperDir is the writable layer. It is empty for read-only overlays.
+	UpperDir string
+	// WorkDir is the work directory of overlayfs, which must be on the same filesystem as UpperDir.
+	WorkDir string
+	// Options are the mount options other than the layer directories.
+	Options []string
+}
+
+// IsOverlayMount returns true if the mount is an overlay mount.
+func IsOverlayMount(mnt *types.Mount) bool {
+	return mnt != nil && mnt.Type == overlayMountType
+}
+
+// ParseOverlayMount breaks the provided overlay mount down into its layers.
+func ParseOverlayMount(mnt *types.Mount) (*OverlayMount, error) {
+	if !IsOverlayMount(mnt) {
+		return nil, fmt.Errorf("mount of type %q is not an overlay mount", mnt.GetType())
+	}
+
+	overlay := &OverlayMount{}
+	for _, opt := range mnt.Options {
+		switch {
+		case strings.HasPrefix(opt, overlayLowerDirOption):
+			overlay.LowerDirs = strings.Split(strings.TrimPrefix(opt, overlayLowerDirOption), ":")
+		case strings.HasPrefix(opt, overlayUpperDirOption):
+			overlay.UpperDir = strings.TrimPrefix(opt, overlayUpperDirOption)
+		case strings.HasPrefix(opt, overlayWorkDirOption):
+			overlay.WorkDir = strings.TrimPrefix(opt, overlayWorkDirOption)
+		default:
+			overlay.Options = append(overlay.Options, opt)
+		}
+	}
+
+	if len(overlay.LowerDirs) == 0 {
+		return nil, fmt.Errorf("overlay mount has no lower layers: %+v", mnt)
+	}
+
+	return overlay, nil
+}
+
+// Mount returns the overlay mount of the layers.
+func (o *OverlayMount) Mount() *types.Mount {
+	options := append([]string{}, o.Options...)
+	if o.UpperDir != "" {
+		options = append(options, overlayWorkDirOption+o.WorkDir, overlayUpperDirOption+o.UpperDir)
+	}
+	options = append(options, overlayLowerDirOption+strings.Join(o.LowerDirs, ":"))
+
+	return &types.Mount{
+		Type:    overlayMountType,
+		Source:  overlayMountType,
+		Options: options,
+	}
+}
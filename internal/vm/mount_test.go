@@ -19,6 +19,7 @@ import (
 	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/mount"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsLocalMount(t *testing.T) {
@@ -69,3 +70,35 @@ func TestStripLocalMountIdentifier(t *testing.T) {
 	localMntProto = StripLocalMountIdentifier(localMntProto)
 	assert.False(t, IsLocalMount(localMntProto), "Mount was vm local after stripping the local mount identifier")
 }
+
+func TestParseOverlayMount(t *testing.T) {
+	mnt := &types.Mount{
+		Type:   "overlay",
+		Source: "overlay",
+		Options: []string{
+			"index=off",
+			"workdir=/layers/work",
+			"upperdir=/layers/upper.img",
+			"lowerdir=/layers/2.img:/layers/1.img",
+		},
+	}
+	assert.True(t, IsOverlayMount(mnt))
+
+	overlay, err := ParseOverlayMount(mnt)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/layers/2.img", "/layers/1.img"}, overlay.LowerDirs)
+	assert.Equal(t, "/layers/upper.img", overlay.UpperDir)
+	assert.Equal(t, "/layers/work", overlay.WorkDir)
+	assert.Equal(t, []string{"index=off"}, overlay.Options)
+	assert.Equal(t, mnt, overlay.Mount())
+
+	// read-only overlays have no upper layer
+	overlay.UpperDir = ""
+	assert.Equal(t, []string{"index=off", "lowerdir=/layers/2.img:/layers/1.img"}, overlay.Mount().Options)
+
+	_, err = ParseOverlayMount(&types.Mount{Type: "overlay", Options: []string{"upperdir=/upper"}})
+	assert.Error(t, err)
+
+	_, err = ParseOverlayMount(&types.Mount{Type: "ext4", Source: "/rootfs.img"})
+	assert.Error(t, err)
+}
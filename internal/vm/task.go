@@ -68,6 +68,9 @@ type TaskManager interface {
 	// IsProxyOpen returns true if the given task or exec has an IO proxy
 	// which hasn't been closed.
 	IsProxyOpen(string, string) (bool, error)
+
+	// TaskCount returns the number of tasks being managed.
+	TaskCount() int
 }
 
 // NewTaskManager initializes a new TaskManager
@@ -172,6 +175,13 @@ func (m *taskManager) ShutdownIfEmpty() bool {
 	return false
 }
 
+func (m *taskManager) TaskCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.tasks)
+}
+
 func (m *taskManager) CreateTask(
 	reqCtx context.Context,
 	req *taskAPI.CreateTaskRequest,
@@ -362,6 +362,7 @@ func TestTaskManager_CreateExecDeleteTask(t *testing.T) {
 	}, ts, mockExec.IOConnectorSet)
 	require.NoError(t, err, "exec failed")
 	execReqCancel()
+	require.Equal(t, 1, tm.TaskCount(), "execs should not be counted as tasks")
 
 	execStdinData := []byte("execstdin")
 	err = mockExec.WriteStdin(execStdinData)
@@ -417,6 +418,7 @@ func TestTaskManager_CreateExecDeleteTask(t *testing.T) {
 	_, err = tm.DeleteProcess(deleteReqCtx, &taskAPI.DeleteRequest{ID: mockTask.TaskID}, ts)
 	require.NoError(t, err, "delete task failed")
 	deleteReqCancel()
+	require.Equal(t, 0, tm.TaskCount())
 
 	didShutdown = tm.ShutdownIfEmpty()
 	require.True(t, didShutdown, "task manager didn't shutdown when all tasks deleted")
@@ -22,8 +22,9 @@ import (
 )
 
 // VSockDialConnector returns an IOConnector for establishing vsock connections
-// that are dialed from the host to a guest listener.
-func VSockDialConnector(timeout time.Duration, udsPath string, port uint32) IOConnector {
+// that are dialed from the host to a guest listener. onRetry, if not nil, is called
+// every time the dial is retried after a temporary failure.
+func VSockDialConnector(timeout time.Duration, udsPath string, port uint32, onRetry func()) IOConnector {
 	return func(procCtx context.Context, logger *logrus.Entry) <-chan IOConnectorResult {
 		returnCh := make(chan IOConnectorResult)
 
@@ -32,7 +33,7 @@ func VSockDialConnector(timeout time.Duration, udsPath string, port uint32) IOCo
 			timeoutCtx, cancel := context.WithTimeout(procCtx, timeout)
 			defer cancel()
 
-			conn, err := vsock.DialContext(timeoutCtx, udsPath, port, vsock.WithLogger(logger))
+			conn, err := vsock.DialContext(timeoutCtx, udsPath, port, vsock.WithLogger(DialRetryLogger(logger, onRetry)))
 			returnCh <- IOConnectorResult{
 				ReadWriteCloser: conn,
 				Err:             err,
@@ -72,3 +73,26 @@ func VSockAcceptConnector(port uint32) IOConnector {
 		return returnCh
 	}
 }
+
+// DialRetryLogger returns a logger to be passed to vsock.WithLogger which calls onRetry
+// every time vsock.DialContext retries a dial. logger is returned as is if onRetry is nil.
+func DialRetryLogger(logger *logrus.Entry, onRetry func()) logrus.FieldLogger {
+	if onRetry == nil {
+		return logger
+	}
+	return &dialRetryLogger{Entry: logger, onRetry: onRetry}
+}
+
+// dialRetryLogger relies on vsock.DialContext logging each dial attempt with a logger
+// carrying the number of the attempt in the "attempt" field.
+type dialRetryLogger struct {
+	*logrus.Entry
+	onRetry func()
+}
+
+func (l *dialRetryLogger) WithField(key string, value interface{}) *logrus.Entry {
+	if attempt, ok := value.(int); ok && key == "attempt" && attempt > 1 {
+		l.onRetry()
+	}
+	return l.Entry.WithField(key, value)
+}
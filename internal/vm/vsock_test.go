@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialRetryLogger(t *testing.T) {
+	logger := logrus.NewEntry(logrus.New())
+	assert.Equal(t, logger, DialRetryLogger(logger, nil))
+
+	retries := 0
+	uut := DialRetryLogger(logger, func() { retries++ })
+	for attempt := 1; attempt <= 3; attempt++ {
+		uut.WithField("attempt", attempt).Debug()
+	}
+	uut.WithField("port", 10).Debug()
+
+	assert.Equal(t, 2, retries, "only the attempts after the first one should count as retries")
+}
@@ -20,15 +20,130 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// VMState is the lifecycle state of a VM as reported by its shim.
+type VMState int32
+
+const (
+	VMState_VM_STATE_UNKNOWN VMState = 0
+	VMState_VM_STATE_RUNNING VMState = 1
+	VMState_VM_STATE_PAUSED  VMState = 2
+	VMState_VM_STATE_STOPPED VMState = 3
+)
+
+// Enum value maps for VMState.
+var (
+	VMState_name = map[int32]string{
+		0: "VM_STATE_UNKNOWN",
+		1: "VM_STATE_RUNNING",
+		2: "VM_STATE_PAUSED",
+		3: "VM_STATE_STOPPED",
+	}
+	VMState_value = map[string]int32{
+		"VM_STATE_UNKNOWN": 0,
+		"VM_STATE_RUNNING": 1,
+		"VM_STATE_PAUSED":  2,
+		"VM_STATE_STOPPED": 3,
+	}
+)
+
+func (x VMState) Enum() *VMState {
+	p := new(VMState)
+	*p = x
+	return p
+}
+
+func (x VMState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VMState) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[0].Descriptor()
+}
+
+func (VMState) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[0]
+}
+
+func (x VMState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VMState.Descriptor instead.
+func (VMState) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{0}
+}
+
+// VMHealth is the health of a VM agent as reported by the shim polling it.
+// "HEALTH_UNKNOWN" is reported until the first health check, or if health checks are disabled.
+// "HEALTHY" is reported once a health check succeeds.
+// "UNHEALTHY" is reported after a number of consecutive health checks failed.
+type VMHealth int32
+
+const (
+	VMHealth_HEALTH_UNKNOWN VMHealth = 0
+	VMHealth_HEALTHY        VMHealth = 1
+	VMHealth_UNHEALTHY      VMHealth = 2
+)
+
+// Enum value maps for VMHealth.
+var (
+	VMHealth_name = map[int32]string{
+		0: "HEALTH_UNKNOWN",
+		1: "HEALTHY",
+		2: "UNHEALTHY",
+	}
+	VMHealth_value = map[string]int32{
+		"HEALTH_UNKNOWN": 0,
+		"HEALTHY":        1,
+		"UNHEALTHY":      2,
+	}
+)
+
+func (x VMHealth) Enum() *VMHealth {
+	p := new(VMHealth)
+	*p = x
+	return p
+}
+
+func (x VMHealth) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VMHealth) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[1].Descriptor()
+}
+
+func (VMHealth) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[1]
+}
+
+func (x VMHealth) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VMHealth.Descriptor instead.
+func (VMHealth) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{1}
+}
+
 // DriveExposePolicy is used to configure the method to expose drive files.
 // "COPY" is copying the files to the jail, which is the default behavior.
 // "BIND" is bind-mounting the files on the jail, assuming a caller pre-configures the permissions of
 // the files appropriately.
+// "LINK" is hard-linking the files of read-only drives to the jail, which requires them to be on the
+// same mount as the jail, to be owned by the jail user and to have the mode 0400, as linked files share
+// their owner and mode. Being owned by the jail user, a linked file could still be made writable by a
+// compromised VMM. The files of writable drives, whose writes would otherwise reach the original
+// files, and the files which cannot be linked are copied.
+// "REFLINK" is cloning the files to the jail with FICLONE, which shares their extents until either
+// copy is written to on filesystems such as btrfs and XFS. The files are copied if they cannot be cloned.
 type DriveExposePolicy int32
 
 const (
-	DriveExposePolicy_COPY DriveExposePolicy = 0
-	DriveExposePolicy_BIND DriveExposePolicy = 1
+	DriveExposePolicy_COPY    DriveExposePolicy = 0
+	DriveExposePolicy_BIND    DriveExposePolicy = 1
+	DriveExposePolicy_LINK    DriveExposePolicy = 2
+	DriveExposePolicy_REFLINK DriveExposePolicy = 3
 )
 
 // Enum value maps for DriveExposePolicy.
@@ -36,10 +151,14 @@ var (
 	DriveExposePolicy_name = map[int32]string{
 		0: "COPY",
 		1: "BIND",
+		2: "LINK",
+		3: "REFLINK",
 	}
 	DriveExposePolicy_value = map[string]int32{
-		"COPY": 0,
-		"BIND": 1,
+		"COPY":    0,
+		"BIND":    1,
+		"LINK":    2,
+		"REFLINK": 3,
 	}
 )
 
@@ -54,11 +173,11 @@ func (x DriveExposePolicy) String() string {
 }
 
 func (DriveExposePolicy) Descriptor() protoreflect.EnumDescriptor {
-	return file_firecracker_proto_enumTypes[0].Descriptor()
+	return file_firecracker_proto_enumTypes[2].Descriptor()
 }
 
 func (DriveExposePolicy) Type() protoreflect.EnumType {
-	return &file_firecracker_proto_enumTypes[0]
+	return &file_firecracker_proto_enumTypes[2]
 }
 
 func (x DriveExposePolicy) Number() protoreflect.EnumNumber {
@@ -67,7 +186,113 @@ func (x DriveExposePolicy) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DriveExposePolicy.Descriptor instead.
 func (DriveExposePolicy) EnumDescriptor() ([]byte, []int) {
-	return file_firecracker_proto_rawDescGZIP(), []int{0}
+	return file_firecracker_proto_rawDescGZIP(), []int{2}
+}
+
+// JailerBackend is used to select the program jailing the Firecracker VMM.
+// "DEFAULT_JAILER" uses the backend of the runtime config, which is runc unless configured otherwise.
+// "RUNC_JAILER" runs the VMM in a runc container.
+// "FIRECRACKER_JAILER" runs the VMM with Firecracker's own jailer binary.
+type JailerBackend int32
+
+const (
+	JailerBackend_DEFAULT_JAILER     JailerBackend = 0
+	JailerBackend_RUNC_JAILER        JailerBackend = 1
+	JailerBackend_FIRECRACKER_JAILER JailerBackend = 2
+)
+
+// Enum value maps for JailerBackend.
+var (
+	JailerBackend_name = map[int32]string{
+		0: "DEFAULT_JAILER",
+		1: "RUNC_JAILER",
+		2: "FIRECRACKER_JAILER",
+	}
+	JailerBackend_value = map[string]int32{
+		"DEFAULT_JAILER":     0,
+		"RUNC_JAILER":        1,
+		"FIRECRACKER_JAILER": 2,
+	}
+)
+
+func (x JailerBackend) Enum() *JailerBackend {
+	p := new(JailerBackend)
+	*p = x
+	return p
+}
+
+func (x JailerBackend) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JailerBackend) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[3].Descriptor()
+}
+
+func (JailerBackend) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[3]
+}
+
+func (x JailerBackend) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JailerBackend.Descriptor instead.
+func (JailerBackend) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{3}
+}
+
+// SeccompPolicy is used to select the seccomp profile of the VMM jailed by runc.
+// "TEMPLATE_SECCOMP" keeps the seccomp profile of the runc config template, if any.
+// "DEFAULT_SECCOMP" uses a built-in profile allowing the system calls needed by Firecracker only.
+// "CUSTOM_SECCOMP" uses the profile at the SeccompProfilePath of the jailer config.
+type SeccompPolicy int32
+
+const (
+	SeccompPolicy_TEMPLATE_SECCOMP SeccompPolicy = 0
+	SeccompPolicy_DEFAULT_SECCOMP  SeccompPolicy = 1
+	SeccompPolicy_CUSTOM_SECCOMP   SeccompPolicy = 2
+)
+
+// Enum value maps for SeccompPolicy.
+var (
+	SeccompPolicy_name = map[int32]string{
+		0: "TEMPLATE_SECCOMP",
+		1: "DEFAULT_SECCOMP",
+		2: "CUSTOM_SECCOMP",
+	}
+	SeccompPolicy_value = map[string]int32{
+		"TEMPLATE_SECCOMP": 0,
+		"DEFAULT_SECCOMP":  1,
+		"CUSTOM_SECCOMP":   2,
+	}
+)
+
+func (x SeccompPolicy) Enum() *SeccompPolicy {
+	p := new(SeccompPolicy)
+	*p = x
+	return p
+}
+
+func (x SeccompPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeccompPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[4].Descriptor()
+}
+
+func (SeccompPolicy) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[4]
+}
+
+func (x SeccompPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeccompPolicy.Descriptor instead.
+func (SeccompPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{4}
 }
 
 // CreateVMRequest specifies creation parameters for a new FC instance
@@ -91,14 +316,34 @@ type CreateVMRequest struct {
 	// Specifies the networking configuration for a VM
 	NetworkInterfaces []*FirecrackerNetworkInterface `protobuf:"bytes,7,rep,name=NetworkInterfaces,proto3" json:"NetworkInterfaces,omitempty"`
 	// The number of dummy drives to reserve in advance before running FC instance.
+	// Deprecated: equivalent to setting both MinContainerCount and MaxContainerCount
+	// to the same value. Ignored if either of them is set.
 	ContainerCount int32 `protobuf:"varint,8,opt,name=ContainerCount,proto3" json:"ContainerCount,omitempty"`
 	// Whether the VM should exit after all tasks running in it have been deleted.
-	ExitAfterAllTasksDeleted bool                      `protobuf:"varint,9,opt,name=ExitAfterAllTasksDeleted,proto3" json:"ExitAfterAllTasksDeleted,omitempty"`
-	JailerConfig             *JailerConfig             `protobuf:"bytes,10,opt,name=JailerConfig,proto3" json:"JailerConfig,omitempty"`
-	TimeoutSeconds           uint32                    `protobuf:"varint,11,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty"`
-	LogFifoPath              string                    `protobuf:"bytes,12,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
-	MetricsFifoPath          string                    `protobuf:"bytes,13,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
-	BalloonDevice            *FirecrackerBalloonDevice `protobuf:"bytes,14,opt,name=BalloonDevice,proto3" json:"BalloonDevice,omitempty"`
+	ExitAfterAllTasksDeleted bool          `protobuf:"varint,9,opt,name=ExitAfterAllTasksDeleted,proto3" json:"ExitAfterAllTasksDeleted,omitempty"`
+	JailerConfig             *JailerConfig `protobuf:"bytes,10,opt,name=JailerConfig,proto3" json:"JailerConfig,omitempty"`
+	TimeoutSeconds           uint32        `protobuf:"varint,11,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty"`
+	LogFifoPath              string        `protobuf:"bytes,12,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
+	// FIFO Firecracker writes its metrics to. The shim only reads the FIFO it creates
+	// when this is unset, so the metrics of a VM given a FIFO aren't exported by the shim
+	// nor included in GetVMStats.
+	MetricsFifoPath string                    `protobuf:"bytes,13,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
+	BalloonDevice   *FirecrackerBalloonDevice `protobuf:"bytes,14,opt,name=BalloonDevice,proto3" json:"BalloonDevice,omitempty"`
+	// If set, the VM is restored from the provided snapshot instead of being booted. The
+	// configuration of the VM is taken from the snapshot's manifest; only VMID,
+	// ExitAfterAllTasksDeleted, JailerConfig, TimeoutSeconds, LogFifoPath and MetricsFifoPath
+	// are taken from this request.
+	Snapshot *SnapshotSource `protobuf:"bytes,15,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+	// Firecracker instance metadata (MMDS) to set once the VM has started, as a JSON document.
+	// A warm pool VM is given the metadata of the request it is bound to.
+	Metadata string `protobuf:"bytes,16,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	// The number of container drives ready when the VM boots. Defaults to 1.
+	MinContainerCount int32 `protobuf:"varint,17,opt,name=MinContainerCount,proto3" json:"MinContainerCount,omitempty"`
+	// The maximum number of containers the VM can run at once. Firecracker cannot
+	// attach drives to a running VM, so a drive is attached for each of them at boot,
+	// but drives beyond MinContainerCount are backed by small sparse placeholder files
+	// until a container needs them. Defaults to MinContainerCount.
+	MaxContainerCount int32 `protobuf:"varint,18,opt,name=MaxContainerCount,proto3" json:"MaxContainerCount,omitempty"`
 }
 
 func (x *CreateVMRequest) Reset() {
@@ -231,6 +476,34 @@ func (x *CreateVMRequest) GetBalloonDevice() *FirecrackerBalloonDevice {
 	return nil
 }
 
+func (x *CreateVMRequest) GetSnapshot() *SnapshotSource {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *CreateVMRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *CreateVMRequest) GetMinContainerCount() int32 {
+	if x != nil {
+		return x.MinContainerCount
+	}
+	return 0
+}
+
+func (x *CreateVMRequest) GetMaxContainerCount() int32 {
+	if x != nil {
+		return x.MaxContainerCount
+	}
+	return 0
+}
+
 type CreateVMResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -511,12 +784,17 @@ type GetVMInfoResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID            string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	SocketPath      string `protobuf:"bytes,2,opt,name=SocketPath,proto3" json:"SocketPath,omitempty"`
-	LogFifoPath     string `protobuf:"bytes,3,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
-	MetricsFifoPath string `protobuf:"bytes,4,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
-	CgroupPath      string `protobuf:"bytes,5,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
-	VSockPath       string `protobuf:"bytes,6,opt,name=VSockPath,proto3" json:"VSockPath,omitempty"`
+	VMID            string  `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	SocketPath      string  `protobuf:"bytes,2,opt,name=SocketPath,proto3" json:"SocketPath,omitempty"`
+	LogFifoPath     string  `protobuf:"bytes,3,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
+	MetricsFifoPath string  `protobuf:"bytes,4,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
+	CgroupPath      string  `protobuf:"bytes,5,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	VSockPath       string  `protobuf:"bytes,6,opt,name=VSockPath,proto3" json:"VSockPath,omitempty"`
+	State           VMState `protobuf:"varint,7,opt,name=State,proto3,enum=VMState" json:"State,omitempty"`
+	// Health is whether the VM agent answered the latest health checks of the shim.
+	Health VMHealth `protobuf:"varint,8,opt,name=Health,proto3,enum=VMHealth" json:"Health,omitempty"`
+	// HealthError is the error of the latest failed health check of an unhealthy VM.
+	HealthError string `protobuf:"bytes,9,opt,name=HealthError,proto3" json:"HealthError,omitempty"`
 }
 
 func (x *GetVMInfoResponse) Reset() {
@@ -593,17 +871,35 @@ func (x *GetVMInfoResponse) GetVSockPath() string {
 	return ""
 }
 
-type SetVMMetadataRequest struct {
+func (x *GetVMInfoResponse) GetState() VMState {
+	if x != nil {
+		return x.State
+	}
+	return VMState_VM_STATE_UNKNOWN
+}
+
+func (x *GetVMInfoResponse) GetHealth() VMHealth {
+	if x != nil {
+		return x.Health
+	}
+	return VMHealth_HEALTH_UNKNOWN
+}
+
+func (x *GetVMInfoResponse) GetHealthError() string {
+	if x != nil {
+		return x.HealthError
+	}
+	return ""
+}
+
+type ListVMsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
 }
 
-func (x *SetVMMetadataRequest) Reset() {
-	*x = SetVMMetadataRequest{}
+func (x *ListVMsRequest) Reset() {
+	*x = ListVMsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -611,13 +907,13 @@ func (x *SetVMMetadataRequest) Reset() {
 	}
 }
 
-func (x *SetVMMetadataRequest) String() string {
+func (x *ListVMsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetVMMetadataRequest) ProtoMessage() {}
+func (*ListVMsRequest) ProtoMessage() {}
 
-func (x *SetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *ListVMsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -629,36 +925,21 @@ func (x *SetVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetVMMetadataRequest.ProtoReflect.Descriptor instead.
-func (*SetVMMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListVMsRequest.ProtoReflect.Descriptor instead.
+func (*ListVMsRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SetVMMetadataRequest) GetVMID() string {
-	if x != nil {
-		return x.VMID
-	}
-	return ""
-}
-
-func (x *SetVMMetadataRequest) GetMetadata() string {
-	if x != nil {
-		return x.Metadata
-	}
-	return ""
-}
-
-type UpdateVMMetadataRequest struct {
+type ListVMsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	VMs []*VMInfo `protobuf:"bytes,1,rep,name=VMs,proto3" json:"VMs,omitempty"`
 }
 
-func (x *UpdateVMMetadataRequest) Reset() {
-	*x = UpdateVMMetadataRequest{}
+func (x *ListVMsResponse) Reset() {
+	*x = ListVMsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,13 +947,13 @@ func (x *UpdateVMMetadataRequest) Reset() {
 	}
 }
 
-func (x *UpdateVMMetadataRequest) String() string {
+func (x *ListVMsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateVMMetadataRequest) ProtoMessage() {}
+func (*ListVMsResponse) ProtoMessage() {}
 
-func (x *UpdateVMMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *ListVMsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -684,35 +965,37 @@ func (x *UpdateVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateVMMetadataRequest.ProtoReflect.Descriptor instead.
-func (*UpdateVMMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListVMsResponse.ProtoReflect.Descriptor instead.
+func (*ListVMsResponse) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateVMMetadataRequest) GetVMID() string {
-	if x != nil {
-		return x.VMID
-	}
-	return ""
-}
-
-func (x *UpdateVMMetadataRequest) GetMetadata() string {
+func (x *ListVMsResponse) GetVMs() []*VMInfo {
 	if x != nil {
-		return x.Metadata
+		return x.VMs
 	}
-	return ""
+	return nil
 }
 
-type GetVMMetadataRequest struct {
+// VMInfo describes a single VM managed by the firecracker-control plugin.
+type VMInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-}
-
-func (x *GetVMMetadataRequest) Reset() {
-	*x = GetVMMetadataRequest{}
+	// PID of the runtime shim managing the VM, or 0 if the shim is not running.
+	ShimPID         uint32  `protobuf:"varint,2,opt,name=ShimPID,proto3" json:"ShimPID,omitempty"`
+	SocketPath      string  `protobuf:"bytes,3,opt,name=SocketPath,proto3" json:"SocketPath,omitempty"`
+	VSockPath       string  `protobuf:"bytes,4,opt,name=VSockPath,proto3" json:"VSockPath,omitempty"`
+	LogFifoPath     string  `protobuf:"bytes,5,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
+	MetricsFifoPath string  `protobuf:"bytes,6,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
+	CgroupPath      string  `protobuf:"bytes,7,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	State           VMState `protobuf:"varint,8,opt,name=State,proto3,enum=VMState" json:"State,omitempty"`
+}
+
+func (x *VMInfo) Reset() {
+	*x = VMInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -720,13 +1003,13 @@ func (x *GetVMMetadataRequest) Reset() {
 	}
 }
 
-func (x *GetVMMetadataRequest) String() string {
+func (x *VMInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVMMetadataRequest) ProtoMessage() {}
+func (*VMInfo) ProtoMessage() {}
 
-func (x *GetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *VMInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -738,28 +1021,83 @@ func (x *GetVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVMMetadataRequest.ProtoReflect.Descriptor instead.
-func (*GetVMMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VMInfo.ProtoReflect.Descriptor instead.
+func (*VMInfo) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetVMMetadataRequest) GetVMID() string {
+func (x *VMInfo) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-type GetVMMetadataResponse struct {
+func (x *VMInfo) GetShimPID() uint32 {
+	if x != nil {
+		return x.ShimPID
+	}
+	return 0
+}
+
+func (x *VMInfo) GetSocketPath() string {
+	if x != nil {
+		return x.SocketPath
+	}
+	return ""
+}
+
+func (x *VMInfo) GetVSockPath() string {
+	if x != nil {
+		return x.VSockPath
+	}
+	return ""
+}
+
+func (x *VMInfo) GetLogFifoPath() string {
+	if x != nil {
+		return x.LogFifoPath
+	}
+	return ""
+}
+
+func (x *VMInfo) GetMetricsFifoPath() string {
+	if x != nil {
+		return x.MetricsFifoPath
+	}
+	return ""
+}
+
+func (x *VMInfo) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *VMInfo) GetState() VMState {
+	if x != nil {
+		return x.State
+	}
+	return VMState_VM_STATE_UNKNOWN
+}
+
+type CreateSnapshotRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Metadata string `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Path on the host where the guest memory file will be written
+	MemFilePath string `protobuf:"bytes,2,opt,name=MemFilePath,proto3" json:"MemFilePath,omitempty"`
+	// Path on the host where the VM state file will be written
+	SnapshotPath string `protobuf:"bytes,3,opt,name=SnapshotPath,proto3" json:"SnapshotPath,omitempty"`
+	// Path on the host where the snapshot manifest will be written
+	ManifestPath string `protobuf:"bytes,4,opt,name=ManifestPath,proto3" json:"ManifestPath,omitempty"`
 }
 
-func (x *GetVMMetadataResponse) Reset() {
-	*x = GetVMMetadataResponse{}
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -767,13 +1105,13 @@ func (x *GetVMMetadataResponse) Reset() {
 	}
 }
 
-func (x *GetVMMetadataResponse) String() string {
+func (x *CreateSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVMMetadataResponse) ProtoMessage() {}
+func (*CreateSnapshotRequest) ProtoMessage() {}
 
-func (x *GetVMMetadataResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -785,57 +1123,52 @@ func (x *GetVMMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVMMetadataResponse.ProtoReflect.Descriptor instead.
-func (*GetVMMetadataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetVMMetadataResponse) GetMetadata() string {
+func (x *CreateSnapshotRequest) GetVMID() string {
 	if x != nil {
-		return x.Metadata
+		return x.VMID
 	}
 	return ""
 }
 
-type JailerConfig struct {
+func (x *CreateSnapshotRequest) GetMemFilePath() string {
+	if x != nil {
+		return x.MemFilePath
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetSnapshotPath() string {
+	if x != nil {
+		return x.SnapshotPath
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetManifestPath() string {
+	if x != nil {
+		return x.ManifestPath
+	}
+	return ""
+}
+
+// SnapshotSource specifies the files written by CreateSnapshot to restore a VM from
+type SnapshotSource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetNS string `protobuf:"bytes,1,opt,name=NetNS,proto3" json:"NetNS,omitempty"`
-	// List of the physical numbers of the CPUs on which processes in that
-	// cpuset are allowed to execute.  See List Format below for a description
-	// of the format of cpus.
-	//
-	// The CPUs allowed to a cpuset may be changed by writing a new list to its
-	// cpus file.
-	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
-	//
-	// This is formatted as specified in the cpuset man page under "List Format"
-	// http://man7.org/linux/man-pages/man7/cpuset.7.html
-	CPUs string `protobuf:"bytes,2,opt,name=CPUs,proto3" json:"CPUs,omitempty"`
-	// List of memory nodes on which processes in this cpuset are allowed to
-	// allocate memory.  See List Format below for a description of the format
-	// of mems.
-	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
-	//
-	// This is formatted as specified in the cpuset man page under "List Format"
-	// http://man7.org/linux/man-pages/man7/cpuset.7.html
-	Mems string `protobuf:"bytes,3,opt,name=Mems,proto3" json:"Mems,omitempty"`
-	UID  uint32 `protobuf:"varint,4,opt,name=UID,proto3" json:"UID,omitempty"`
-	GID  uint32 `protobuf:"varint,5,opt,name=GID,proto3" json:"GID,omitempty"`
-	// CgroupPath is used to dictate where the cgroup should be located
-	// relative to the cgroup directory which is
-	// /sys/fs/cgroup/cpu/<CgroupPath>/<vmID>
-	// if no value was provided, then /firecracker-containerd will be used as
-	// the default value
-	CgroupPath string `protobuf:"bytes,6,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
-	// DriveExposePolicy is used to configure the method to expose drive files.
-	DriveExposePolicy DriveExposePolicy `protobuf:"varint,7,opt,name=DriveExposePolicy,proto3,enum=DriveExposePolicy" json:"DriveExposePolicy,omitempty"`
+	MemFilePath  string `protobuf:"bytes,1,opt,name=MemFilePath,proto3" json:"MemFilePath,omitempty"`
+	SnapshotPath string `protobuf:"bytes,2,opt,name=SnapshotPath,proto3" json:"SnapshotPath,omitempty"`
+	ManifestPath string `protobuf:"bytes,3,opt,name=ManifestPath,proto3" json:"ManifestPath,omitempty"`
 }
 
-func (x *JailerConfig) Reset() {
-	*x = JailerConfig{}
+func (x *SnapshotSource) Reset() {
+	*x = SnapshotSource{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -843,13 +1176,13 @@ func (x *JailerConfig) Reset() {
 	}
 }
 
-func (x *JailerConfig) String() string {
+func (x *SnapshotSource) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JailerConfig) ProtoMessage() {}
+func (*SnapshotSource) ProtoMessage() {}
 
-func (x *JailerConfig) ProtoReflect() protoreflect.Message {
+func (x *SnapshotSource) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -861,71 +1194,46 @@ func (x *JailerConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JailerConfig.ProtoReflect.Descriptor instead.
-func (*JailerConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotSource.ProtoReflect.Descriptor instead.
+func (*SnapshotSource) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *JailerConfig) GetNetNS() string {
-	if x != nil {
-		return x.NetNS
-	}
-	return ""
-}
-
-func (x *JailerConfig) GetCPUs() string {
+func (x *SnapshotSource) GetMemFilePath() string {
 	if x != nil {
-		return x.CPUs
+		return x.MemFilePath
 	}
 	return ""
 }
 
-func (x *JailerConfig) GetMems() string {
+func (x *SnapshotSource) GetSnapshotPath() string {
 	if x != nil {
-		return x.Mems
+		return x.SnapshotPath
 	}
 	return ""
 }
 
-func (x *JailerConfig) GetUID() uint32 {
-	if x != nil {
-		return x.UID
-	}
-	return 0
-}
-
-func (x *JailerConfig) GetGID() uint32 {
+func (x *SnapshotSource) GetManifestPath() string {
 	if x != nil {
-		return x.GID
-	}
-	return 0
-}
-
-func (x *JailerConfig) GetCgroupPath() string {
-	if x != nil {
-		return x.CgroupPath
+		return x.ManifestPath
 	}
 	return ""
 }
 
-func (x *JailerConfig) GetDriveExposePolicy() DriveExposePolicy {
-	if x != nil {
-		return x.DriveExposePolicy
-	}
-	return DriveExposePolicy_COPY
-}
-
-type UpdateBalloonRequest struct {
+// SnapshotManifest records the configuration of a VM at the time it was snapshotted,
+// which is needed to set up a new shim and jail the snapshot can be loaded into.
+type SnapshotManifest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID      string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	AmountMib int64  `protobuf:"varint,2,opt,name=AmountMib,proto3" json:"AmountMib,omitempty"`
+	Request         *CreateVMRequest     `protobuf:"bytes,1,opt,name=Request,proto3" json:"Request,omitempty"`
+	ContainerStubs  []*SnapshotStubDrive `protobuf:"bytes,2,rep,name=ContainerStubs,proto3" json:"ContainerStubs,omitempty"`
+	DriveMountStubs []*SnapshotStubDrive `protobuf:"bytes,3,rep,name=DriveMountStubs,proto3" json:"DriveMountStubs,omitempty"`
 }
 
-func (x *UpdateBalloonRequest) Reset() {
-	*x = UpdateBalloonRequest{}
+func (x *SnapshotManifest) Reset() {
+	*x = SnapshotManifest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,13 +1241,13 @@ func (x *UpdateBalloonRequest) Reset() {
 	}
 }
 
-func (x *UpdateBalloonRequest) String() string {
+func (x *SnapshotManifest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBalloonRequest) ProtoMessage() {}
+func (*SnapshotManifest) ProtoMessage() {}
 
-func (x *UpdateBalloonRequest) ProtoReflect() protoreflect.Message {
+func (x *SnapshotManifest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -951,35 +1259,45 @@ func (x *UpdateBalloonRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBalloonRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBalloonRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotManifest.ProtoReflect.Descriptor instead.
+func (*SnapshotManifest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateBalloonRequest) GetVMID() string {
+func (x *SnapshotManifest) GetRequest() *CreateVMRequest {
 	if x != nil {
-		return x.VMID
+		return x.Request
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateBalloonRequest) GetAmountMib() int64 {
+func (x *SnapshotManifest) GetContainerStubs() []*SnapshotStubDrive {
 	if x != nil {
-		return x.AmountMib
+		return x.ContainerStubs
 	}
-	return 0
+	return nil
 }
 
-type GetBalloonConfigRequest struct {
+func (x *SnapshotManifest) GetDriveMountStubs() []*SnapshotStubDrive {
+	if x != nil {
+		return x.DriveMountStubs
+	}
+	return nil
+}
+
+// SnapshotStubDrive maps a Firecracker drive to the stub file backing it
+type SnapshotStubDrive struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	DriveID      string                 `protobuf:"bytes,1,opt,name=DriveID,proto3" json:"DriveID,omitempty"`
+	StubFileName string                 `protobuf:"bytes,2,opt,name=StubFileName,proto3" json:"StubFileName,omitempty"`
+	DriveMount   *FirecrackerDriveMount `protobuf:"bytes,3,opt,name=DriveMount,proto3" json:"DriveMount,omitempty"`
 }
 
-func (x *GetBalloonConfigRequest) Reset() {
-	*x = GetBalloonConfigRequest{}
+func (x *SnapshotStubDrive) Reset() {
+	*x = SnapshotStubDrive{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -987,13 +1305,13 @@ func (x *GetBalloonConfigRequest) Reset() {
 	}
 }
 
-func (x *GetBalloonConfigRequest) String() string {
+func (x *SnapshotStubDrive) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonConfigRequest) ProtoMessage() {}
+func (*SnapshotStubDrive) ProtoMessage() {}
 
-func (x *GetBalloonConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *SnapshotStubDrive) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1005,28 +1323,52 @@ func (x *GetBalloonConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetBalloonConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotStubDrive.ProtoReflect.Descriptor instead.
+func (*SnapshotStubDrive) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetBalloonConfigRequest) GetVMID() string {
+func (x *SnapshotStubDrive) GetDriveID() string {
 	if x != nil {
-		return x.VMID
+		return x.DriveID
 	}
 	return ""
 }
 
-type GetBalloonConfigResponse struct {
+func (x *SnapshotStubDrive) GetStubFileName() string {
+	if x != nil {
+		return x.StubFileName
+	}
+	return ""
+}
+
+func (x *SnapshotStubDrive) GetDriveMount() *FirecrackerDriveMount {
+	if x != nil {
+		return x.DriveMount
+	}
+	return nil
+}
+
+type AttachDriveRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BalloonConfig *FirecrackerBalloonDevice `protobuf:"bytes,1,opt,name=BalloonConfig,proto3" json:"BalloonConfig,omitempty"`
-}
-
-func (x *GetBalloonConfigResponse) Reset() {
-	*x = GetBalloonConfigResponse{}
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Identifies the drive in DetachDrive calls. It must be unique among the drives
+	// attached to the VM.
+	DriveID string `protobuf:"bytes,2,opt,name=DriveID,proto3" json:"DriveID,omitempty"`
+	// The drive to attach. It is backed by one of the VM's spare container drives, so
+	// RateLimiter and CacheType cannot be set. With the BIND DriveExposePolicy, regular
+	// files can only be attached if they were exposed to the jail when the VM was created.
+	// Firecracker cannot change whether a drive is read-only once the VM has booted, so
+	// IsWritable must be set: the spare drive backing it is writable, and a guest able
+	// to remount a read-only mount read-write could write to the host file.
+	DriveMount *FirecrackerDriveMount `protobuf:"bytes,3,opt,name=DriveMount,proto3" json:"DriveMount,omitempty"`
+}
+
+func (x *AttachDriveRequest) Reset() {
+	*x = AttachDriveRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1034,13 +1376,13 @@ func (x *GetBalloonConfigResponse) Reset() {
 	}
 }
 
-func (x *GetBalloonConfigResponse) String() string {
+func (x *AttachDriveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonConfigResponse) ProtoMessage() {}
+func (*AttachDriveRequest) ProtoMessage() {}
 
-func (x *GetBalloonConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *AttachDriveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1052,28 +1394,43 @@ func (x *GetBalloonConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetBalloonConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttachDriveRequest.ProtoReflect.Descriptor instead.
+func (*AttachDriveRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetBalloonConfigResponse) GetBalloonConfig() *FirecrackerBalloonDevice {
+func (x *AttachDriveRequest) GetVMID() string {
 	if x != nil {
-		return x.BalloonConfig
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *AttachDriveRequest) GetDriveID() string {
+	if x != nil {
+		return x.DriveID
+	}
+	return ""
+}
+
+func (x *AttachDriveRequest) GetDriveMount() *FirecrackerDriveMount {
+	if x != nil {
+		return x.DriveMount
 	}
 	return nil
 }
 
-type GetBalloonStatsRequest struct {
+type DetachDriveRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	VMID    string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	DriveID string `protobuf:"bytes,2,opt,name=DriveID,proto3" json:"DriveID,omitempty"`
 }
 
-func (x *GetBalloonStatsRequest) Reset() {
-	*x = GetBalloonStatsRequest{}
+func (x *DetachDriveRequest) Reset() {
+	*x = DetachDriveRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1081,13 +1438,13 @@ func (x *GetBalloonStatsRequest) Reset() {
 	}
 }
 
-func (x *GetBalloonStatsRequest) String() string {
+func (x *DetachDriveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonStatsRequest) ProtoMessage() {}
+func (*DetachDriveRequest) ProtoMessage() {}
 
-func (x *GetBalloonStatsRequest) ProtoReflect() protoreflect.Message {
+func (x *DetachDriveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1099,56 +1456,36 @@ func (x *GetBalloonStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetBalloonStatsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetachDriveRequest.ProtoReflect.Descriptor instead.
+func (*DetachDriveRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetBalloonStatsRequest) GetVMID() string {
+func (x *DetachDriveRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-type GetBalloonStatsResponse struct {
+func (x *DetachDriveRequest) GetDriveID() string {
+	if x != nil {
+		return x.DriveID
+	}
+	return ""
+}
+
+type SetVMMetadataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Actual amount of memory (in MiB) the device is holding.
-	ActualMib int64 `protobuf:"varint,1,opt,name=ActualMib,proto3" json:"ActualMib,omitempty"`
-	// Actual number of pages the device is holding.
-	ActualPages int64 `protobuf:"varint,2,opt,name=ActualPages,proto3" json:"ActualPages,omitempty"`
-	// An estimate of how much memory is available (in bytes) for starting new applications, without pushing the system to swap.
-	AvailableMemory int64 `protobuf:"varint,3,opt,name=AvailableMemory,proto3" json:"AvailableMemory,omitempty"`
-	// The amount of memory, in bytes, that can be quickly reclaimed without additional I/O.
-	// Typically these pages are used for caching files from disk.
-	DiskCaches int64 `protobuf:"varint,4,opt,name=DiskCaches,proto3" json:"DiskCaches,omitempty"`
-	// The amount of memory not being used for any purpose (in bytes).
-	FreeMemory int64 `protobuf:"varint,5,opt,name=FreeMemory,proto3" json:"FreeMemory,omitempty"`
-	// The number of successful hugetlb page allocations in the guest.
-	HugetlbAllocations int64 `protobuf:"varint,6,opt,name=HugetlbAllocations,proto3" json:"HugetlbAllocations,omitempty"`
-	// The number of failed hugetlb page allocations in the guest.
-	HugetlbFailures int64 `protobuf:"varint,7,opt,name=HugetlbFailures,proto3" json:"HugetlbFailures,omitempty"`
-	// The number of major page faults that have occurred.
-	MajorFaults int64 `protobuf:"varint,8,opt,name=MajorFaults,proto3" json:"MajorFaults,omitempty"`
-	// The number of minor page faults that have occurred.
-	MinorFaults int64 `protobuf:"varint,9,opt,name=MinorFaults,proto3" json:"MinorFaults,omitempty"`
-	// The amount of memory that has been swapped in (in bytes).
-	SwapIn int64 `protobuf:"varint,10,opt,name=SwapIn,proto3" json:"SwapIn,omitempty"`
-	// The amount of memory that has been swapped out to disk (in bytes).
-	SwapOut int64 `protobuf:"varint,11,opt,name=SwapOut,proto3" json:"SwapOut,omitempty"`
-	// Target amount of memory (in MiB) the device aims to hold.
-	TargetMib int64 `protobuf:"varint,12,opt,name=TargetMib,proto3" json:"TargetMib,omitempty"`
-	// Target number of pages the device aims to hold.
-	TargetPages int64 `protobuf:"varint,13,opt,name=TargetPages,proto3" json:"TargetPages,omitempty"`
-	// The total amount of memory available (in bytes).
-	TotalMemory int64 `protobuf:"varint,14,opt,name=TotalMemory,proto3" json:"TotalMemory,omitempty"`
+	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
 }
 
-func (x *GetBalloonStatsResponse) Reset() {
-	*x = GetBalloonStatsResponse{}
+func (x *SetVMMetadataRequest) Reset() {
+	*x = SetVMMetadataRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1156,13 +1493,13 @@ func (x *GetBalloonStatsResponse) Reset() {
 	}
 }
 
-func (x *GetBalloonStatsResponse) String() string {
+func (x *SetVMMetadataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonStatsResponse) ProtoMessage() {}
+func (*SetVMMetadataRequest) ProtoMessage() {}
 
-func (x *GetBalloonStatsResponse) ProtoReflect() protoreflect.Message {
+func (x *SetVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1174,135 +1511,2031 @@ func (x *GetBalloonStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetBalloonStatsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetVMMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetVMMetadataRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetBalloonStatsResponse) GetActualMib() int64 {
+func (x *SetVMMetadataRequest) GetVMID() string {
 	if x != nil {
-		return x.ActualMib
+		return x.VMID
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetActualPages() int64 {
+func (x *SetVMMetadataRequest) GetMetadata() string {
 	if x != nil {
-		return x.ActualPages
+		return x.Metadata
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetAvailableMemory() int64 {
-	if x != nil {
-		return x.AvailableMemory
-	}
-	return 0
+type UpdateVMMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
 }
 
-func (x *GetBalloonStatsResponse) GetDiskCaches() int64 {
-	if x != nil {
-		return x.DiskCaches
+func (x *UpdateVMMetadataRequest) Reset() {
+	*x = UpdateVMMetadataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *GetBalloonStatsResponse) GetFreeMemory() int64 {
-	if x != nil {
-		return x.FreeMemory
-	}
+func (x *UpdateVMMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVMMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateVMMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVMMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVMMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateVMMetadataRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateVMMetadataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type GetVMMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetVMMetadataRequest) Reset() {
+	*x = GetVMMetadataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMMetadataRequest) ProtoMessage() {}
+
+func (x *GetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetVMMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetVMMetadataRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetVMMetadataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata string `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *GetVMMetadataResponse) Reset() {
+	*x = GetVMMetadataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMMetadataResponse) ProtoMessage() {}
+
+func (x *GetVMMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetVMMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetVMMetadataResponse) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type JailerConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetNS string `protobuf:"bytes,1,opt,name=NetNS,proto3" json:"NetNS,omitempty"`
+	// List of the physical numbers of the CPUs on which processes in that
+	// cpuset are allowed to execute.  See List Format below for a description
+	// of the format of cpus.
+	//
+	// The CPUs allowed to a cpuset may be changed by writing a new list to its
+	// cpus file.
+	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
+	//
+	// This is formatted as specified in the cpuset man page under "List Format"
+	// http://man7.org/linux/man-pages/man7/cpuset.7.html
+	CPUs string `protobuf:"bytes,2,opt,name=CPUs,proto3" json:"CPUs,omitempty"`
+	// List of memory nodes on which processes in this cpuset are allowed to
+	// allocate memory.  See List Format below for a description of the format
+	// of mems.
+	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
+	//
+	// This is formatted as specified in the cpuset man page under "List Format"
+	// http://man7.org/linux/man-pages/man7/cpuset.7.html
+	Mems string `protobuf:"bytes,3,opt,name=Mems,proto3" json:"Mems,omitempty"`
+	UID  uint32 `protobuf:"varint,4,opt,name=UID,proto3" json:"UID,omitempty"`
+	GID  uint32 `protobuf:"varint,5,opt,name=GID,proto3" json:"GID,omitempty"`
+	// CgroupPath is used to dictate where the cgroup should be located
+	// relative to the root of the cgroup hierarchies, which is
+	// /sys/fs/cgroup/<CgroupPath>/<vmID> on unified cgroup v2 hosts, and
+	// /sys/fs/cgroup/<controller>/<CgroupPath>/<vmID> on cgroup v1 hosts.
+	// if no value was provided, then /firecracker-containerd will be used as
+	// the default value
+	CgroupPath string `protobuf:"bytes,6,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	// DriveExposePolicy is used to configure the method to expose drive files.
+	DriveExposePolicy DriveExposePolicy `protobuf:"varint,7,opt,name=DriveExposePolicy,proto3,enum=DriveExposePolicy" json:"DriveExposePolicy,omitempty"`
+	// Backend is used to select the program jailing the Firecracker VMM.
+	Backend JailerBackend `protobuf:"varint,8,opt,name=Backend,proto3,enum=JailerBackend" json:"Backend,omitempty"`
+	// MemoryMax limits the memory usage of the cgroup, in bytes. It is the
+	// memory.max of the cgroup with cgroup v2, and its memory.limit_in_bytes
+	// with cgroup v1.
+	MemoryMax int64 `protobuf:"varint,9,opt,name=MemoryMax,proto3" json:"MemoryMax,omitempty"`
+	// CPUQuota limits the CPU time of the cgroup to CPUQuota microseconds every
+	// CPUPeriod microseconds, which defaults to 100000. They are the cpu.max of
+	// the cgroup with cgroup v2, and its cpu.cfs_quota_us and cpu.cfs_period_us
+	// with cgroup v1.
+	CPUQuota  int64  `protobuf:"varint,10,opt,name=CPUQuota,proto3" json:"CPUQuota,omitempty"`
+	CPUPeriod uint64 `protobuf:"varint,11,opt,name=CPUPeriod,proto3" json:"CPUPeriod,omitempty"`
+	// IOMax limits the I/O of the cgroup per block device. It is the io.max of
+	// the cgroup with cgroup v2, and its blkio.throttle.* files with cgroup v1.
+	// The firecracker backend rejects it with cgroup v2, as Firecracker's jailer
+	// cannot set io.max.
+	IOMax []*CgroupIOLimit `protobuf:"bytes,12,rep,name=IOMax,proto3" json:"IOMax,omitempty"`
+	// PidsMax limits the number of processes and threads in the cgroup. It is
+	// the pids.max of the cgroup.
+	PidsMax int64 `protobuf:"varint,13,opt,name=PidsMax,proto3" json:"PidsMax,omitempty"`
+	// SeccompPolicy selects the seccomp profile applied to the VMM.
+	SeccompPolicy SeccompPolicy `protobuf:"varint,14,opt,name=SeccompPolicy,proto3,enum=SeccompPolicy" json:"SeccompPolicy,omitempty"`
+	// SeccompProfilePath is the path to a JSON file holding the seccomp profile
+	// of the VMM, formatted as the "seccomp" object of an OCI runtime spec. It
+	// must be set with the CUSTOM_SECCOMP policy only.
+	SeccompProfilePath string `protobuf:"bytes,15,opt,name=SeccompProfilePath,proto3" json:"SeccompProfilePath,omitempty"`
+	// DropCapabilities drops all the capabilities of the VMM, except the ones
+	// in AllowedCapabilities, regardless of the runc config template.
+	DropCapabilities bool `protobuf:"varint,16,opt,name=DropCapabilities,proto3" json:"DropCapabilities,omitempty"`
+	// AllowedCapabilities are the names of the capabilities the VMM keeps when
+	// DropCapabilities is set, such as "CAP_NET_ADMIN".
+	AllowedCapabilities []string `protobuf:"bytes,17,rep,name=AllowedCapabilities,proto3" json:"AllowedCapabilities,omitempty"`
+	// UIDMappings and GIDMappings run the VMM in a new user namespace with the
+	// provided mappings. They must map the root user and group of the
+	// namespace, along with the UID and GID of the jailer config, which are
+	// host IDs, so that the VMM is never root on the host.
+	UIDMappings []*IDMapping `protobuf:"bytes,18,rep,name=UIDMappings,proto3" json:"UIDMappings,omitempty"`
+	GIDMappings []*IDMapping `protobuf:"bytes,19,rep,name=GIDMappings,proto3" json:"GIDMappings,omitempty"`
+}
+
+func (x *JailerConfig) Reset() {
+	*x = JailerConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JailerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JailerConfig) ProtoMessage() {}
+
+func (x *JailerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JailerConfig.ProtoReflect.Descriptor instead.
+func (*JailerConfig) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *JailerConfig) GetNetNS() string {
+	if x != nil {
+		return x.NetNS
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetCPUs() string {
+	if x != nil {
+		return x.CPUs
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetMems() string {
+	if x != nil {
+		return x.Mems
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetUID() uint32 {
+	if x != nil {
+		return x.UID
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetGID() uint32 {
+	if x != nil {
+		return x.GID
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetDriveExposePolicy() DriveExposePolicy {
+	if x != nil {
+		return x.DriveExposePolicy
+	}
+	return DriveExposePolicy_COPY
+}
+
+func (x *JailerConfig) GetBackend() JailerBackend {
+	if x != nil {
+		return x.Backend
+	}
+	return JailerBackend_DEFAULT_JAILER
+}
+
+func (x *JailerConfig) GetMemoryMax() int64 {
+	if x != nil {
+		return x.MemoryMax
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetCPUQuota() int64 {
+	if x != nil {
+		return x.CPUQuota
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetCPUPeriod() uint64 {
+	if x != nil {
+		return x.CPUPeriod
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetIOMax() []*CgroupIOLimit {
+	if x != nil {
+		return x.IOMax
+	}
+	return nil
+}
+
+func (x *JailerConfig) GetPidsMax() int64 {
+	if x != nil {
+		return x.PidsMax
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetSeccompPolicy() SeccompPolicy {
+	if x != nil {
+		return x.SeccompPolicy
+	}
+	return SeccompPolicy_TEMPLATE_SECCOMP
+}
+
+func (x *JailerConfig) GetSeccompProfilePath() string {
+	if x != nil {
+		return x.SeccompProfilePath
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetDropCapabilities() bool {
+	if x != nil {
+		return x.DropCapabilities
+	}
+	return false
+}
+
+func (x *JailerConfig) GetAllowedCapabilities() []string {
+	if x != nil {
+		return x.AllowedCapabilities
+	}
+	return nil
+}
+
+func (x *JailerConfig) GetUIDMappings() []*IDMapping {
+	if x != nil {
+		return x.UIDMappings
+	}
+	return nil
+}
+
+func (x *JailerConfig) GetGIDMappings() []*IDMapping {
+	if x != nil {
+		return x.GIDMappings
+	}
+	return nil
+}
+
+// IDMapping maps Size IDs of a user namespace, starting at ContainerID, to
+// the IDs of the host starting at HostID.
+type IDMapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContainerID uint32 `protobuf:"varint,1,opt,name=ContainerID,proto3" json:"ContainerID,omitempty"`
+	HostID      uint32 `protobuf:"varint,2,opt,name=HostID,proto3" json:"HostID,omitempty"`
+	Size        uint32 `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
+}
+
+func (x *IDMapping) Reset() {
+	*x = IDMapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IDMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDMapping) ProtoMessage() {}
+
+func (x *IDMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDMapping.ProtoReflect.Descriptor instead.
+func (*IDMapping) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IDMapping) GetContainerID() uint32 {
+	if x != nil {
+		return x.ContainerID
+	}
+	return 0
+}
+
+func (x *IDMapping) GetHostID() uint32 {
+	if x != nil {
+		return x.HostID
+	}
+	return 0
+}
+
+func (x *IDMapping) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// CgroupIOLimit limits the I/O of a cgroup on a block device. A limit is left
+// unset if its value is 0.
+type CgroupIOLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device is the path to the block device, or its major and minor numbers
+	// formatted as "major:minor".
+	Device    string `protobuf:"bytes,1,opt,name=Device,proto3" json:"Device,omitempty"`
+	ReadBps   uint64 `protobuf:"varint,2,opt,name=ReadBps,proto3" json:"ReadBps,omitempty"`
+	WriteBps  uint64 `protobuf:"varint,3,opt,name=WriteBps,proto3" json:"WriteBps,omitempty"`
+	ReadIOPS  uint64 `protobuf:"varint,4,opt,name=ReadIOPS,proto3" json:"ReadIOPS,omitempty"`
+	WriteIOPS uint64 `protobuf:"varint,5,opt,name=WriteIOPS,proto3" json:"WriteIOPS,omitempty"`
+}
+
+func (x *CgroupIOLimit) Reset() {
+	*x = CgroupIOLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CgroupIOLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgroupIOLimit) ProtoMessage() {}
+
+func (x *CgroupIOLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CgroupIOLimit.ProtoReflect.Descriptor instead.
+func (*CgroupIOLimit) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CgroupIOLimit) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *CgroupIOLimit) GetReadBps() uint64 {
+	if x != nil {
+		return x.ReadBps
+	}
+	return 0
+}
+
+func (x *CgroupIOLimit) GetWriteBps() uint64 {
+	if x != nil {
+		return x.WriteBps
+	}
+	return 0
+}
+
+func (x *CgroupIOLimit) GetReadIOPS() uint64 {
+	if x != nil {
+		return x.ReadIOPS
+	}
+	return 0
+}
+
+func (x *CgroupIOLimit) GetWriteIOPS() uint64 {
+	if x != nil {
+		return x.WriteIOPS
+	}
+	return 0
+}
+
+type UpdateBalloonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID      string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	AmountMib int64  `protobuf:"varint,2,opt,name=AmountMib,proto3" json:"AmountMib,omitempty"`
+}
+
+func (x *UpdateBalloonRequest) Reset() {
+	*x = UpdateBalloonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBalloonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBalloonRequest) ProtoMessage() {}
+
+func (x *UpdateBalloonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBalloonRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBalloonRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateBalloonRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateBalloonRequest) GetAmountMib() int64 {
+	if x != nil {
+		return x.AmountMib
+	}
+	return 0
+}
+
+type GetBalloonConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetBalloonConfigRequest) Reset() {
+	*x = GetBalloonConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonConfigRequest) ProtoMessage() {}
+
+func (x *GetBalloonConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetBalloonConfigRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBalloonConfigRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetBalloonConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BalloonConfig *FirecrackerBalloonDevice `protobuf:"bytes,1,opt,name=BalloonConfig,proto3" json:"BalloonConfig,omitempty"`
+}
+
+func (x *GetBalloonConfigResponse) Reset() {
+	*x = GetBalloonConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonConfigResponse) ProtoMessage() {}
+
+func (x *GetBalloonConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetBalloonConfigResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBalloonConfigResponse) GetBalloonConfig() *FirecrackerBalloonDevice {
+	if x != nil {
+		return x.BalloonConfig
+	}
+	return nil
+}
+
+type GetBalloonStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetBalloonStatsRequest) Reset() {
+	*x = GetBalloonStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonStatsRequest) ProtoMessage() {}
+
+func (x *GetBalloonStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetBalloonStatsRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetBalloonStatsRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetBalloonStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Actual amount of memory (in MiB) the device is holding.
+	ActualMib int64 `protobuf:"varint,1,opt,name=ActualMib,proto3" json:"ActualMib,omitempty"`
+	// Actual number of pages the device is holding.
+	ActualPages int64 `protobuf:"varint,2,opt,name=ActualPages,proto3" json:"ActualPages,omitempty"`
+	// An estimate of how much memory is available (in bytes) for starting new applications, without pushing the system to swap.
+	AvailableMemory int64 `protobuf:"varint,3,opt,name=AvailableMemory,proto3" json:"AvailableMemory,omitempty"`
+	// The amount of memory, in bytes, that can be quickly reclaimed without additional I/O.
+	// Typically these pages are used for caching files from disk.
+	DiskCaches int64 `protobuf:"varint,4,opt,name=DiskCaches,proto3" json:"DiskCaches,omitempty"`
+	// The amount of memory not being used for any purpose (in bytes).
+	FreeMemory int64 `protobuf:"varint,5,opt,name=FreeMemory,proto3" json:"FreeMemory,omitempty"`
+	// The number of successful hugetlb page allocations in the guest.
+	HugetlbAllocations int64 `protobuf:"varint,6,opt,name=HugetlbAllocations,proto3" json:"HugetlbAllocations,omitempty"`
+	// The number of failed hugetlb page allocations in the guest.
+	HugetlbFailures int64 `protobuf:"varint,7,opt,name=HugetlbFailures,proto3" json:"HugetlbFailures,omitempty"`
+	// The number of major page faults that have occurred.
+	MajorFaults int64 `protobuf:"varint,8,opt,name=MajorFaults,proto3" json:"MajorFaults,omitempty"`
+	// The number of minor page faults that have occurred.
+	MinorFaults int64 `protobuf:"varint,9,opt,name=MinorFaults,proto3" json:"MinorFaults,omitempty"`
+	// The amount of memory that has been swapped in (in bytes).
+	SwapIn int64 `protobuf:"varint,10,opt,name=SwapIn,proto3" json:"SwapIn,omitempty"`
+	// The amount of memory that has been swapped out to disk (in bytes).
+	SwapOut int64 `protobuf:"varint,11,opt,name=SwapOut,proto3" json:"SwapOut,omitempty"`
+	// Target amount of memory (in MiB) the device aims to hold.
+	TargetMib int64 `protobuf:"varint,12,opt,name=TargetMib,proto3" json:"TargetMib,omitempty"`
+	// Target number of pages the device aims to hold.
+	TargetPages int64 `protobuf:"varint,13,opt,name=TargetPages,proto3" json:"TargetPages,omitempty"`
+	// The total amount of memory available (in bytes).
+	TotalMemory int64 `protobuf:"varint,14,opt,name=TotalMemory,proto3" json:"TotalMemory,omitempty"`
+}
+
+func (x *GetBalloonStatsResponse) Reset() {
+	*x = GetBalloonStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonStatsResponse) ProtoMessage() {}
+
+func (x *GetBalloonStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetBalloonStatsResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetBalloonStatsResponse) GetActualMib() int64 {
+	if x != nil {
+		return x.ActualMib
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetActualPages() int64 {
+	if x != nil {
+		return x.ActualPages
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetAvailableMemory() int64 {
+	if x != nil {
+		return x.AvailableMemory
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetDiskCaches() int64 {
+	if x != nil {
+		return x.DiskCaches
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetFreeMemory() int64 {
+	if x != nil {
+		return x.FreeMemory
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetHugetlbAllocations() int64 {
+	if x != nil {
+		return x.HugetlbAllocations
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetHugetlbFailures() int64 {
+	if x != nil {
+		return x.HugetlbFailures
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetMajorFaults() int64 {
+	if x != nil {
+		return x.MajorFaults
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetMinorFaults() int64 {
+	if x != nil {
+		return x.MinorFaults
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetSwapIn() int64 {
+	if x != nil {
+		return x.SwapIn
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetSwapOut() int64 {
+	if x != nil {
+		return x.SwapOut
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetTargetMib() int64 {
+	if x != nil {
+		return x.TargetMib
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetTargetPages() int64 {
+	if x != nil {
+		return x.TargetPages
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetTotalMemory() int64 {
+	if x != nil {
+		return x.TotalMemory
+	}
+	return 0
+}
+
+type UpdateBalloonStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID                  string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	StatsPollingIntervals int64  `protobuf:"varint,2,opt,name=StatsPollingIntervals,proto3" json:"StatsPollingIntervals,omitempty"`
+}
+
+func (x *UpdateBalloonStatsRequest) Reset() {
+	*x = UpdateBalloonStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBalloonStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBalloonStatsRequest) ProtoMessage() {}
+
+func (x *UpdateBalloonStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBalloonStatsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBalloonStatsRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateBalloonStatsRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateBalloonStatsRequest) GetStatsPollingIntervals() int64 {
+	if x != nil {
+		return x.StatsPollingIntervals
+	}
+	return 0
+}
+
+type GetVMStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetVMStatsRequest) Reset() {
+	*x = GetVMStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMStatsRequest) ProtoMessage() {}
+
+func (x *GetVMStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetVMStatsRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetVMStatsRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+// GetVMStatsResponse holds the resource usage of a VM as a whole, rather than of the containers running in it.
+type GetVMStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Totals of the metrics Firecracker wrote to its metrics FIFO since the VM started.
+	// Firecracker is asked to write its current metrics first. Should it fail to, the totals
+	// are the ones of its last periodic flush, which happens every minute. Unset when the VM
+	// was created with a MetricsFifoPath, as the FIFO is then read by the caller.
+	VMM *FirecrackerVMMetrics `protobuf:"bytes,2,opt,name=VMM,proto3" json:"VMM,omitempty"`
+	// Usage of the host cgroup the VM is jailed in. Unset when the VM isn't jailed in a cgroup.
+	Cgroup *CgroupStats `protobuf:"bytes,3,opt,name=Cgroup,proto3" json:"Cgroup,omitempty"`
+	// Memory usage and load reported by the guest kernel.
+	Guest *GuestKernelStats `protobuf:"bytes,4,opt,name=Guest,proto3" json:"Guest,omitempty"`
+}
+
+func (x *GetVMStatsResponse) Reset() {
+	*x = GetVMStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMStatsResponse) ProtoMessage() {}
+
+func (x *GetVMStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetVMStatsResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetVMStatsResponse) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *GetVMStatsResponse) GetVMM() *FirecrackerVMMetrics {
+	if x != nil {
+		return x.VMM
+	}
+	return nil
+}
+
+func (x *GetVMStatsResponse) GetCgroup() *CgroupStats {
+	if x != nil {
+		return x.Cgroup
+	}
+	return nil
+}
+
+func (x *GetVMStatsResponse) GetGuest() *GuestKernelStats {
+	if x != nil {
+		return x.Guest
+	}
+	return nil
+}
+
+// FirecrackerVMMetrics are totals of the metrics Firecracker counts between two flushes of its
+// metrics FIFO, summed over every flush since the VM started.
+type FirecrackerVMMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of flushes of the metrics FIFO the totals are summed over.
+	Flushes           uint64 `protobuf:"varint,1,opt,name=Flushes,proto3" json:"Flushes,omitempty"`
+	VcpuExitIoIn      uint64 `protobuf:"varint,2,opt,name=VcpuExitIoIn,proto3" json:"VcpuExitIoIn,omitempty"`
+	VcpuExitIoOut     uint64 `protobuf:"varint,3,opt,name=VcpuExitIoOut,proto3" json:"VcpuExitIoOut,omitempty"`
+	VcpuExitMmioRead  uint64 `protobuf:"varint,4,opt,name=VcpuExitMmioRead,proto3" json:"VcpuExitMmioRead,omitempty"`
+	VcpuExitMmioWrite uint64 `protobuf:"varint,5,opt,name=VcpuExitMmioWrite,proto3" json:"VcpuExitMmioWrite,omitempty"`
+	VcpuFailures      uint64 `protobuf:"varint,6,opt,name=VcpuFailures,proto3" json:"VcpuFailures,omitempty"`
+	// Totals over all the block devices of the VM.
+	BlockReadBytes  uint64 `protobuf:"varint,7,opt,name=BlockReadBytes,proto3" json:"BlockReadBytes,omitempty"`
+	BlockWriteBytes uint64 `protobuf:"varint,8,opt,name=BlockWriteBytes,proto3" json:"BlockWriteBytes,omitempty"`
+	BlockReadCount  uint64 `protobuf:"varint,9,opt,name=BlockReadCount,proto3" json:"BlockReadCount,omitempty"`
+	BlockWriteCount uint64 `protobuf:"varint,10,opt,name=BlockWriteCount,proto3" json:"BlockWriteCount,omitempty"`
+	// Totals over all the network interfaces of the VM.
+	NetRxBytes   uint64 `protobuf:"varint,11,opt,name=NetRxBytes,proto3" json:"NetRxBytes,omitempty"`
+	NetTxBytes   uint64 `protobuf:"varint,12,opt,name=NetTxBytes,proto3" json:"NetTxBytes,omitempty"`
+	NetRxPackets uint64 `protobuf:"varint,13,opt,name=NetRxPackets,proto3" json:"NetRxPackets,omitempty"`
+	NetTxPackets uint64 `protobuf:"varint,14,opt,name=NetTxPackets,proto3" json:"NetTxPackets,omitempty"`
+}
+
+func (x *FirecrackerVMMetrics) Reset() {
+	*x = FirecrackerVMMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerVMMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerVMMetrics) ProtoMessage() {}
+
+func (x *FirecrackerVMMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerVMMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerVMMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FirecrackerVMMetrics) GetFlushes() uint64 {
+	if x != nil {
+		return x.Flushes
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetVcpuExitIoIn() uint64 {
+	if x != nil {
+		return x.VcpuExitIoIn
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetVcpuExitIoOut() uint64 {
+	if x != nil {
+		return x.VcpuExitIoOut
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetVcpuExitMmioRead() uint64 {
+	if x != nil {
+		return x.VcpuExitMmioRead
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetVcpuExitMmioWrite() uint64 {
+	if x != nil {
+		return x.VcpuExitMmioWrite
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetVcpuFailures() uint64 {
+	if x != nil {
+		return x.VcpuFailures
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetBlockReadBytes() uint64 {
+	if x != nil {
+		return x.BlockReadBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetBlockWriteBytes() uint64 {
+	if x != nil {
+		return x.BlockWriteBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetBlockReadCount() uint64 {
+	if x != nil {
+		return x.BlockReadCount
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetBlockWriteCount() uint64 {
+	if x != nil {
+		return x.BlockWriteCount
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetNetRxBytes() uint64 {
+	if x != nil {
+		return x.NetRxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetNetTxBytes() uint64 {
+	if x != nil {
+		return x.NetTxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetNetRxPackets() uint64 {
+	if x != nil {
+		return x.NetRxPackets
+	}
+	return 0
+}
+
+func (x *FirecrackerVMMetrics) GetNetTxPackets() uint64 {
+	if x != nil {
+		return x.NetTxPackets
+	}
+	return 0
+}
+
+type CgroupStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the cgroup, relative to the root of the cgroup hierarchy.
+	CgroupPath string `protobuf:"bytes,1,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	// CPU time consumed by the processes of the cgroup, in nanoseconds.
+	CPUUsageNanoseconds uint64 `protobuf:"varint,2,opt,name=CPUUsageNanoseconds,proto3" json:"CPUUsageNanoseconds,omitempty"`
+	// Memory currently used by the processes of the cgroup, including the page cache, in bytes.
+	MemoryUsageBytes uint64 `protobuf:"varint,3,opt,name=MemoryUsageBytes,proto3" json:"MemoryUsageBytes,omitempty"`
+	// Maximum memory used by the processes of the cgroup, in bytes. Zero if the kernel doesn't record it.
+	MemoryMaxUsageBytes uint64 `protobuf:"varint,4,opt,name=MemoryMaxUsageBytes,proto3" json:"MemoryMaxUsageBytes,omitempty"`
+	// Bytes read from and written to block devices by the processes of the cgroup.
+	IOReadBytes  uint64 `protobuf:"varint,5,opt,name=IOReadBytes,proto3" json:"IOReadBytes,omitempty"`
+	IOWriteBytes uint64 `protobuf:"varint,6,opt,name=IOWriteBytes,proto3" json:"IOWriteBytes,omitempty"`
+}
+
+func (x *CgroupStats) Reset() {
+	*x = CgroupStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CgroupStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgroupStats) ProtoMessage() {}
+
+func (x *CgroupStats) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CgroupStats.ProtoReflect.Descriptor instead.
+func (*CgroupStats) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CgroupStats) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *CgroupStats) GetCPUUsageNanoseconds() uint64 {
+	if x != nil {
+		return x.CPUUsageNanoseconds
+	}
+	return 0
+}
+
+func (x *CgroupStats) GetMemoryUsageBytes() uint64 {
+	if x != nil {
+		return x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *CgroupStats) GetMemoryMaxUsageBytes() uint64 {
+	if x != nil {
+		return x.MemoryMaxUsageBytes
+	}
+	return 0
+}
+
+func (x *CgroupStats) GetIOReadBytes() uint64 {
+	if x != nil {
+		return x.IOReadBytes
+	}
+	return 0
+}
+
+func (x *CgroupStats) GetIOWriteBytes() uint64 {
+	if x != nil {
+		return x.IOWriteBytes
+	}
+	return 0
+}
+
+type GuestKernelStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Values of /proc/meminfo in the guest, in bytes.
+	MemTotalBytes     uint64 `protobuf:"varint,1,opt,name=MemTotalBytes,proto3" json:"MemTotalBytes,omitempty"`
+	MemFreeBytes      uint64 `protobuf:"varint,2,opt,name=MemFreeBytes,proto3" json:"MemFreeBytes,omitempty"`
+	MemAvailableBytes uint64 `protobuf:"varint,3,opt,name=MemAvailableBytes,proto3" json:"MemAvailableBytes,omitempty"`
+	BuffersBytes      uint64 `protobuf:"varint,4,opt,name=BuffersBytes,proto3" json:"BuffersBytes,omitempty"`
+	CachedBytes       uint64 `protobuf:"varint,5,opt,name=CachedBytes,proto3" json:"CachedBytes,omitempty"`
+	SwapTotalBytes    uint64 `protobuf:"varint,6,opt,name=SwapTotalBytes,proto3" json:"SwapTotalBytes,omitempty"`
+	SwapFreeBytes     uint64 `protobuf:"varint,7,opt,name=SwapFreeBytes,proto3" json:"SwapFreeBytes,omitempty"`
+	// Load averages of /proc/loadavg in the guest over 1, 5 and 15 minutes.
+	Load1  float64 `protobuf:"fixed64,8,opt,name=Load1,proto3" json:"Load1,omitempty"`
+	Load5  float64 `protobuf:"fixed64,9,opt,name=Load5,proto3" json:"Load5,omitempty"`
+	Load15 float64 `protobuf:"fixed64,10,opt,name=Load15,proto3" json:"Load15,omitempty"`
+}
+
+func (x *GuestKernelStats) Reset() {
+	*x = GuestKernelStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestKernelStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestKernelStats) ProtoMessage() {}
+
+func (x *GuestKernelStats) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestKernelStats.ProtoReflect.Descriptor instead.
+func (*GuestKernelStats) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GuestKernelStats) GetMemTotalBytes() uint64 {
+	if x != nil {
+		return x.MemTotalBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetMemFreeBytes() uint64 {
+	if x != nil {
+		return x.MemFreeBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetMemAvailableBytes() uint64 {
+	if x != nil {
+		return x.MemAvailableBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetBuffersBytes() uint64 {
+	if x != nil {
+		return x.BuffersBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetCachedBytes() uint64 {
+	if x != nil {
+		return x.CachedBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetSwapTotalBytes() uint64 {
+	if x != nil {
+		return x.SwapTotalBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetSwapFreeBytes() uint64 {
+	if x != nil {
+		return x.SwapFreeBytes
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetLoad1() float64 {
+	if x != nil {
+		return x.Load1
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetLoad5() float64 {
+	if x != nil {
+		return x.Load5
+	}
+	return 0
+}
+
+func (x *GuestKernelStats) GetLoad15() float64 {
+	if x != nil {
+		return x.Load15
+	}
+	return 0
+}
+
+// UpdateRateLimitersRequest replaces the rate limiters of drives and network interfaces of a running VM.
+// Drives and interfaces which aren't listed keep their rate limiters.
+type UpdateRateLimitersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID              string                               `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Drives            []*DriveRateLimiterUpdate            `protobuf:"bytes,2,rep,name=Drives,proto3" json:"Drives,omitempty"`
+	NetworkInterfaces []*NetworkInterfaceRateLimiterUpdate `protobuf:"bytes,3,rep,name=NetworkInterfaces,proto3" json:"NetworkInterfaces,omitempty"`
+}
+
+func (x *UpdateRateLimitersRequest) Reset() {
+	*x = UpdateRateLimitersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRateLimitersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRateLimitersRequest) ProtoMessage() {}
+
+func (x *UpdateRateLimitersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRateLimitersRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRateLimitersRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateRateLimitersRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateRateLimitersRequest) GetDrives() []*DriveRateLimiterUpdate {
+	if x != nil {
+		return x.Drives
+	}
+	return nil
+}
+
+func (x *UpdateRateLimitersRequest) GetNetworkInterfaces() []*NetworkInterfaceRateLimiterUpdate {
+	if x != nil {
+		return x.NetworkInterfaces
+	}
+	return nil
+}
+
+// DriveRateLimiterUpdate identifies a drive by the path it is mounted at in the VM, which is
+// the VMPath of one of the DriveMounts of the CreateVMRequest or "/" for the root drive.
+type DriveRateLimiterUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMPath string `protobuf:"bytes,1,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+	// A token bucket whose Capacity is 0 removes the limit it specifies. An unset token bucket
+	// keeps the current limit.
+	RateLimiter *FirecrackerRateLimiter `protobuf:"bytes,2,opt,name=RateLimiter,proto3" json:"RateLimiter,omitempty"`
+}
+
+func (x *DriveRateLimiterUpdate) Reset() {
+	*x = DriveRateLimiterUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DriveRateLimiterUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriveRateLimiterUpdate) ProtoMessage() {}
+
+func (x *DriveRateLimiterUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriveRateLimiterUpdate.ProtoReflect.Descriptor instead.
+func (*DriveRateLimiterUpdate) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DriveRateLimiterUpdate) GetVMPath() string {
+	if x != nil {
+		return x.VMPath
+	}
+	return ""
+}
+
+func (x *DriveRateLimiterUpdate) GetRateLimiter() *FirecrackerRateLimiter {
+	if x != nil {
+		return x.RateLimiter
+	}
+	return nil
+}
+
+// NetworkInterfaceRateLimiterUpdate identifies a network interface by its index in the
+// NetworkInterfaces of the CreateVMRequest. Unset rate limiters keep the current limits.
+type NetworkInterfaceRateLimiterUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index          uint32                  `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	InRateLimiter  *FirecrackerRateLimiter `protobuf:"bytes,2,opt,name=InRateLimiter,proto3" json:"InRateLimiter,omitempty"`
+	OutRateLimiter *FirecrackerRateLimiter `protobuf:"bytes,3,opt,name=OutRateLimiter,proto3" json:"OutRateLimiter,omitempty"`
+}
+
+func (x *NetworkInterfaceRateLimiterUpdate) Reset() {
+	*x = NetworkInterfaceRateLimiterUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkInterfaceRateLimiterUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkInterfaceRateLimiterUpdate) ProtoMessage() {}
+
+func (x *NetworkInterfaceRateLimiterUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkInterfaceRateLimiterUpdate.ProtoReflect.Descriptor instead.
+func (*NetworkInterfaceRateLimiterUpdate) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *NetworkInterfaceRateLimiterUpdate) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
 	return 0
 }
 
-func (x *GetBalloonStatsResponse) GetHugetlbAllocations() int64 {
+func (x *NetworkInterfaceRateLimiterUpdate) GetInRateLimiter() *FirecrackerRateLimiter {
+	if x != nil {
+		return x.InRateLimiter
+	}
+	return nil
+}
+
+func (x *NetworkInterfaceRateLimiterUpdate) GetOutRateLimiter() *FirecrackerRateLimiter {
+	if x != nil {
+		return x.OutRateLimiter
+	}
+	return nil
+}
+
+type GetVMNetworkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetVMNetworkRequest) Reset() {
+	*x = GetVMNetworkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMNetworkRequest) ProtoMessage() {}
+
+func (x *GetVMNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMNetworkRequest.ProtoReflect.Descriptor instead.
+func (*GetVMNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetVMNetworkRequest) GetVMID() string {
 	if x != nil {
-		return x.HugetlbAllocations
+		return x.VMID
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetHugetlbFailures() int64 {
+type GetVMNetworkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// The network interfaces of the VM, in the order of the NetworkInterfaces of the CreateVMRequest.
+	NetworkInterfaces []*VMNetworkInterface `protobuf:"bytes,2,rep,name=NetworkInterfaces,proto3" json:"NetworkInterfaces,omitempty"`
+}
+
+func (x *GetVMNetworkResponse) Reset() {
+	*x = GetVMNetworkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMNetworkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMNetworkResponse) ProtoMessage() {}
+
+func (x *GetVMNetworkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMNetworkResponse.ProtoReflect.Descriptor instead.
+func (*GetVMNetworkResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetVMNetworkResponse) GetVMID() string {
 	if x != nil {
-		return x.HugetlbFailures
+		return x.VMID
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetMajorFaults() int64 {
+func (x *GetVMNetworkResponse) GetNetworkInterfaces() []*VMNetworkInterface {
 	if x != nil {
-		return x.MajorFaults
+		return x.NetworkInterfaces
 	}
-	return 0
+	return nil
 }
 
-func (x *GetBalloonStatsResponse) GetMinorFaults() int64 {
+// VMNetworkInterface is the configuration a network interface of a VM resolved to when the VM started,
+// including the results of CNI for interfaces created with CNI.
+type VMNetworkInterface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Index of the interface in the NetworkInterfaces of the CreateVMRequest.
+	Index uint32 `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	// Name of the tap device backing the interface on the host.
+	TapName string `protobuf:"bytes,2,opt,name=TapName,proto3" json:"TapName,omitempty"`
+	// MAC address of the interface inside the VM.
+	MacAddress string `protobuf:"bytes,3,opt,name=MacAddress,proto3" json:"MacAddress,omitempty"`
+	// Name of the CNI network and interface the interface was created with. Unset for
+	// interfaces with a static configuration.
+	CNINetworkName   string `protobuf:"bytes,4,opt,name=CNINetworkName,proto3" json:"CNINetworkName,omitempty"`
+	CNIInterfaceName string `protobuf:"bytes,5,opt,name=CNIInterfaceName,proto3" json:"CNIInterfaceName,omitempty"`
+	// IP configuration of the interface inside the VM, unset if the VM configures it itself.
+	// IPAddress is in CIDR notation.
+	IPAddress       string   `protobuf:"bytes,6,opt,name=IPAddress,proto3" json:"IPAddress,omitempty"`
+	Gateway         string   `protobuf:"bytes,7,opt,name=Gateway,proto3" json:"Gateway,omitempty"`
+	Nameservers     []string `protobuf:"bytes,8,rep,name=Nameservers,proto3" json:"Nameservers,omitempty"`
+	VMInterfaceName string   `protobuf:"bytes,9,opt,name=VMInterfaceName,proto3" json:"VMInterfaceName,omitempty"`
+	AllowMMDS       bool     `protobuf:"varint,10,opt,name=AllowMMDS,proto3" json:"AllowMMDS,omitempty"`
+}
+
+func (x *VMNetworkInterface) Reset() {
+	*x = VMNetworkInterface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMNetworkInterface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMNetworkInterface) ProtoMessage() {}
+
+func (x *VMNetworkInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMNetworkInterface.ProtoReflect.Descriptor instead.
+func (*VMNetworkInterface) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VMNetworkInterface) GetIndex() uint32 {
 	if x != nil {
-		return x.MinorFaults
+		return x.Index
 	}
 	return 0
 }
 
-func (x *GetBalloonStatsResponse) GetSwapIn() int64 {
+func (x *VMNetworkInterface) GetTapName() string {
 	if x != nil {
-		return x.SwapIn
+		return x.TapName
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetSwapOut() int64 {
+func (x *VMNetworkInterface) GetMacAddress() string {
 	if x != nil {
-		return x.SwapOut
+		return x.MacAddress
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetTargetMib() int64 {
+func (x *VMNetworkInterface) GetCNINetworkName() string {
 	if x != nil {
-		return x.TargetMib
+		return x.CNINetworkName
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetTargetPages() int64 {
+func (x *VMNetworkInterface) GetCNIInterfaceName() string {
 	if x != nil {
-		return x.TargetPages
+		return x.CNIInterfaceName
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetTotalMemory() int64 {
+func (x *VMNetworkInterface) GetIPAddress() string {
 	if x != nil {
-		return x.TotalMemory
+		return x.IPAddress
+	}
+	return ""
+}
+
+func (x *VMNetworkInterface) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *VMNetworkInterface) GetNameservers() []string {
+	if x != nil {
+		return x.Nameservers
+	}
+	return nil
+}
+
+func (x *VMNetworkInterface) GetVMInterfaceName() string {
+	if x != nil {
+		return x.VMInterfaceName
+	}
+	return ""
+}
+
+func (x *VMNetworkInterface) GetAllowMMDS() bool {
+	if x != nil {
+		return x.AllowMMDS
+	}
+	return false
+}
+
+// AddNetworkInterfaceRequest adds a network interface to a running VM. Firecracker cannot add
+// network interfaces to a running VM, so the request always fails with FailedPrecondition, as the VM
+// needs to be recreated with the new interface. The rate limiters of the interfaces the VM already
+// has can be changed with UpdateRateLimiters.
+type AddNetworkInterfaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID             string                       `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	NetworkInterface *FirecrackerNetworkInterface `protobuf:"bytes,2,opt,name=NetworkInterface,proto3" json:"NetworkInterface,omitempty"`
+}
+
+func (x *AddNetworkInterfaceRequest) Reset() {
+	*x = AddNetworkInterfaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNetworkInterfaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNetworkInterfaceRequest) ProtoMessage() {}
+
+func (x *AddNetworkInterfaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNetworkInterfaceRequest.ProtoReflect.Descriptor instead.
+func (*AddNetworkInterfaceRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AddNetworkInterfaceRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *AddNetworkInterfaceRequest) GetNetworkInterface() *FirecrackerNetworkInterface {
+	if x != nil {
+		return x.NetworkInterface
+	}
+	return nil
+}
+
+type AddNetworkInterfaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Index of the interface in the NetworkInterfaces of the CreateVMRequest.
+	Index uint32 `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+}
+
+func (x *AddNetworkInterfaceResponse) Reset() {
+	*x = AddNetworkInterfaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNetworkInterfaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNetworkInterfaceResponse) ProtoMessage() {}
+
+func (x *AddNetworkInterfaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNetworkInterfaceResponse.ProtoReflect.Descriptor instead.
+func (*AddNetworkInterfaceResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddNetworkInterfaceResponse) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
 	}
 	return 0
 }
 
-type UpdateBalloonStatsRequest struct {
+// RemoveNetworkInterfaceRequest removes a network interface from a running VM. Firecracker cannot
+// remove network interfaces from a running VM, so the request fails with FailedPrecondition unless
+// the VM has no interface at Index, in which case it fails with NotFound.
+type RemoveNetworkInterfaceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID                  string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	StatsPollingIntervals int64  `protobuf:"varint,2,opt,name=StatsPollingIntervals,proto3" json:"StatsPollingIntervals,omitempty"`
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Index of the interface in the NetworkInterfaces of the CreateVMRequest.
+	Index uint32 `protobuf:"varint,2,opt,name=Index,proto3" json:"Index,omitempty"`
 }
 
-func (x *UpdateBalloonStatsRequest) Reset() {
-	*x = UpdateBalloonStatsRequest{}
+func (x *RemoveNetworkInterfaceRequest) Reset() {
+	*x = RemoveNetworkInterfaceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_firecracker_proto_msgTypes[17]
+		mi := &file_firecracker_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateBalloonStatsRequest) String() string {
+func (x *RemoveNetworkInterfaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBalloonStatsRequest) ProtoMessage() {}
+func (*RemoveNetworkInterfaceRequest) ProtoMessage() {}
 
-func (x *UpdateBalloonStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_firecracker_proto_msgTypes[17]
+func (x *RemoveNetworkInterfaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,21 +3546,21 @@ func (x *UpdateBalloonStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBalloonStatsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBalloonStatsRequest) Descriptor() ([]byte, []int) {
-	return file_firecracker_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RemoveNetworkInterfaceRequest.ProtoReflect.Descriptor instead.
+func (*RemoveNetworkInterfaceRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *UpdateBalloonStatsRequest) GetVMID() string {
+func (x *RemoveNetworkInterfaceRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-func (x *UpdateBalloonStatsRequest) GetStatsPollingIntervals() int64 {
+func (x *RemoveNetworkInterfaceRequest) GetIndex() uint32 {
 	if x != nil {
-		return x.StatsPollingIntervals
+		return x.Index
 	}
 	return 0
 }
@@ -1337,7 +3570,7 @@ var File_firecracker_proto protoreflect.FileDescriptor
 var file_firecracker_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x66, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xb8, 0x05, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71,
+	0x22, 0xdd, 0x06, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x40, 0x0a, 0x0a, 0x4d, 0x61, 0x63, 0x68,
 	0x69, 0x6e, 0x65, 0x43, 0x66, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x46,
@@ -1380,130 +3613,446 @@ var file_firecracker_proto_rawDesc = []byte{
 	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42,
 	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x42, 0x61,
-	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x10,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61,
-	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74,
-	0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50,
-	0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69,
-	0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68,
-	0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68,
-	0x22, 0x24, 0x0a, 0x0e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x25, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
-	0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x4b, 0x0a,
-	0x0d, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
-	0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x54, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65,
-	0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
-	0x49, 0x44, 0x22, 0xd1, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a,
-	0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b,
-	0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28,
-	0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74,
-	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x53, 0x6f, 0x63,
-	0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x53, 0x6f,
-	0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x22, 0x46, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x11, 0x4d, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x11, 0x4d, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x4d,
+	0x61, 0x78, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0xb2, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67,
+	0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66,
+	0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x50, 0x61, 0x74, 0x68, 0x22, 0x24, 0x0a, 0x0e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x25, 0x0a, 0x0f, 0x52,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
 	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
-	0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x49,
-	0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1a, 0x0a,
-	0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x49, 0x44, 0x22, 0x4b, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22,
+	0x26, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0xb6, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56,
+	0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69,
+	0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a,
+	0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a,
+	0x09, 0x56, 0x53, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x56, 0x53, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x05, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x08, 0x2e, 0x56, 0x4d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x06, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x56, 0x4d,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x20,
+	0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0x10, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x2c, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x03, 0x56, 0x4d, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x56, 0x4d, 0x73,
+	0x22, 0x80, 0x02, 0x0a, 0x06, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x56,
+	0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12,
+	0x18, 0x0a, 0x07, 0x53, 0x68, 0x69, 0x6d, 0x50, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x53, 0x68, 0x69, 0x6d, 0x50, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x53, 0x6f,
+	0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x53,
+	0x6f, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69,
+	0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f,
+	0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x08, 0x2e, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x22, 0x95, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0x7a, 0x0a, 0x0e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x4d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0xb8, 0x01, 0x0a, 0x10, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x75, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x62, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53,
+	0x74, 0x75, 0x62, 0x73, 0x12, 0x3c, 0x0a, 0x0f, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x53, 0x74, 0x75, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x62, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x52, 0x0f, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x75,
+	0x62, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x11, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53,
+	0x74, 0x75, 0x62, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65,
+	0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x74, 0x75, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x74, 0x75, 0x62, 0x46, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x46, 0x69, 0x72,
+	0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x0a, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x7a,
+	0x0a, 0x12, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65,
+	0x49, 0x44, 0x12, 0x36, 0x0a, 0x0a, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a,
+	0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x42, 0x0a, 0x12, 0x44, 0x65,
+	0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x56, 0x4d, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x22, 0x46,
+	0x0a, 0x14, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x49, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
 	0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x33, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd2, 0x01, 0x0a, 0x0c, 0x4a,
-	0x61, 0x69, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x4e,
-	0x65, 0x74, 0x4e, 0x53, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4e, 0x65, 0x74, 0x4e,
-	0x53, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x50, 0x55, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x43, 0x50, 0x55, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x49, 0x44,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x55, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x47,
-	0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x47, 0x49, 0x44, 0x12, 0x1e, 0x0a,
-	0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x40, 0x0a,
-	0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x65,
-	0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x44, 0x72,
-	0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
-	0x48, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x33, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xb4, 0x05, 0x0a, 0x0c, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x65, 0x74, 0x4e, 0x53, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x4e, 0x65, 0x74, 0x4e, 0x53, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x50, 0x55,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x50, 0x55, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x4d, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x55, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x47, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x47, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x40, 0x0a, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78,
+	0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x28, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x4a, 0x61, 0x69, 0x6c, 0x65,
+	0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x78, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x78, 0x12,
+	0x1a, 0x0a, 0x08, 0x43, 0x50, 0x55, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x43, 0x50, 0x55, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x43,
+	0x50, 0x55, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x43, 0x50, 0x55, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x49, 0x4f, 0x4d,
+	0x61, 0x78, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x43, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x05, 0x49, 0x4f, 0x4d, 0x61, 0x78, 0x12,
+	0x18, 0x0a, 0x07, 0x50, 0x69, 0x64, 0x73, 0x4d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x50, 0x69, 0x64, 0x73, 0x4d, 0x61, 0x78, 0x12, 0x34, 0x0a, 0x0d, 0x53, 0x65, 0x63,
+	0x63, 0x6f, 0x6d, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0e, 0x2e, 0x53, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0d, 0x53, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x2e, 0x0a, 0x12, 0x53, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x53, 0x65, 0x63,
+	0x63, 0x6f, 0x6d, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x2a, 0x0a, 0x10, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x44, 0x72, 0x6f, 0x70, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a,
+	0x0b, 0x55, 0x49, 0x44, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x49, 0x44, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x0b,
+	0x55, 0x49, 0x44, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2c, 0x0a, 0x0b, 0x47,
+	0x49, 0x44, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x49, 0x44, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x0b, 0x47, 0x49,
+	0x44, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x59, 0x0a, 0x09, 0x49, 0x44, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x6f, 0x73, 0x74,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x44,
+	0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x0d, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49,
+	0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x42, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x52, 0x65, 0x61, 0x64, 0x42, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x49, 0x4f, 0x50, 0x53,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x52, 0x65, 0x61, 0x64, 0x49, 0x4f, 0x50, 0x53,
+	0x12, 0x1c, 0x0a, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x4f, 0x50, 0x53, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x4f, 0x50, 0x53, 0x22, 0x48,
+	0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x22, 0x2d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42,
+	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x5b, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x61,
+	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69, 0x72,
+	0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x22, 0xf5, 0x03, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0b,
+	0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x28,
+	0x0a, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x6b,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44, 0x69,
+	0x73, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x65, 0x65,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x46, 0x72,
+	0x65, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x48, 0x75, 0x67, 0x65,
+	0x74, 0x6c, 0x62, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x48, 0x75, 0x67, 0x65,
+	0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x69, 0x6e, 0x6f, 0x72,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x65, 0x0a, 0x19, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x41,
-	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x22, 0x2d, 0x0a, 0x17, 0x47, 0x65, 0x74,
-	0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x15, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x73, 0x22, 0x27, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0xa0, 0x01, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x27, 0x0a, 0x03, 0x56, 0x4d, 0x4d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x56, 0x4d, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x03, 0x56, 0x4d, 0x4d, 0x12, 0x24,
+	0x0a, 0x06, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x06, 0x43, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x27, 0x0a, 0x05, 0x47, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x47, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa4, 0x04,
+	0x0a, 0x14, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x56, 0x4d, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x65, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x56, 0x63, 0x70, 0x75, 0x45, 0x78, 0x69, 0x74, 0x49, 0x6f, 0x49, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x56, 0x63, 0x70, 0x75, 0x45, 0x78, 0x69, 0x74,
+	0x49, 0x6f, 0x49, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x56, 0x63, 0x70, 0x75, 0x45, 0x78, 0x69, 0x74,
+	0x49, 0x6f, 0x4f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x56, 0x63, 0x70,
+	0x75, 0x45, 0x78, 0x69, 0x74, 0x49, 0x6f, 0x4f, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x56, 0x63,
+	0x70, 0x75, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x6d, 0x69, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x56, 0x63, 0x70, 0x75, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x6d,
+	0x69, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x56, 0x63, 0x70, 0x75, 0x45, 0x78,
+	0x69, 0x74, 0x4d, 0x6d, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x11, 0x56, 0x63, 0x70, 0x75, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x6d, 0x69, 0x6f, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x56, 0x63, 0x70, 0x75, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x56, 0x63, 0x70, 0x75,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x28, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x4e, 0x65, 0x74, 0x52, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x4e, 0x65, 0x74, 0x52, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x4e, 0x65, 0x74, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x4e, 0x65, 0x74, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x4e, 0x65, 0x74, 0x52, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x4e, 0x65, 0x74, 0x52, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x54, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x4e, 0x65, 0x74, 0x54, 0x78, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x22, 0x83, 0x02, 0x0a, 0x0b, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x30, 0x0a, 0x13, 0x43, 0x50, 0x55, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x13, 0x43, 0x50, 0x55, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x10, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x78, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x13, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x78, 0x55, 0x73, 0x61, 0x67, 0x65, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x49, 0x4f, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x49, 0x4f, 0x52, 0x65, 0x61,
+	0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x49, 0x4f, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x49, 0x4f,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xe2, 0x02, 0x0a, 0x10, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x4d, 0x65, 0x6d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x4d, 0x65, 0x6d, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x65, 0x6d, 0x46, 0x72, 0x65, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x4d, 0x65, 0x6d,
+	0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x4d, 0x65, 0x6d,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x4d, 0x65, 0x6d, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x42, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x42,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x77, 0x61, 0x70, 0x46, 0x72, 0x65,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x53, 0x77,
+	0x61, 0x70, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4c,
+	0x6f, 0x61, 0x64, 0x31, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x4c, 0x6f, 0x61, 0x64,
+	0x31, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x6f, 0x61, 0x64, 0x35, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x4c, 0x6f, 0x61, 0x64, 0x35, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x6f, 0x61, 0x64, 0x31,
+	0x35, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x4c, 0x6f, 0x61, 0x64, 0x31, 0x35, 0x22,
+	0xb2, 0x01, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x12, 0x2f, 0x0a, 0x06, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x73, 0x12, 0x50, 0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x16, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x39, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69,
+	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x22, 0xb9, 0x01, 0x0a, 0x21, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3d, 0x0a,
+	0x0d, 0x49, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0d, 0x49,
+	0x6e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x3f, 0x0a, 0x0e,
+	0x4f, 0x75, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0e, 0x4f,
+	0x75, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x22, 0x29, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x5b, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42,
-	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69,
-	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c,
-	0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56,
-	0x4d, 0x49, 0x44, 0x22, 0xf5, 0x03, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
-	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a,
-	0x0b, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12,
-	0x28, 0x0a, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x69, 0x73,
-	0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44,
-	0x69, 0x73, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x65,
-	0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x46,
-	0x72, 0x65, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x48, 0x75, 0x67,
-	0x65, 0x74, 0x6c, 0x62, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x41, 0x6c,
-	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x48, 0x75, 0x67,
-	0x65, 0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0f, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75,
-	0x72, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c,
-	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46,
-	0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x46, 0x61,
-	0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x69, 0x6e, 0x6f,
-	0x72, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49,
-	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12,
-	0x18, 0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
-	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x65, 0x0a, 0x19, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x15,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x73, 0x2a, 0x27, 0x0a, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73,
-	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x4f, 0x50, 0x59, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x42, 0x09, 0x5a, 0x07, 0x2e,
-	0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x6d, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x56,
+	0x4d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x56, 0x4d, 0x49, 0x44, 0x12, 0x41, 0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x56, 0x4d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x52, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22, 0xda, 0x02, 0x0a, 0x12, 0x56, 0x4d, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x61, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x61, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x26,
+	0x0a, 0x0e, 0x43, 0x4e, 0x49, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x43, 0x4e, 0x49, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x4e, 0x49, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x43, 0x4e, 0x49, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x50, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x49, 0x50, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x56, 0x4d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x56, 0x4d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x4d,
+	0x4d, 0x44, 0x53, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x4d, 0x4d, 0x44, 0x53, 0x22, 0x7a, 0x0a, 0x1a, 0x41, 0x64, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x48, 0x0a, 0x10, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x10,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x22, 0x33, 0x0a, 0x1b, 0x41, 0x64, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x49, 0x0a, 0x1d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x2a, 0x60, 0x0a, 0x07, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x56,
+	0x4d, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x14, 0x0a, 0x10, 0x56, 0x4d, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x55,
+	0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x56, 0x4d, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x50, 0x41, 0x55, 0x53, 0x45, 0x44, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10,
+	0x56, 0x4d, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44,
+	0x10, 0x03, 0x2a, 0x3a, 0x0a, 0x08, 0x56, 0x4d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x12,
+	0x0a, 0x0e, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10, 0x01, 0x12,
+	0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10, 0x02, 0x2a, 0x3e,
+	0x0a, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x4f, 0x50, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x42, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49, 0x4e, 0x4b, 0x10,
+	0x02, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x46, 0x4c, 0x49, 0x4e, 0x4b, 0x10, 0x03, 0x2a, 0x4c,
+	0x0a, 0x0d, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12,
+	0x12, 0x0a, 0x0e, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x4a, 0x41, 0x49, 0x4c, 0x45,
+	0x52, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x55, 0x4e, 0x43, 0x5f, 0x4a, 0x41, 0x49, 0x4c,
+	0x45, 0x52, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x49, 0x52, 0x45, 0x43, 0x52, 0x41, 0x43,
+	0x4b, 0x45, 0x52, 0x5f, 0x4a, 0x41, 0x49, 0x4c, 0x45, 0x52, 0x10, 0x02, 0x2a, 0x4e, 0x0a, 0x0d,
+	0x53, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x14, 0x0a,
+	0x10, 0x54, 0x45, 0x4d, 0x50, 0x4c, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x45, 0x43, 0x43, 0x4f, 0x4d,
+	0x50, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x53,
+	0x45, 0x43, 0x43, 0x4f, 0x4d, 0x50, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x55, 0x53, 0x54,
+	0x4f, 0x4d, 0x5f, 0x53, 0x45, 0x43, 0x43, 0x4f, 0x4d, 0x50, 0x10, 0x02, 0x42, 0x09, 0x5a, 0x07,
+	0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1518,48 +4067,103 @@ func file_firecracker_proto_rawDescGZIP() []byte {
 	return file_firecracker_proto_rawDescData
 }
 
-var file_firecracker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_firecracker_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_firecracker_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_firecracker_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_firecracker_proto_goTypes = []interface{}{
-	(DriveExposePolicy)(0),                  // 0: DriveExposePolicy
-	(*CreateVMRequest)(nil),                 // 1: CreateVMRequest
-	(*CreateVMResponse)(nil),                // 2: CreateVMResponse
-	(*PauseVMRequest)(nil),                  // 3: PauseVMRequest
-	(*ResumeVMRequest)(nil),                 // 4: ResumeVMRequest
-	(*StopVMRequest)(nil),                   // 5: StopVMRequest
-	(*GetVMInfoRequest)(nil),                // 6: GetVMInfoRequest
-	(*GetVMInfoResponse)(nil),               // 7: GetVMInfoResponse
-	(*SetVMMetadataRequest)(nil),            // 8: SetVMMetadataRequest
-	(*UpdateVMMetadataRequest)(nil),         // 9: UpdateVMMetadataRequest
-	(*GetVMMetadataRequest)(nil),            // 10: GetVMMetadataRequest
-	(*GetVMMetadataResponse)(nil),           // 11: GetVMMetadataResponse
-	(*JailerConfig)(nil),                    // 12: JailerConfig
-	(*UpdateBalloonRequest)(nil),            // 13: UpdateBalloonRequest
-	(*GetBalloonConfigRequest)(nil),         // 14: GetBalloonConfigRequest
-	(*GetBalloonConfigResponse)(nil),        // 15: GetBalloonConfigResponse
-	(*GetBalloonStatsRequest)(nil),          // 16: GetBalloonStatsRequest
-	(*GetBalloonStatsResponse)(nil),         // 17: GetBalloonStatsResponse
-	(*UpdateBalloonStatsRequest)(nil),       // 18: UpdateBalloonStatsRequest
-	(*FirecrackerMachineConfiguration)(nil), // 19: FirecrackerMachineConfiguration
-	(*FirecrackerRootDrive)(nil),            // 20: FirecrackerRootDrive
-	(*FirecrackerDriveMount)(nil),           // 21: FirecrackerDriveMount
-	(*FirecrackerNetworkInterface)(nil),     // 22: FirecrackerNetworkInterface
-	(*FirecrackerBalloonDevice)(nil),        // 23: FirecrackerBalloonDevice
+	(VMState)(0),                              // 0: VMState
+	(VMHealth)(0),                             // 1: VMHealth
+	(DriveExposePolicy)(0),                    // 2: DriveExposePolicy
+	(JailerBackend)(0),                        // 3: JailerBackend
+	(SeccompPolicy)(0),                        // 4: SeccompPolicy
+	(*CreateVMRequest)(nil),                   // 5: CreateVMRequest
+	(*CreateVMResponse)(nil),                  // 6: CreateVMResponse
+	(*PauseVMRequest)(nil),                    // 7: PauseVMRequest
+	(*ResumeVMRequest)(nil),                   // 8: ResumeVMRequest
+	(*StopVMRequest)(nil),                     // 9: StopVMRequest
+	(*GetVMInfoRequest)(nil),                  // 10: GetVMInfoRequest
+	(*GetVMInfoResponse)(nil),                 // 11: GetVMInfoResponse
+	(*ListVMsRequest)(nil),                    // 12: ListVMsRequest
+	(*ListVMsResponse)(nil),                   // 13: ListVMsResponse
+	(*VMInfo)(nil),                            // 14: VMInfo
+	(*CreateSnapshotRequest)(nil),             // 15: CreateSnapshotRequest
+	(*SnapshotSource)(nil),                    // 16: SnapshotSource
+	(*SnapshotManifest)(nil),                  // 17: SnapshotManifest
+	(*SnapshotStubDrive)(nil),                 // 18: SnapshotStubDrive
+	(*AttachDriveRequest)(nil),                // 19: AttachDriveRequest
+	(*DetachDriveRequest)(nil),                // 20: DetachDriveRequest
+	(*SetVMMetadataRequest)(nil),              // 21: SetVMMetadataRequest
+	(*UpdateVMMetadataRequest)(nil),           // 22: UpdateVMMetadataRequest
+	(*GetVMMetadataRequest)(nil),              // 23: GetVMMetadataRequest
+	(*GetVMMetadataResponse)(nil),             // 24: GetVMMetadataResponse
+	(*JailerConfig)(nil),                      // 25: JailerConfig
+	(*IDMapping)(nil),                         // 26: IDMapping
+	(*CgroupIOLimit)(nil),                     // 27: CgroupIOLimit
+	(*UpdateBalloonRequest)(nil),              // 28: UpdateBalloonRequest
+	(*GetBalloonConfigRequest)(nil),           // 29: GetBalloonConfigRequest
+	(*GetBalloonConfigResponse)(nil),          // 30: GetBalloonConfigResponse
+	(*GetBalloonStatsRequest)(nil),            // 31: GetBalloonStatsRequest
+	(*GetBalloonStatsResponse)(nil),           // 32: GetBalloonStatsResponse
+	(*UpdateBalloonStatsRequest)(nil),         // 33: UpdateBalloonStatsRequest
+	(*GetVMStatsRequest)(nil),                 // 34: GetVMStatsRequest
+	(*GetVMStatsResponse)(nil),                // 35: GetVMStatsResponse
+	(*FirecrackerVMMetrics)(nil),              // 36: FirecrackerVMMetrics
+	(*CgroupStats)(nil),                       // 37: CgroupStats
+	(*GuestKernelStats)(nil),                  // 38: GuestKernelStats
+	(*UpdateRateLimitersRequest)(nil),         // 39: UpdateRateLimitersRequest
+	(*DriveRateLimiterUpdate)(nil),            // 40: DriveRateLimiterUpdate
+	(*NetworkInterfaceRateLimiterUpdate)(nil), // 41: NetworkInterfaceRateLimiterUpdate
+	(*GetVMNetworkRequest)(nil),               // 42: GetVMNetworkRequest
+	(*GetVMNetworkResponse)(nil),              // 43: GetVMNetworkResponse
+	(*VMNetworkInterface)(nil),                // 44: VMNetworkInterface
+	(*AddNetworkInterfaceRequest)(nil),        // 45: AddNetworkInterfaceRequest
+	(*AddNetworkInterfaceResponse)(nil),       // 46: AddNetworkInterfaceResponse
+	(*RemoveNetworkInterfaceRequest)(nil),     // 47: RemoveNetworkInterfaceRequest
+	(*FirecrackerMachineConfiguration)(nil),   // 48: FirecrackerMachineConfiguration
+	(*FirecrackerRootDrive)(nil),              // 49: FirecrackerRootDrive
+	(*FirecrackerDriveMount)(nil),             // 50: FirecrackerDriveMount
+	(*FirecrackerNetworkInterface)(nil),       // 51: FirecrackerNetworkInterface
+	(*FirecrackerBalloonDevice)(nil),          // 52: FirecrackerBalloonDevice
+	(*FirecrackerRateLimiter)(nil),            // 53: FirecrackerRateLimiter
 }
 var file_firecracker_proto_depIdxs = []int32{
-	19, // 0: CreateVMRequest.MachineCfg:type_name -> FirecrackerMachineConfiguration
-	20, // 1: CreateVMRequest.RootDrive:type_name -> FirecrackerRootDrive
-	21, // 2: CreateVMRequest.DriveMounts:type_name -> FirecrackerDriveMount
-	22, // 3: CreateVMRequest.NetworkInterfaces:type_name -> FirecrackerNetworkInterface
-	12, // 4: CreateVMRequest.JailerConfig:type_name -> JailerConfig
-	23, // 5: CreateVMRequest.BalloonDevice:type_name -> FirecrackerBalloonDevice
-	0,  // 6: JailerConfig.DriveExposePolicy:type_name -> DriveExposePolicy
-	23, // 7: GetBalloonConfigResponse.BalloonConfig:type_name -> FirecrackerBalloonDevice
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	48, // 0: CreateVMRequest.MachineCfg:type_name -> FirecrackerMachineConfiguration
+	49, // 1: CreateVMRequest.RootDrive:type_name -> FirecrackerRootDrive
+	50, // 2: CreateVMRequest.DriveMounts:type_name -> FirecrackerDriveMount
+	51, // 3: CreateVMRequest.NetworkInterfaces:type_name -> FirecrackerNetworkInterface
+	25, // 4: CreateVMRequest.JailerConfig:type_name -> JailerConfig
+	52, // 5: CreateVMRequest.BalloonDevice:type_name -> FirecrackerBalloonDevice
+	16, // 6: CreateVMRequest.Snapshot:type_name -> SnapshotSource
+	0,  // 7: GetVMInfoResponse.State:type_name -> VMState
+	1,  // 8: GetVMInfoResponse.Health:type_name -> VMHealth
+	14, // 9: ListVMsResponse.VMs:type_name -> VMInfo
+	0,  // 10: VMInfo.State:type_name -> VMState
+	5,  // 11: SnapshotManifest.Request:type_name -> CreateVMRequest
+	18, // 12: SnapshotManifest.ContainerStubs:type_name -> SnapshotStubDrive
+	18, // 13: SnapshotManifest.DriveMountStubs:type_name -> SnapshotStubDrive
+	50, // 14: SnapshotStubDrive.DriveMount:type_name -> FirecrackerDriveMount
+	50, // 15: AttachDriveRequest.DriveMount:type_name -> FirecrackerDriveMount
+	2,  // 16: JailerConfig.DriveExposePolicy:type_name -> DriveExposePolicy
+	3,  // 17: JailerConfig.Backend:type_name -> JailerBackend
+	27, // 18: JailerConfig.IOMax:type_name -> CgroupIOLimit
+	4,  // 19: JailerConfig.SeccompPolicy:type_name -> SeccompPolicy
+	26, // 20: JailerConfig.UIDMappings:type_name -> IDMapping
+	26, // 21: JailerConfig.GIDMappings:type_name -> IDMapping
+	52, // 22: GetBalloonConfigResponse.BalloonConfig:type_name -> FirecrackerBalloonDevice
+	36, // 23: GetVMStatsResponse.VMM:type_name -> FirecrackerVMMetrics
+	37, // 24: GetVMStatsResponse.Cgroup:type_name -> CgroupStats
+	38, // 25: GetVMStatsResponse.Guest:type_name -> GuestKernelStats
+	40, // 26: UpdateRateLimitersRequest.Drives:type_name -> DriveRateLimiterUpdate
+	41, // 27: UpdateRateLimitersRequest.NetworkInterfaces:type_name -> NetworkInterfaceRateLimiterUpdate
+	53, // 28: DriveRateLimiterUpdate.RateLimiter:type_name -> FirecrackerRateLimiter
+	53, // 29: NetworkInterfaceRateLimiterUpdate.InRateLimiter:type_name -> FirecrackerRateLimiter
+	53, // 30: NetworkInterfaceRateLimiterUpdate.OutRateLimiter:type_name -> FirecrackerRateLimiter
+	44, // 31: GetVMNetworkResponse.NetworkInterfaces:type_name -> VMNetworkInterface
+	51, // 32: AddNetworkInterfaceRequest.NetworkInterface:type_name -> FirecrackerNetworkInterface
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_firecracker_proto_init() }
@@ -1581,8 +4185,56 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateVMResponse); i {
+		file_firecracker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateVMResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1593,8 +4245,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PauseVMRequest); i {
+		file_firecracker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1605,8 +4257,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResumeVMRequest); i {
+		file_firecracker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVMsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1617,8 +4269,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopVMRequest); i {
+		file_firecracker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVMsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1629,8 +4281,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVMInfoRequest); i {
+		file_firecracker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1641,8 +4293,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVMInfoResponse); i {
+		file_firecracker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1653,7 +4305,67 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotStubDrive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachDriveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetachDriveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetVMMetadataRequest); i {
 			case 0:
 				return &v.state
@@ -1665,7 +4377,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateVMMetadataRequest); i {
 			case 0:
 				return &v.state
@@ -1677,7 +4389,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVMMetadataRequest); i {
 			case 0:
 				return &v.state
@@ -1689,7 +4401,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVMMetadataResponse); i {
 			case 0:
 				return &v.state
@@ -1701,7 +4413,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JailerConfig); i {
 			case 0:
 				return &v.state
@@ -1713,7 +4425,31 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IDMapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CgroupIOLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateBalloonRequest); i {
 			case 0:
 				return &v.state
@@ -1725,7 +4461,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonConfigRequest); i {
 			case 0:
 				return &v.state
@@ -1737,7 +4473,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonConfigResponse); i {
 			case 0:
 				return &v.state
@@ -1749,7 +4485,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonStatsRequest); i {
 			case 0:
 				return &v.state
@@ -1761,7 +4497,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonStatsResponse); i {
 			case 0:
 				return &v.state
@@ -1773,7 +4509,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateBalloonStatsRequest); i {
 			case 0:
 				return &v.state
@@ -1785,14 +4521,182 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
+		file_firecracker_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerVMMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CgroupStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestKernelStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRateLimitersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DriveRateLimiterUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkInterfaceRateLimiterUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMNetworkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMNetworkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMNetworkInterface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNetworkInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNetworkInterfaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNetworkInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_firecracker_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   18,
+			NumEnums:      5,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -139,6 +139,46 @@ func (x *UnmountDriveRequest) GetDriveID() string {
 	return ""
 }
 
+// DiscoverDrivesRequest asks the agent to look for stub drives which were
+// provisioned after it started.
+type DiscoverDrivesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DiscoverDrivesRequest) Reset() {
+	*x = DiscoverDrivesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drivemount_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscoverDrivesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverDrivesRequest) ProtoMessage() {}
+
+func (x *DiscoverDrivesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drivemount_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverDrivesRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverDrivesRequest) Descriptor() ([]byte, []int) {
+	return file_drivemount_proto_rawDescGZIP(), []int{2}
+}
+
 var File_drivemount_proto protoreflect.FileDescriptor
 
 var file_drivemount_proto_rawDesc = []byte{
@@ -157,17 +197,23 @@ var file_drivemount_proto_rawDesc = []byte{
 	0x52, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2f, 0x0a, 0x13, 0x55, 0x6e, 0x6d,
 	0x6f, 0x75, 0x6e, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x18, 0x0a, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x32, 0x86, 0x01, 0x0a, 0x0c, 0x44,
-	0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x0a, 0x4d,
-	0x6f, 0x75, 0x6e, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x12, 0x2e, 0x4d, 0x6f, 0x75, 0x6e,
-	0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x0c, 0x55, 0x6e, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x14, 0x2e, 0x55, 0x6e, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44,
-	0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x3b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x09, 0x52, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x32, 0xc8, 0x01, 0x0a, 0x0c, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x0a, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x12, 0x12, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c,
+	0x0a, 0x0c, 0x55, 0x6e, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x14,
+	0x2e, 0x55, 0x6e, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x0e,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x12, 0x16,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x0e,
+	0x5a, 0x0c, 0x2e, 0x3b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -182,19 +228,22 @@ func file_drivemount_proto_rawDescGZIP() []byte {
 	return file_drivemount_proto_rawDescData
 }
 
-var file_drivemount_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_drivemount_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_drivemount_proto_goTypes = []interface{}{
-	(*MountDriveRequest)(nil),   // 0: MountDriveRequest
-	(*UnmountDriveRequest)(nil), // 1: UnmountDriveRequest
-	(*empty.Empty)(nil),         // 2: google.protobuf.Empty
+	(*MountDriveRequest)(nil),     // 0: MountDriveRequest
+	(*UnmountDriveRequest)(nil),   // 1: UnmountDriveRequest
+	(*DiscoverDrivesRequest)(nil), // 2: DiscoverDrivesRequest
+	(*empty.Empty)(nil),           // 3: google.protobuf.Empty
 }
 var file_drivemount_proto_depIdxs = []int32{
 	0, // 0: DriveMounter.MountDrive:input_type -> MountDriveRequest
 	1, // 1: DriveMounter.UnmountDrive:input_type -> UnmountDriveRequest
-	2, // 2: DriveMounter.MountDrive:output_type -> google.protobuf.Empty
-	2, // 3: DriveMounter.UnmountDrive:output_type -> google.protobuf.Empty
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	2, // 2: DriveMounter.DiscoverDrives:input_type -> DiscoverDrivesRequest
+	3, // 3: DriveMounter.MountDrive:output_type -> google.protobuf.Empty
+	3, // 4: DriveMounter.UnmountDrive:output_type -> google.protobuf.Empty
+	3, // 5: DriveMounter.DiscoverDrives:output_type -> google.protobuf.Empty
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -230,6 +279,18 @@ func file_drivemount_proto_init() {
 				return nil
 			}
 		}
+		file_drivemount_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiscoverDrivesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -237,7 +298,7 @@ func file_drivemount_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_drivemount_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
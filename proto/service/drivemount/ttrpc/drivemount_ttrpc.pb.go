@@ -11,6 +11,7 @@ import (
 type DriveMounterService interface {
 	MountDrive(context.Context, *MountDriveRequest) (*empty.Empty, error)
 	UnmountDrive(context.Context, *UnmountDriveRequest) (*empty.Empty, error)
+	DiscoverDrives(context.Context, *DiscoverDrivesRequest) (*empty.Empty, error)
 }
 
 func RegisterDriveMounterService(srv *ttrpc.Server, svc DriveMounterService) {
@@ -30,6 +31,13 @@ func RegisterDriveMounterService(srv *ttrpc.Server, svc DriveMounterService) {
 				}
 				return svc.UnmountDrive(ctx, &req)
 			},
+			"DiscoverDrives": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req DiscoverDrivesRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.DiscoverDrives(ctx, &req)
+			},
 		},
 	})
 }
@@ -59,3 +67,11 @@ func (c *drivemounterClient) UnmountDrive(ctx context.Context, req *UnmountDrive
 	}
 	return &resp, nil
 }
+
+func (c *drivemounterClient) DiscoverDrives(ctx context.Context, req *DiscoverDrivesRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "DriveMounter", "DiscoverDrives", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -28,7 +28,7 @@ var file_fccontrol_proto_rawDesc = []byte{
 	0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11,
 	0x66, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x32, 0xed, 0x05, 0x0a, 0x0b, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x6f, 0x32, 0xb7, 0x0a, 0x0a, 0x0b, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
 	0x72, 0x12, 0x2f, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x12, 0x10, 0x2e,
 	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x11, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
@@ -38,94 +38,162 @@ var file_fccontrol_proto_rawDesc = []byte{
 	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x34, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
 	0x56, 0x4d, 0x12, 0x10, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x06,
-	0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x12, 0x0e, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x52,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x0e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x32,
-	0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x11, 0x2e, 0x47, 0x65,
-	0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
-	0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56,
-	0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x56,
-	0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x56,
-	0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42,
-	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x2e, 0x47,
-	0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c,
-	0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
-	0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x12, 0x44, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f,
-	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
-	0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1a, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x3b, 0x66, 0x63, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x30,
+	0x0a, 0x06, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x12, 0x0e, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x56,
+	0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x32, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x11, 0x2e,
+	0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x12,
+	0x0f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x10, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x12, 0x13, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3a,
+	0x0a, 0x0b, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x13, 0x2e,
+	0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0d, 0x53, 0x65,
+	0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x53, 0x65,
+	0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x47, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x47,
+	0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x48, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61,
+	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x35, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65,
+	0x74, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x48, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x56, 0x4d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x14, 0x2e, 0x47, 0x65, 0x74,
+	0x56, 0x4d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x13, 0x41, 0x64, 0x64, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x1b,
+	0x2e, 0x41, 0x64, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x41, 0x64,
+	0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x16, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x0d, 0x5a, 0x0b, 0x2e,
+	0x3b, 0x66, 0x63, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var file_fccontrol_proto_goTypes = []interface{}{
-	(*proto.CreateVMRequest)(nil),           // 0: CreateVMRequest
-	(*proto.PauseVMRequest)(nil),            // 1: PauseVMRequest
-	(*proto.ResumeVMRequest)(nil),           // 2: ResumeVMRequest
-	(*proto.StopVMRequest)(nil),             // 3: StopVMRequest
-	(*proto.GetVMInfoRequest)(nil),          // 4: GetVMInfoRequest
-	(*proto.SetVMMetadataRequest)(nil),      // 5: SetVMMetadataRequest
-	(*proto.UpdateVMMetadataRequest)(nil),   // 6: UpdateVMMetadataRequest
-	(*proto.GetVMMetadataRequest)(nil),      // 7: GetVMMetadataRequest
-	(*proto.GetBalloonConfigRequest)(nil),   // 8: GetBalloonConfigRequest
-	(*proto.UpdateBalloonRequest)(nil),      // 9: UpdateBalloonRequest
-	(*proto.GetBalloonStatsRequest)(nil),    // 10: GetBalloonStatsRequest
-	(*proto.UpdateBalloonStatsRequest)(nil), // 11: UpdateBalloonStatsRequest
-	(*proto.CreateVMResponse)(nil),          // 12: CreateVMResponse
-	(*empty.Empty)(nil),                     // 13: google.protobuf.Empty
-	(*proto.GetVMInfoResponse)(nil),         // 14: GetVMInfoResponse
-	(*proto.GetVMMetadataResponse)(nil),     // 15: GetVMMetadataResponse
-	(*proto.GetBalloonConfigResponse)(nil),  // 16: GetBalloonConfigResponse
-	(*proto.GetBalloonStatsResponse)(nil),   // 17: GetBalloonStatsResponse
+	(*proto.CreateVMRequest)(nil),               // 0: CreateVMRequest
+	(*proto.PauseVMRequest)(nil),                // 1: PauseVMRequest
+	(*proto.ResumeVMRequest)(nil),               // 2: ResumeVMRequest
+	(*proto.CreateSnapshotRequest)(nil),         // 3: CreateSnapshotRequest
+	(*proto.StopVMRequest)(nil),                 // 4: StopVMRequest
+	(*proto.GetVMInfoRequest)(nil),              // 5: GetVMInfoRequest
+	(*proto.ListVMsRequest)(nil),                // 6: ListVMsRequest
+	(*proto.AttachDriveRequest)(nil),            // 7: AttachDriveRequest
+	(*proto.DetachDriveRequest)(nil),            // 8: DetachDriveRequest
+	(*proto.SetVMMetadataRequest)(nil),          // 9: SetVMMetadataRequest
+	(*proto.UpdateVMMetadataRequest)(nil),       // 10: UpdateVMMetadataRequest
+	(*proto.GetVMMetadataRequest)(nil),          // 11: GetVMMetadataRequest
+	(*proto.GetBalloonConfigRequest)(nil),       // 12: GetBalloonConfigRequest
+	(*proto.UpdateBalloonRequest)(nil),          // 13: UpdateBalloonRequest
+	(*proto.GetBalloonStatsRequest)(nil),        // 14: GetBalloonStatsRequest
+	(*proto.UpdateBalloonStatsRequest)(nil),     // 15: UpdateBalloonStatsRequest
+	(*proto.GetVMStatsRequest)(nil),             // 16: GetVMStatsRequest
+	(*proto.UpdateRateLimitersRequest)(nil),     // 17: UpdateRateLimitersRequest
+	(*proto.GetVMNetworkRequest)(nil),           // 18: GetVMNetworkRequest
+	(*proto.AddNetworkInterfaceRequest)(nil),    // 19: AddNetworkInterfaceRequest
+	(*proto.RemoveNetworkInterfaceRequest)(nil), // 20: RemoveNetworkInterfaceRequest
+	(*proto.CreateVMResponse)(nil),              // 21: CreateVMResponse
+	(*empty.Empty)(nil),                         // 22: google.protobuf.Empty
+	(*proto.GetVMInfoResponse)(nil),             // 23: GetVMInfoResponse
+	(*proto.ListVMsResponse)(nil),               // 24: ListVMsResponse
+	(*proto.GetVMMetadataResponse)(nil),         // 25: GetVMMetadataResponse
+	(*proto.GetBalloonConfigResponse)(nil),      // 26: GetBalloonConfigResponse
+	(*proto.GetBalloonStatsResponse)(nil),       // 27: GetBalloonStatsResponse
+	(*proto.GetVMStatsResponse)(nil),            // 28: GetVMStatsResponse
+	(*proto.GetVMNetworkResponse)(nil),          // 29: GetVMNetworkResponse
+	(*proto.AddNetworkInterfaceResponse)(nil),   // 30: AddNetworkInterfaceResponse
 }
 var file_fccontrol_proto_depIdxs = []int32{
 	0,  // 0: Firecracker.CreateVM:input_type -> CreateVMRequest
 	1,  // 1: Firecracker.PauseVM:input_type -> PauseVMRequest
 	2,  // 2: Firecracker.ResumeVM:input_type -> ResumeVMRequest
-	3,  // 3: Firecracker.StopVM:input_type -> StopVMRequest
-	4,  // 4: Firecracker.GetVMInfo:input_type -> GetVMInfoRequest
-	5,  // 5: Firecracker.SetVMMetadata:input_type -> SetVMMetadataRequest
-	6,  // 6: Firecracker.UpdateVMMetadata:input_type -> UpdateVMMetadataRequest
-	7,  // 7: Firecracker.GetVMMetadata:input_type -> GetVMMetadataRequest
-	8,  // 8: Firecracker.GetBalloonConfig:input_type -> GetBalloonConfigRequest
-	9,  // 9: Firecracker.UpdateBalloon:input_type -> UpdateBalloonRequest
-	10, // 10: Firecracker.GetBalloonStats:input_type -> GetBalloonStatsRequest
-	11, // 11: Firecracker.UpdateBalloonStats:input_type -> UpdateBalloonStatsRequest
-	12, // 12: Firecracker.CreateVM:output_type -> CreateVMResponse
-	13, // 13: Firecracker.PauseVM:output_type -> google.protobuf.Empty
-	13, // 14: Firecracker.ResumeVM:output_type -> google.protobuf.Empty
-	13, // 15: Firecracker.StopVM:output_type -> google.protobuf.Empty
-	14, // 16: Firecracker.GetVMInfo:output_type -> GetVMInfoResponse
-	13, // 17: Firecracker.SetVMMetadata:output_type -> google.protobuf.Empty
-	13, // 18: Firecracker.UpdateVMMetadata:output_type -> google.protobuf.Empty
-	15, // 19: Firecracker.GetVMMetadata:output_type -> GetVMMetadataResponse
-	16, // 20: Firecracker.GetBalloonConfig:output_type -> GetBalloonConfigResponse
-	13, // 21: Firecracker.UpdateBalloon:output_type -> google.protobuf.Empty
-	17, // 22: Firecracker.GetBalloonStats:output_type -> GetBalloonStatsResponse
-	13, // 23: Firecracker.UpdateBalloonStats:output_type -> google.protobuf.Empty
-	12, // [12:24] is the sub-list for method output_type
-	0,  // [0:12] is the sub-list for method input_type
+	3,  // 3: Firecracker.CreateSnapshot:input_type -> CreateSnapshotRequest
+	4,  // 4: Firecracker.StopVM:input_type -> StopVMRequest
+	5,  // 5: Firecracker.GetVMInfo:input_type -> GetVMInfoRequest
+	6,  // 6: Firecracker.ListVMs:input_type -> ListVMsRequest
+	7,  // 7: Firecracker.AttachDrive:input_type -> AttachDriveRequest
+	8,  // 8: Firecracker.DetachDrive:input_type -> DetachDriveRequest
+	9,  // 9: Firecracker.SetVMMetadata:input_type -> SetVMMetadataRequest
+	10, // 10: Firecracker.UpdateVMMetadata:input_type -> UpdateVMMetadataRequest
+	11, // 11: Firecracker.GetVMMetadata:input_type -> GetVMMetadataRequest
+	12, // 12: Firecracker.GetBalloonConfig:input_type -> GetBalloonConfigRequest
+	13, // 13: Firecracker.UpdateBalloon:input_type -> UpdateBalloonRequest
+	14, // 14: Firecracker.GetBalloonStats:input_type -> GetBalloonStatsRequest
+	15, // 15: Firecracker.UpdateBalloonStats:input_type -> UpdateBalloonStatsRequest
+	16, // 16: Firecracker.GetVMStats:input_type -> GetVMStatsRequest
+	17, // 17: Firecracker.UpdateRateLimiters:input_type -> UpdateRateLimitersRequest
+	18, // 18: Firecracker.GetVMNetwork:input_type -> GetVMNetworkRequest
+	19, // 19: Firecracker.AddNetworkInterface:input_type -> AddNetworkInterfaceRequest
+	20, // 20: Firecracker.RemoveNetworkInterface:input_type -> RemoveNetworkInterfaceRequest
+	21, // 21: Firecracker.CreateVM:output_type -> CreateVMResponse
+	22, // 22: Firecracker.PauseVM:output_type -> google.protobuf.Empty
+	22, // 23: Firecracker.ResumeVM:output_type -> google.protobuf.Empty
+	22, // 24: Firecracker.CreateSnapshot:output_type -> google.protobuf.Empty
+	22, // 25: Firecracker.StopVM:output_type -> google.protobuf.Empty
+	23, // 26: Firecracker.GetVMInfo:output_type -> GetVMInfoResponse
+	24, // 27: Firecracker.ListVMs:output_type -> ListVMsResponse
+	22, // 28: Firecracker.AttachDrive:output_type -> google.protobuf.Empty
+	22, // 29: Firecracker.DetachDrive:output_type -> google.protobuf.Empty
+	22, // 30: Firecracker.SetVMMetadata:output_type -> google.protobuf.Empty
+	22, // 31: Firecracker.UpdateVMMetadata:output_type -> google.protobuf.Empty
+	25, // 32: Firecracker.GetVMMetadata:output_type -> GetVMMetadataResponse
+	26, // 33: Firecracker.GetBalloonConfig:output_type -> GetBalloonConfigResponse
+	22, // 34: Firecracker.UpdateBalloon:output_type -> google.protobuf.Empty
+	27, // 35: Firecracker.GetBalloonStats:output_type -> GetBalloonStatsResponse
+	22, // 36: Firecracker.UpdateBalloonStats:output_type -> google.protobuf.Empty
+	28, // 37: Firecracker.GetVMStats:output_type -> GetVMStatsResponse
+	22, // 38: Firecracker.UpdateRateLimiters:output_type -> google.protobuf.Empty
+	29, // 39: Firecracker.GetVMNetwork:output_type -> GetVMNetworkResponse
+	30, // 40: Firecracker.AddNetworkInterface:output_type -> AddNetworkInterfaceResponse
+	22, // 41: Firecracker.RemoveNetworkInterface:output_type -> google.protobuf.Empty
+	21, // [21:42] is the sub-list for method output_type
+	0,  // [0:21] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
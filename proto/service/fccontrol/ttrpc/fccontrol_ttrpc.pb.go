@@ -13,8 +13,12 @@ type FirecrackerService interface {
 	CreateVM(context.Context, *proto.CreateVMRequest) (*proto.CreateVMResponse, error)
 	PauseVM(context.Context, *proto.PauseVMRequest) (*empty.Empty, error)
 	ResumeVM(context.Context, *proto.ResumeVMRequest) (*empty.Empty, error)
+	CreateSnapshot(context.Context, *proto.CreateSnapshotRequest) (*empty.Empty, error)
 	StopVM(context.Context, *proto.StopVMRequest) (*empty.Empty, error)
 	GetVMInfo(context.Context, *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error)
+	ListVMs(context.Context, *proto.ListVMsRequest) (*proto.ListVMsResponse, error)
+	AttachDrive(context.Context, *proto.AttachDriveRequest) (*empty.Empty, error)
+	DetachDrive(context.Context, *proto.DetachDriveRequest) (*empty.Empty, error)
 	SetVMMetadata(context.Context, *proto.SetVMMetadataRequest) (*empty.Empty, error)
 	UpdateVMMetadata(context.Context, *proto.UpdateVMMetadataRequest) (*empty.Empty, error)
 	GetVMMetadata(context.Context, *proto.GetVMMetadataRequest) (*proto.GetVMMetadataResponse, error)
@@ -22,6 +26,11 @@ type FirecrackerService interface {
 	UpdateBalloon(context.Context, *proto.UpdateBalloonRequest) (*empty.Empty, error)
 	GetBalloonStats(context.Context, *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error)
 	UpdateBalloonStats(context.Context, *proto.UpdateBalloonStatsRequest) (*empty.Empty, error)
+	GetVMStats(context.Context, *proto.GetVMStatsRequest) (*proto.GetVMStatsResponse, error)
+	UpdateRateLimiters(context.Context, *proto.UpdateRateLimitersRequest) (*empty.Empty, error)
+	GetVMNetwork(context.Context, *proto.GetVMNetworkRequest) (*proto.GetVMNetworkResponse, error)
+	AddNetworkInterface(context.Context, *proto.AddNetworkInterfaceRequest) (*proto.AddNetworkInterfaceResponse, error)
+	RemoveNetworkInterface(context.Context, *proto.RemoveNetworkInterfaceRequest) (*empty.Empty, error)
 }
 
 func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
@@ -48,6 +57,13 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.ResumeVM(ctx, &req)
 			},
+			"CreateSnapshot": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.CreateSnapshotRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.CreateSnapshot(ctx, &req)
+			},
 			"StopVM": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
 				var req proto.StopVMRequest
 				if err := unmarshal(&req); err != nil {
@@ -62,6 +78,27 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.GetVMInfo(ctx, &req)
 			},
+			"ListVMs": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.ListVMsRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ListVMs(ctx, &req)
+			},
+			"AttachDrive": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.AttachDriveRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.AttachDrive(ctx, &req)
+			},
+			"DetachDrive": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.DetachDriveRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.DetachDrive(ctx, &req)
+			},
 			"SetVMMetadata": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
 				var req proto.SetVMMetadataRequest
 				if err := unmarshal(&req); err != nil {
@@ -111,6 +148,41 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.UpdateBalloonStats(ctx, &req)
 			},
+			"GetVMStats": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.GetVMStatsRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetVMStats(ctx, &req)
+			},
+			"UpdateRateLimiters": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.UpdateRateLimitersRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.UpdateRateLimiters(ctx, &req)
+			},
+			"GetVMNetwork": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.GetVMNetworkRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetVMNetwork(ctx, &req)
+			},
+			"AddNetworkInterface": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.AddNetworkInterfaceRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.AddNetworkInterface(ctx, &req)
+			},
+			"RemoveNetworkInterface": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.RemoveNetworkInterfaceRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.RemoveNetworkInterface(ctx, &req)
+			},
 		},
 	})
 }
@@ -149,6 +221,14 @@ func (c *firecrackerClient) ResumeVM(ctx context.Context, req *proto.ResumeVMReq
 	return &resp, nil
 }
 
+func (c *firecrackerClient) CreateSnapshot(ctx context.Context, req *proto.CreateSnapshotRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "CreateSnapshot", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *firecrackerClient) StopVM(ctx context.Context, req *proto.StopVMRequest) (*empty.Empty, error) {
 	var resp empty.Empty
 	if err := c.client.Call(ctx, "Firecracker", "StopVM", req, &resp); err != nil {
@@ -165,6 +245,30 @@ func (c *firecrackerClient) GetVMInfo(ctx context.Context, req *proto.GetVMInfoR
 	return &resp, nil
 }
 
+func (c *firecrackerClient) ListVMs(ctx context.Context, req *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	var resp proto.ListVMsResponse
+	if err := c.client.Call(ctx, "Firecracker", "ListVMs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) AttachDrive(ctx context.Context, req *proto.AttachDriveRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "AttachDrive", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) DetachDrive(ctx context.Context, req *proto.DetachDriveRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "DetachDrive", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *firecrackerClient) SetVMMetadata(ctx context.Context, req *proto.SetVMMetadataRequest) (*empty.Empty, error) {
 	var resp empty.Empty
 	if err := c.client.Call(ctx, "Firecracker", "SetVMMetadata", req, &resp); err != nil {
@@ -220,3 +324,43 @@ func (c *firecrackerClient) UpdateBalloonStats(ctx context.Context, req *proto.U
 	}
 	return &resp, nil
 }
+
+func (c *firecrackerClient) GetVMStats(ctx context.Context, req *proto.GetVMStatsRequest) (*proto.GetVMStatsResponse, error) {
+	var resp proto.GetVMStatsResponse
+	if err := c.client.Call(ctx, "Firecracker", "GetVMStats", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) UpdateRateLimiters(ctx context.Context, req *proto.UpdateRateLimitersRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "UpdateRateLimiters", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) GetVMNetwork(ctx context.Context, req *proto.GetVMNetworkRequest) (*proto.GetVMNetworkResponse, error) {
+	var resp proto.GetVMNetworkResponse
+	if err := c.client.Call(ctx, "Firecracker", "GetVMNetwork", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) AddNetworkInterface(ctx context.Context, req *proto.AddNetworkInterfaceRequest) (*proto.AddNetworkInterfaceResponse, error) {
+	var resp proto.AddNetworkInterfaceResponse
+	if err := c.client.Call(ctx, "Firecracker", "AddNetworkInterface", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) RemoveNetworkInterface(ctx context.Context, req *proto.RemoveNetworkInterfaceRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "RemoveNetworkInterface", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
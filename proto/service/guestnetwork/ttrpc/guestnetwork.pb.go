@@ -0,0 +1,346 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: guestnetwork.proto
+
+package guestnetwork
+
+import (
+	proto "github.com/firecracker-microvm/firecracker-containerd/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigureNetworkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkInterfaces []*GuestNetworkInterface `protobuf:"bytes,1,rep,name=NetworkInterfaces,proto3" json:"NetworkInterfaces,omitempty"`
+	// The DNS configuration written to /etc/resolv.conf. /etc/resolv.conf is left as is if unset.
+	DNS *GuestDNSConfiguration `protobuf:"bytes,2,opt,name=DNS,proto3" json:"DNS,omitempty"`
+}
+
+func (x *ConfigureNetworkRequest) Reset() {
+	*x = ConfigureNetworkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_guestnetwork_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigureNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigureNetworkRequest) ProtoMessage() {}
+
+func (x *ConfigureNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_guestnetwork_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigureNetworkRequest.ProtoReflect.Descriptor instead.
+func (*ConfigureNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_guestnetwork_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigureNetworkRequest) GetNetworkInterfaces() []*GuestNetworkInterface {
+	if x != nil {
+		return x.NetworkInterfaces
+	}
+	return nil
+}
+
+func (x *ConfigureNetworkRequest) GetDNS() *GuestDNSConfiguration {
+	if x != nil {
+		return x.DNS
+	}
+	return nil
+}
+
+type GuestNetworkInterface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// MAC address of the network interface to configure.
+	MacAddress string `protobuf:"bytes,1,opt,name=MacAddress,proto3" json:"MacAddress,omitempty"`
+	// Addresses assigned to the interface, in CIDR notation.
+	Addrs []string `protobuf:"bytes,2,rep,name=Addrs,proto3" json:"Addrs,omitempty"`
+	// Default IPv6 gateway of the interface.
+	IPv6GatewayAddr string `protobuf:"bytes,3,opt,name=IPv6GatewayAddr,proto3" json:"IPv6GatewayAddr,omitempty"`
+	// Static routes through the interface.
+	Routes []*proto.IPRoute `protobuf:"bytes,4,rep,name=Routes,proto3" json:"Routes,omitempty"`
+}
+
+func (x *GuestNetworkInterface) Reset() {
+	*x = GuestNetworkInterface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_guestnetwork_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestNetworkInterface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestNetworkInterface) ProtoMessage() {}
+
+func (x *GuestNetworkInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_guestnetwork_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestNetworkInterface.ProtoReflect.Descriptor instead.
+func (*GuestNetworkInterface) Descriptor() ([]byte, []int) {
+	return file_guestnetwork_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GuestNetworkInterface) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+func (x *GuestNetworkInterface) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *GuestNetworkInterface) GetIPv6GatewayAddr() string {
+	if x != nil {
+		return x.IPv6GatewayAddr
+	}
+	return ""
+}
+
+func (x *GuestNetworkInterface) GetRoutes() []*proto.IPRoute {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+type GuestDNSConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nameservers   []string `protobuf:"bytes,1,rep,name=Nameservers,proto3" json:"Nameservers,omitempty"`
+	SearchDomains []string `protobuf:"bytes,2,rep,name=SearchDomains,proto3" json:"SearchDomains,omitempty"`
+}
+
+func (x *GuestDNSConfiguration) Reset() {
+	*x = GuestDNSConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_guestnetwork_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestDNSConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestDNSConfiguration) ProtoMessage() {}
+
+func (x *GuestDNSConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_guestnetwork_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestDNSConfiguration.ProtoReflect.Descriptor instead.
+func (*GuestDNSConfiguration) Descriptor() ([]byte, []int) {
+	return file_guestnetwork_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GuestDNSConfiguration) GetNameservers() []string {
+	if x != nil {
+		return x.Nameservers
+	}
+	return nil
+}
+
+func (x *GuestDNSConfiguration) GetSearchDomains() []string {
+	if x != nil {
+		return x.SearchDomains
+	}
+	return nil
+}
+
+var File_guestnetwork_proto protoreflect.FileDescriptor
+
+var file_guestnetwork_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x67, 0x75, 0x65, 0x73, 0x74, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89,
+	0x01, 0x0a, 0x17, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x11, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x11, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x12, 0x28, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x44, 0x4e, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x44, 0x4e, 0x53, 0x22, 0x99, 0x01, 0x0a, 0x15, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4d, 0x61, 0x63, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x64, 0x64, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x49, 0x50,
+	0x76, 0x36, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x49, 0x50, 0x76, 0x36, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x41, 0x64, 0x64, 0x72, 0x12, 0x20, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x49, 0x50, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x06,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0x5f, 0x0a, 0x15, 0x47, 0x75, 0x65, 0x73, 0x74, 0x44,
+	0x4e, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x20, 0x0a, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x32, 0x54, 0x0a, 0x0c, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x44, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x18, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x10, 0x5a,
+	0x0e, 0x2e, 0x3b, 0x67, 0x75, 0x65, 0x73, 0x74, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_guestnetwork_proto_rawDescOnce sync.Once
+	file_guestnetwork_proto_rawDescData = file_guestnetwork_proto_rawDesc
+)
+
+func file_guestnetwork_proto_rawDescGZIP() []byte {
+	file_guestnetwork_proto_rawDescOnce.Do(func() {
+		file_guestnetwork_proto_rawDescData = protoimpl.X.CompressGZIP(file_guestnetwork_proto_rawDescData)
+	})
+	return file_guestnetwork_proto_rawDescData
+}
+
+var file_guestnetwork_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_guestnetwork_proto_goTypes = []interface{}{
+	(*ConfigureNetworkRequest)(nil), // 0: ConfigureNetworkRequest
+	(*GuestNetworkInterface)(nil),   // 1: GuestNetworkInterface
+	(*GuestDNSConfiguration)(nil),   // 2: GuestDNSConfiguration
+	(*proto.IPRoute)(nil),           // 3: IPRoute
+	(*empty.Empty)(nil),             // 4: google.protobuf.Empty
+}
+var file_guestnetwork_proto_depIdxs = []int32{
+	1, // 0: ConfigureNetworkRequest.NetworkInterfaces:type_name -> GuestNetworkInterface
+	2, // 1: ConfigureNetworkRequest.DNS:type_name -> GuestDNSConfiguration
+	3, // 2: GuestNetworkInterface.Routes:type_name -> IPRoute
+	0, // 3: GuestNetwork.ConfigureNetwork:input_type -> ConfigureNetworkRequest
+	4, // 4: GuestNetwork.ConfigureNetwork:output_type -> google.protobuf.Empty
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_guestnetwork_proto_init() }
+func file_guestnetwork_proto_init() {
+	if File_guestnetwork_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_guestnetwork_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigureNetworkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_guestnetwork_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestNetworkInterface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_guestnetwork_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestDNSConfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_guestnetwork_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_guestnetwork_proto_goTypes,
+		DependencyIndexes: file_guestnetwork_proto_depIdxs,
+		MessageInfos:      file_guestnetwork_proto_msgTypes,
+	}.Build()
+	File_guestnetwork_proto = out.File
+	file_guestnetwork_proto_rawDesc = nil
+	file_guestnetwork_proto_goTypes = nil
+	file_guestnetwork_proto_depIdxs = nil
+}
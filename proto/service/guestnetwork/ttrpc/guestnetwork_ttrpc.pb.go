@@ -0,0 +1,45 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: guestnetwork.proto
+package guestnetwork
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+	empty "github.com/golang/protobuf/ptypes/empty"
+)
+
+type GuestNetworkService interface {
+	ConfigureNetwork(context.Context, *ConfigureNetworkRequest) (*empty.Empty, error)
+}
+
+func RegisterGuestNetworkService(srv *ttrpc.Server, svc GuestNetworkService) {
+	srv.RegisterService("GuestNetwork", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"ConfigureNetwork": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req ConfigureNetworkRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ConfigureNetwork(ctx, &req)
+			},
+		},
+	})
+}
+
+type guestnetworkClient struct {
+	client *ttrpc.Client
+}
+
+func NewGuestNetworkClient(client *ttrpc.Client) GuestNetworkService {
+	return &guestnetworkClient{
+		client: client,
+	}
+}
+
+func (c *guestnetworkClient) ConfigureNetwork(ctx context.Context, req *ConfigureNetworkRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "GuestNetwork", "ConfigureNetwork", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
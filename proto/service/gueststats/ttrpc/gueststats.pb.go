@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: gueststats.proto
+
+package gueststats
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetGuestStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetGuestStatsRequest) Reset() {
+	*x = GetGuestStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gueststats_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGuestStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuestStatsRequest) ProtoMessage() {}
+
+func (x *GetGuestStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gueststats_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuestStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetGuestStatsRequest) Descriptor() ([]byte, []int) {
+	return file_gueststats_proto_rawDescGZIP(), []int{0}
+}
+
+type GetGuestStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Values of /proc/meminfo, in bytes.
+	MemTotalBytes     uint64 `protobuf:"varint,1,opt,name=MemTotalBytes,proto3" json:"MemTotalBytes,omitempty"`
+	MemFreeBytes      uint64 `protobuf:"varint,2,opt,name=MemFreeBytes,proto3" json:"MemFreeBytes,omitempty"`
+	MemAvailableBytes uint64 `protobuf:"varint,3,opt,name=MemAvailableBytes,proto3" json:"MemAvailableBytes,omitempty"`
+	BuffersBytes      uint64 `protobuf:"varint,4,opt,name=BuffersBytes,proto3" json:"BuffersBytes,omitempty"`
+	CachedBytes       uint64 `protobuf:"varint,5,opt,name=CachedBytes,proto3" json:"CachedBytes,omitempty"`
+	SwapTotalBytes    uint64 `protobuf:"varint,6,opt,name=SwapTotalBytes,proto3" json:"SwapTotalBytes,omitempty"`
+	SwapFreeBytes     uint64 `protobuf:"varint,7,opt,name=SwapFreeBytes,proto3" json:"SwapFreeBytes,omitempty"`
+	// Load averages of /proc/loadavg over 1, 5 and 15 minutes.
+	Load1  float64 `protobuf:"fixed64,8,opt,name=Load1,proto3" json:"Load1,omitempty"`
+	Load5  float64 `protobuf:"fixed64,9,opt,name=Load5,proto3" json:"Load5,omitempty"`
+	Load15 float64 `protobuf:"fixed64,10,opt,name=Load15,proto3" json:"Load15,omitempty"`
+}
+
+func (x *GetGuestStatsResponse) Reset() {
+	*x = GetGuestStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gueststats_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGuestStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGuestStatsResponse) ProtoMessage() {}
+
+func (x *GetGuestStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gueststats_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGuestStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetGuestStatsResponse) Descriptor() ([]byte, []int) {
+	return file_gueststats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetGuestStatsResponse) GetMemTotalBytes() uint64 {
+	if x != nil {
+		return x.MemTotalBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetMemFreeBytes() uint64 {
+	if x != nil {
+		return x.MemFreeBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetMemAvailableBytes() uint64 {
+	if x != nil {
+		return x.MemAvailableBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetBuffersBytes() uint64 {
+	if x != nil {
+		return x.BuffersBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetCachedBytes() uint64 {
+	if x != nil {
+		return x.CachedBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetSwapTotalBytes() uint64 {
+	if x != nil {
+		return x.SwapTotalBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetSwapFreeBytes() uint64 {
+	if x != nil {
+		return x.SwapFreeBytes
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetLoad1() float64 {
+	if x != nil {
+		return x.Load1
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetLoad5() float64 {
+	if x != nil {
+		return x.Load5
+	}
+	return 0
+}
+
+func (x *GetGuestStatsResponse) GetLoad15() float64 {
+	if x != nil {
+		return x.Load15
+	}
+	return 0
+}
+
+var File_gueststats_proto protoreflect.FileDescriptor
+
+var file_gueststats_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe7, 0x02, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x65, 0x6d, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x4d, 0x65, 0x6d,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x65,
+	0x6d, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x4d, 0x65, 0x6d, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c,
+	0x0a, 0x11, 0x4d, 0x65, 0x6d, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x4d, 0x65, 0x6d, 0x41, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x53, 0x77, 0x61, 0x70,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x77,
+	0x61, 0x70, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x53, 0x77, 0x61, 0x70, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x4c, 0x6f, 0x61, 0x64, 0x31, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x4c, 0x6f, 0x61, 0x64, 0x31, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x6f, 0x61, 0x64, 0x35, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x4c, 0x6f, 0x61, 0x64, 0x35, 0x12, 0x16, 0x0a, 0x06,
+	0x4c, 0x6f, 0x61, 0x64, 0x31, 0x35, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x4c, 0x6f,
+	0x61, 0x64, 0x31, 0x35, 0x32, 0x4c, 0x0a, 0x0a, 0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x47, 0x65, 0x74,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x3b, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gueststats_proto_rawDescOnce sync.Once
+	file_gueststats_proto_rawDescData = file_gueststats_proto_rawDesc
+)
+
+func file_gueststats_proto_rawDescGZIP() []byte {
+	file_gueststats_proto_rawDescOnce.Do(func() {
+		file_gueststats_proto_rawDescData = protoimpl.X.CompressGZIP(file_gueststats_proto_rawDescData)
+	})
+	return file_gueststats_proto_rawDescData
+}
+
+var file_gueststats_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_gueststats_proto_goTypes = []interface{}{
+	(*GetGuestStatsRequest)(nil),  // 0: GetGuestStatsRequest
+	(*GetGuestStatsResponse)(nil), // 1: GetGuestStatsResponse
+}
+var file_gueststats_proto_depIdxs = []int32{
+	0, // 0: GuestStats.GetGuestStats:input_type -> GetGuestStatsRequest
+	1, // 1: GuestStats.GetGuestStats:output_type -> GetGuestStatsResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gueststats_proto_init() }
+func file_gueststats_proto_init() {
+	if File_gueststats_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gueststats_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGuestStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gueststats_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGuestStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gueststats_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gueststats_proto_goTypes,
+		DependencyIndexes: file_gueststats_proto_depIdxs,
+		MessageInfos:      file_gueststats_proto_msgTypes,
+	}.Build()
+	File_gueststats_proto = out.File
+	file_gueststats_proto_rawDesc = nil
+	file_gueststats_proto_goTypes = nil
+	file_gueststats_proto_depIdxs = nil
+}
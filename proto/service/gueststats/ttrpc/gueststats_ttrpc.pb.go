@@ -0,0 +1,44 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: gueststats.proto
+package gueststats
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+)
+
+type GuestStatsService interface {
+	GetGuestStats(context.Context, *GetGuestStatsRequest) (*GetGuestStatsResponse, error)
+}
+
+func RegisterGuestStatsService(srv *ttrpc.Server, svc GuestStatsService) {
+	srv.RegisterService("GuestStats", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"GetGuestStats": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req GetGuestStatsRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetGuestStats(ctx, &req)
+			},
+		},
+	})
+}
+
+type gueststatsClient struct {
+	client *ttrpc.Client
+}
+
+func NewGuestStatsClient(client *ttrpc.Client) GuestStatsService {
+	return &gueststatsClient{
+		client: client,
+	}
+}
+
+func (c *gueststatsClient) GetGuestStats(ctx context.Context, req *GetGuestStatsRequest) (*GetGuestStatsResponse, error) {
+	var resp GetGuestStatsResponse
+	if err := c.client.Call(ctx, "GuestStats", "GetGuestStats", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
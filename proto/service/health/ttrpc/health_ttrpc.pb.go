@@ -0,0 +1,44 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: health.proto
+package health
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+)
+
+type HealthService interface {
+	GetHealth(context.Context, *GetHealthRequest) (*GetHealthResponse, error)
+}
+
+func RegisterHealthService(srv *ttrpc.Server, svc HealthService) {
+	srv.RegisterService("Health", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"GetHealth": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req GetHealthRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetHealth(ctx, &req)
+			},
+		},
+	})
+}
+
+type healthClient struct {
+	client *ttrpc.Client
+}
+
+func NewHealthClient(client *ttrpc.Client) HealthService {
+	return &healthClient{
+		client: client,
+	}
+}
+
+func (c *healthClient) GetHealth(ctx context.Context, req *GetHealthRequest) (*GetHealthResponse, error) {
+	var resp GetHealthResponse
+	if err := c.client.Call(ctx, "Health", "GetHealth", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -373,8 +373,24 @@ type IPConfiguration struct {
 	// Nameservers is a list of nameservers that the VM will be configured
 	// to use internally. Currently only up to 2 nameservers can be specified
 	// (any more in the list will be ignored) and configuration is provided
-	// to the VM via /proc/net/pnp.
+	// to the VM via /proc/net/pnp. If SearchDomains is set or one of the
+	// nameservers is an IPv6 address, the agent writes all of the nameservers
+	// and search domains to the VM's /etc/resolv.conf instead.
 	Nameservers []string `protobuf:"bytes,4,rep,name=Nameservers,proto3" json:"Nameservers,omitempty"`
+	// (Optional) AdditionalAddrs specifies, in CIDR notation, secondary IPv4
+	// addresses and IPv6 addresses that the network interface will be assigned
+	// inside the VM in addition to PrimaryAddr. PrimaryAddr and GatewayAddr may
+	// be left unset for interfaces with IPv6 addresses only.
+	AdditionalAddrs []string `protobuf:"bytes,5,rep,name=AdditionalAddrs,proto3" json:"AdditionalAddrs,omitempty"`
+	// (Optional) IPv6GatewayAddr specifies the default IPv6 gateway that a
+	// network interface should use inside the VM.
+	IPv6GatewayAddr string `protobuf:"bytes,6,opt,name=IPv6GatewayAddr,proto3" json:"IPv6GatewayAddr,omitempty"`
+	// (Optional) Routes are static routes through the network interface
+	// inside the VM.
+	Routes []*IPRoute `protobuf:"bytes,7,rep,name=Routes,proto3" json:"Routes,omitempty"`
+	// (Optional) SearchDomains are the DNS search domains the VM will be
+	// configured to use internally.
+	SearchDomains []string `protobuf:"bytes,8,rep,name=SearchDomains,proto3" json:"SearchDomains,omitempty"`
 }
 
 func (x *IPConfiguration) Reset() {
@@ -430,6 +446,102 @@ func (x *IPConfiguration) GetNameservers() []string {
 	return nil
 }
 
+func (x *IPConfiguration) GetAdditionalAddrs() []string {
+	if x != nil {
+		return x.AdditionalAddrs
+	}
+	return nil
+}
+
+func (x *IPConfiguration) GetIPv6GatewayAddr() string {
+	if x != nil {
+		return x.IPv6GatewayAddr
+	}
+	return ""
+}
+
+func (x *IPConfiguration) GetRoutes() []*IPRoute {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *IPConfiguration) GetSearchDomains() []string {
+	if x != nil {
+		return x.SearchDomains
+	}
+	return nil
+}
+
+// Message to specify a static route inside a Firecracker VM
+type IPRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Destination specifies, in CIDR notation, the IPv4 or IPv6 destination of the route.
+	Destination string `protobuf:"bytes,1,opt,name=Destination,proto3" json:"Destination,omitempty"`
+	// (Optional) GatewayAddr specifies the gateway of the route. The destination is
+	// directly reachable through the network interface if it is unset.
+	GatewayAddr string `protobuf:"bytes,2,opt,name=GatewayAddr,proto3" json:"GatewayAddr,omitempty"`
+	// (Optional) Metric specifies the priority of the route.
+	Metric uint32 `protobuf:"varint,3,opt,name=Metric,proto3" json:"Metric,omitempty"`
+}
+
+func (x *IPRoute) Reset() {
+	*x = IPRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IPRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPRoute) ProtoMessage() {}
+
+func (x *IPRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPRoute.ProtoReflect.Descriptor instead.
+func (*IPRoute) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IPRoute) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *IPRoute) GetGatewayAddr() string {
+	if x != nil {
+		return x.GatewayAddr
+	}
+	return ""
+}
+
+func (x *IPRoute) GetMetric() uint32 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
 // Message to set the machine config for a Firecracker VM
 type FirecrackerMachineConfiguration struct {
 	state         protoimpl.MessageState
@@ -448,7 +560,7 @@ type FirecrackerMachineConfiguration struct {
 func (x *FirecrackerMachineConfiguration) Reset() {
 	*x = FirecrackerMachineConfiguration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[5]
+		mi := &file_types_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -461,7 +573,7 @@ func (x *FirecrackerMachineConfiguration) String() string {
 func (*FirecrackerMachineConfiguration) ProtoMessage() {}
 
 func (x *FirecrackerMachineConfiguration) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[5]
+	mi := &file_types_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +586,7 @@ func (x *FirecrackerMachineConfiguration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirecrackerMachineConfiguration.ProtoReflect.Descriptor instead.
 func (*FirecrackerMachineConfiguration) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{5}
+	return file_types_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *FirecrackerMachineConfiguration) GetCPUTemplate() string {
@@ -533,7 +645,7 @@ type FirecrackerRootDrive struct {
 func (x *FirecrackerRootDrive) Reset() {
 	*x = FirecrackerRootDrive{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[6]
+		mi := &file_types_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -546,7 +658,7 @@ func (x *FirecrackerRootDrive) String() string {
 func (*FirecrackerRootDrive) ProtoMessage() {}
 
 func (x *FirecrackerRootDrive) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[6]
+	mi := &file_types_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,7 +671,7 @@ func (x *FirecrackerRootDrive) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirecrackerRootDrive.ProtoReflect.Descriptor instead.
 func (*FirecrackerRootDrive) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{6}
+	return file_types_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FirecrackerRootDrive) GetHostPath() string {
@@ -631,7 +743,7 @@ type FirecrackerDriveMount struct {
 func (x *FirecrackerDriveMount) Reset() {
 	*x = FirecrackerDriveMount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[7]
+		mi := &file_types_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -644,7 +756,7 @@ func (x *FirecrackerDriveMount) String() string {
 func (*FirecrackerDriveMount) ProtoMessage() {}
 
 func (x *FirecrackerDriveMount) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[7]
+	mi := &file_types_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +769,7 @@ func (x *FirecrackerDriveMount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirecrackerDriveMount.ProtoReflect.Descriptor instead.
 func (*FirecrackerDriveMount) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{7}
+	return file_types_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FirecrackerDriveMount) GetHostPath() string {
@@ -722,7 +834,7 @@ type FirecrackerRateLimiter struct {
 func (x *FirecrackerRateLimiter) Reset() {
 	*x = FirecrackerRateLimiter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[8]
+		mi := &file_types_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -735,7 +847,7 @@ func (x *FirecrackerRateLimiter) String() string {
 func (*FirecrackerRateLimiter) ProtoMessage() {}
 
 func (x *FirecrackerRateLimiter) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[8]
+	mi := &file_types_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +860,7 @@ func (x *FirecrackerRateLimiter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirecrackerRateLimiter.ProtoReflect.Descriptor instead.
 func (*FirecrackerRateLimiter) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{8}
+	return file_types_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FirecrackerRateLimiter) GetBandwidth() *FirecrackerTokenBucket {
@@ -779,7 +891,7 @@ type FirecrackerTokenBucket struct {
 func (x *FirecrackerTokenBucket) Reset() {
 	*x = FirecrackerTokenBucket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[9]
+		mi := &file_types_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -792,7 +904,7 @@ func (x *FirecrackerTokenBucket) String() string {
 func (*FirecrackerTokenBucket) ProtoMessage() {}
 
 func (x *FirecrackerTokenBucket) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[9]
+	mi := &file_types_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,7 +917,7 @@ func (x *FirecrackerTokenBucket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirecrackerTokenBucket.ProtoReflect.Descriptor instead.
 func (*FirecrackerTokenBucket) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{9}
+	return file_types_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *FirecrackerTokenBucket) GetOneTimeBurst() int64 {
@@ -837,12 +949,15 @@ type FirecrackerBalloonDevice struct {
 	AmountMib             int64 `protobuf:"varint,1,opt,name=AmountMib,proto3" json:"AmountMib,omitempty"`                         //Target balloon size in MiB.
 	DeflateOnOom          bool  `protobuf:"varint,2,opt,name=DeflateOnOom,proto3" json:"DeflateOnOom,omitempty"`                   // Whether the balloon should deflate when the guest has memory pressure.
 	StatsPollingIntervals int64 `protobuf:"varint,3,opt,name=StatsPollingIntervals,proto3" json:"StatsPollingIntervals,omitempty"` // Interval in seconds between refreshing statistics.
+	// (Optional) BalloonPolicy lets the runtime shim inflate and deflate the balloon automatically
+	// based on the statistics reported by the guest. StatsPollingIntervals must be set along with it.
+	BalloonPolicy *BalloonPolicy `protobuf:"bytes,4,opt,name=BalloonPolicy,proto3" json:"BalloonPolicy,omitempty"`
 }
 
 func (x *FirecrackerBalloonDevice) Reset() {
 	*x = FirecrackerBalloonDevice{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[10]
+		mi := &file_types_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -855,7 +970,7 @@ func (x *FirecrackerBalloonDevice) String() string {
 func (*FirecrackerBalloonDevice) ProtoMessage() {}
 
 func (x *FirecrackerBalloonDevice) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[10]
+	mi := &file_types_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,7 +983,7 @@ func (x *FirecrackerBalloonDevice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirecrackerBalloonDevice.ProtoReflect.Descriptor instead.
 func (*FirecrackerBalloonDevice) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{10}
+	return file_types_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FirecrackerBalloonDevice) GetAmountMib() int64 {
@@ -892,6 +1007,95 @@ func (x *FirecrackerBalloonDevice) GetStatsPollingIntervals() int64 {
 	return 0
 }
 
+func (x *FirecrackerBalloonDevice) GetBalloonPolicy() *BalloonPolicy {
+	if x != nil {
+		return x.BalloonPolicy
+	}
+	return nil
+}
+
+// Message to specify how the runtime shim should size a balloon device automatically.
+// The balloon is inflated when the guest has more free memory than TargetFreeMib and
+// deflated when it has less or when it starts swapping in, within MinMib and MaxMib.
+type BalloonPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinMib          int64 `protobuf:"varint,1,opt,name=MinMib,proto3" json:"MinMib,omitempty"`                   // Smallest size in MiB the balloon is deflated to.
+	MaxMib          int64 `protobuf:"varint,2,opt,name=MaxMib,proto3" json:"MaxMib,omitempty"`                   // Largest size in MiB the balloon is inflated to.
+	TargetFreeMib   int64 `protobuf:"varint,3,opt,name=TargetFreeMib,proto3" json:"TargetFreeMib,omitempty"`     // Amount of memory in MiB the guest should keep available.
+	MaxStepMib      int64 `protobuf:"varint,4,opt,name=MaxStepMib,proto3" json:"MaxStepMib,omitempty"`           // (Optional) Largest change in MiB made to the balloon at once. Unlimited if 0.
+	IntervalSeconds int64 `protobuf:"varint,5,opt,name=IntervalSeconds,proto3" json:"IntervalSeconds,omitempty"` // (Optional) Interval in seconds between adjustments. Defaults to StatsPollingIntervals.
+}
+
+func (x *BalloonPolicy) Reset() {
+	*x = BalloonPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BalloonPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalloonPolicy) ProtoMessage() {}
+
+func (x *BalloonPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalloonPolicy.ProtoReflect.Descriptor instead.
+func (*BalloonPolicy) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BalloonPolicy) GetMinMib() int64 {
+	if x != nil {
+		return x.MinMib
+	}
+	return 0
+}
+
+func (x *BalloonPolicy) GetMaxMib() int64 {
+	if x != nil {
+		return x.MaxMib
+	}
+	return 0
+}
+
+func (x *BalloonPolicy) GetTargetFreeMib() int64 {
+	if x != nil {
+		return x.TargetFreeMib
+	}
+	return 0
+}
+
+func (x *BalloonPolicy) GetMaxStepMib() int64 {
+	if x != nil {
+		return x.MaxStepMib
+	}
+	return 0
+}
+
+func (x *BalloonPolicy) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
 type CNIConfiguration_CNIArg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -904,7 +1108,7 @@ type CNIConfiguration_CNIArg struct {
 func (x *CNIConfiguration_CNIArg) Reset() {
 	*x = CNIConfiguration_CNIArg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[11]
+		mi := &file_types_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -917,7 +1121,7 @@ func (x *CNIConfiguration_CNIArg) String() string {
 func (*CNIConfiguration_CNIArg) ProtoMessage() {}
 
 func (x *CNIConfiguration_CNIArg) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[11]
+	mi := &file_types_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1009,79 +1213,110 @@ var file_types_proto_rawDesc = []byte{
 	0x65, 0x76, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x49, 0x50, 0x43, 0x6f, 0x6e, 0x66,
 	0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x49, 0x50, 0x43, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x49, 0x50, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x22, 0x77, 0x0a, 0x0f, 0x49, 0x50, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x6d, 0x61,
-	0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x50, 0x72,
-	0x69, 0x6d, 0x61, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x47, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x4e,
-	0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x9f, 0x01,
-	0x0a, 0x1f, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x50, 0x55, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x50, 0x55, 0x54, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x48, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x69, 0x62, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x4d, 0x65, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x69,
-	0x62, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x56, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0xc7, 0x01, 0x0a, 0x14, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52,
-	0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74,
-	0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74,
-	0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x72, 0x74, 0x75, 0x75, 0x69, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x61, 0x72, 0x74, 0x75, 0x75, 0x69, 0x64,
-	0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x73, 0x57, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x73, 0x57, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65,
-	0x12, 0x39, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
-	0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b,
-	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x43,
-	0x61, 0x63, 0x68, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x86, 0x02, 0x0a, 0x15, 0x46, 0x69,
-	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12,
-	0x16, 0x0a, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x46, 0x69, 0x6c, 0x65, 0x73,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x39, 0x0a, 0x0b, 0x52, 0x61, 0x74,
-	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x73, 0x57, 0x72, 0x69, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x73, 0x57, 0x72, 0x69, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x22, 0x7a, 0x0a, 0x16, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
-	0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x09,
-	0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69,
-	0x64, 0x74, 0x68, 0x12, 0x29, 0x0a, 0x03, 0x4f, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x03, 0x4f, 0x70, 0x73, 0x22, 0x78,
-	0x0a, 0x16, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x54,
-	0x69, 0x6d, 0x65, 0x42, 0x75, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
-	0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x75, 0x72, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
-	0x52, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0a, 0x52, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x92, 0x01, 0x0a, 0x18, 0x46, 0x69, 0x72,
-	0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d,
-	0x69, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x4d, 0x69, 0x62, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65, 0x66, 0x6c, 0x61, 0x74, 0x65, 0x4f, 0x6e,
-	0x4f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x44, 0x65, 0x66, 0x6c, 0x61,
-	0x74, 0x65, 0x4f, 0x6e, 0x4f, 0x6f, 0x6d, 0x12, 0x34, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x6f, 0x6c,
-	0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x42, 0x09, 0x5a,
-	0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x66, 0x69, 0x67, 0x22, 0x93, 0x02, 0x0a, 0x0f, 0x49, 0x50, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x6d,
+	0x61, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x50,
+	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x12, 0x20, 0x0a, 0x0b,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x28,
+	0x0a, 0x0f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x49, 0x50, 0x76, 0x36,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x49, 0x50, 0x76, 0x36, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64,
+	0x64, 0x72, 0x12, 0x20, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x49, 0x50, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x06, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x65, 0x0a, 0x07, 0x49, 0x50,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x47, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x22, 0x9f, 0x01, 0x0a, 0x1f, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x50, 0x55, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x50, 0x55, 0x54,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x74, 0x45, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x48, 0x74, 0x45, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x53, 0x69, 0x7a, 0x65,
+	0x4d, 0x69, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x4d, 0x65, 0x6d, 0x53, 0x69,
+	0x7a, 0x65, 0x4d, 0x69, 0x62, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x56, 0x63, 0x70, 0x75, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xc7, 0x01, 0x0a, 0x14, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x72, 0x74,
+	0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x61, 0x72, 0x74,
+	0x75, 0x75, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x73, 0x57, 0x72, 0x69, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x73, 0x57, 0x72, 0x69, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65,
+	0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x65, 0x72, 0x52, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x86, 0x02,
+	0x0a, 0x15, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x39, 0x0a,
+	0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x73, 0x57, 0x72,
+	0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x73,
+	0x57, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x7a, 0x0a, 0x16, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x12, 0x35, 0x0a, 0x09, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x42, 0x61,
+	0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x29, 0x0a, 0x03, 0x4f, 0x70, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x03, 0x4f,
+	0x70, 0x73, 0x22, 0x78, 0x0a, 0x16, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x75, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x75, 0x72, 0x73, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x52, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0xc8, 0x01, 0x0a,
+	0x18, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x6c,
+	0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65, 0x66, 0x6c, 0x61,
+	0x74, 0x65, 0x4f, 0x6e, 0x4f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x44,
+	0x65, 0x66, 0x6c, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x4f, 0x6f, 0x6d, 0x12, 0x34, 0x0a, 0x15, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x73, 0x12, 0x34, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0xaf, 0x01, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c,
+	0x6f, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x69, 0x6e,
+	0x4d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4d, 0x69, 0x6e, 0x4d, 0x69,
+	0x62, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x62, 0x12, 0x24, 0x0a, 0x0d, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x46, 0x72, 0x65, 0x65, 0x4d, 0x69, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x72, 0x65, 0x65, 0x4d, 0x69, 0x62, 0x12,
+	0x1e, 0x0a, 0x0a, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x65, 0x70, 0x4d, 0x69, 0x62, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x65, 0x70, 0x4d, 0x69, 0x62, 0x12,
+	0x28, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1096,39 +1331,43 @@ func file_types_proto_rawDescGZIP() []byte {
 	return file_types_proto_rawDescData
 }
 
-var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_types_proto_goTypes = []interface{}{
 	(*ExtraData)(nil),                       // 0: ExtraData
 	(*FirecrackerNetworkInterface)(nil),     // 1: FirecrackerNetworkInterface
 	(*CNIConfiguration)(nil),                // 2: CNIConfiguration
 	(*StaticNetworkConfiguration)(nil),      // 3: StaticNetworkConfiguration
 	(*IPConfiguration)(nil),                 // 4: IPConfiguration
-	(*FirecrackerMachineConfiguration)(nil), // 5: FirecrackerMachineConfiguration
-	(*FirecrackerRootDrive)(nil),            // 6: FirecrackerRootDrive
-	(*FirecrackerDriveMount)(nil),           // 7: FirecrackerDriveMount
-	(*FirecrackerRateLimiter)(nil),          // 8: FirecrackerRateLimiter
-	(*FirecrackerTokenBucket)(nil),          // 9: FirecrackerTokenBucket
-	(*FirecrackerBalloonDevice)(nil),        // 10: FirecrackerBalloonDevice
-	(*CNIConfiguration_CNIArg)(nil),         // 11: CNIConfiguration.CNIArg
-	(*anypb.Any)(nil),                       // 12: google.protobuf.Any
+	(*IPRoute)(nil),                         // 5: IPRoute
+	(*FirecrackerMachineConfiguration)(nil), // 6: FirecrackerMachineConfiguration
+	(*FirecrackerRootDrive)(nil),            // 7: FirecrackerRootDrive
+	(*FirecrackerDriveMount)(nil),           // 8: FirecrackerDriveMount
+	(*FirecrackerRateLimiter)(nil),          // 9: FirecrackerRateLimiter
+	(*FirecrackerTokenBucket)(nil),          // 10: FirecrackerTokenBucket
+	(*FirecrackerBalloonDevice)(nil),        // 11: FirecrackerBalloonDevice
+	(*BalloonPolicy)(nil),                   // 12: BalloonPolicy
+	(*CNIConfiguration_CNIArg)(nil),         // 13: CNIConfiguration.CNIArg
+	(*anypb.Any)(nil),                       // 14: google.protobuf.Any
 }
 var file_types_proto_depIdxs = []int32{
-	12, // 0: ExtraData.RuncOptions:type_name -> google.protobuf.Any
-	8,  // 1: FirecrackerNetworkInterface.InRateLimiter:type_name -> FirecrackerRateLimiter
-	8,  // 2: FirecrackerNetworkInterface.OutRateLimiter:type_name -> FirecrackerRateLimiter
+	14, // 0: ExtraData.RuncOptions:type_name -> google.protobuf.Any
+	9,  // 1: FirecrackerNetworkInterface.InRateLimiter:type_name -> FirecrackerRateLimiter
+	9,  // 2: FirecrackerNetworkInterface.OutRateLimiter:type_name -> FirecrackerRateLimiter
 	2,  // 3: FirecrackerNetworkInterface.CNIConfig:type_name -> CNIConfiguration
 	3,  // 4: FirecrackerNetworkInterface.StaticConfig:type_name -> StaticNetworkConfiguration
-	11, // 5: CNIConfiguration.Args:type_name -> CNIConfiguration.CNIArg
+	13, // 5: CNIConfiguration.Args:type_name -> CNIConfiguration.CNIArg
 	4,  // 6: StaticNetworkConfiguration.IPConfig:type_name -> IPConfiguration
-	8,  // 7: FirecrackerRootDrive.RateLimiter:type_name -> FirecrackerRateLimiter
-	8,  // 8: FirecrackerDriveMount.RateLimiter:type_name -> FirecrackerRateLimiter
-	9,  // 9: FirecrackerRateLimiter.Bandwidth:type_name -> FirecrackerTokenBucket
-	9,  // 10: FirecrackerRateLimiter.Ops:type_name -> FirecrackerTokenBucket
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	5,  // 7: IPConfiguration.Routes:type_name -> IPRoute
+	9,  // 8: FirecrackerRootDrive.RateLimiter:type_name -> FirecrackerRateLimiter
+	9,  // 9: FirecrackerDriveMount.RateLimiter:type_name -> FirecrackerRateLimiter
+	10, // 10: FirecrackerRateLimiter.Bandwidth:type_name -> FirecrackerTokenBucket
+	10, // 11: FirecrackerRateLimiter.Ops:type_name -> FirecrackerTokenBucket
+	12, // 12: FirecrackerBalloonDevice.BalloonPolicy:type_name -> BalloonPolicy
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_types_proto_init() }
@@ -1198,7 +1437,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirecrackerMachineConfiguration); i {
+			switch v := v.(*IPRoute); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1210,7 +1449,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirecrackerRootDrive); i {
+			switch v := v.(*FirecrackerMachineConfiguration); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1222,7 +1461,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirecrackerDriveMount); i {
+			switch v := v.(*FirecrackerRootDrive); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1234,7 +1473,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirecrackerRateLimiter); i {
+			switch v := v.(*FirecrackerDriveMount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1246,7 +1485,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirecrackerTokenBucket); i {
+			switch v := v.(*FirecrackerRateLimiter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1258,7 +1497,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirecrackerBalloonDevice); i {
+			switch v := v.(*FirecrackerTokenBucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1270,6 +1509,30 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerBalloonDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalloonPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CNIConfiguration_CNIArg); i {
 			case 0:
 				return &v.state
@@ -1288,7 +1551,7 @@ func file_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_types_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
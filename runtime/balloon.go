@@ -0,0 +1,156 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const bytesPerMib = 1024 * 1024
+
+// validateBalloonPolicy checks the balloon policy of the provided balloon device, if any.
+func validateBalloonPolicy(device *proto.FirecrackerBalloonDevice) error {
+	policy := device.GetBalloonPolicy()
+	if policy == nil {
+		return nil
+	}
+
+	if device.StatsPollingIntervals <= 0 {
+		return errors.New("balloon policy requires StatsPollingIntervals to be set")
+	}
+	if policy.MinMib < 0 || policy.MaxMib < policy.MinMib {
+		return errors.New("balloon policy MinMib cannot be negative or larger than MaxMib")
+	}
+	if policy.TargetFreeMib <= 0 {
+		return errors.New("balloon policy TargetFreeMib must be set")
+	}
+	if policy.MaxStepMib < 0 || policy.IntervalSeconds < 0 {
+		return errors.New("balloon policy MaxStepMib and IntervalSeconds cannot be negative")
+	}
+
+	return nil
+}
+
+// balloonController computes the size of a balloon device from the statistics reported by the
+// guest, following a BalloonPolicy.
+type balloonController struct {
+	policy *proto.BalloonPolicy
+
+	sampled    bool
+	lastSwapIn int64
+}
+
+func newBalloonController(policy *proto.BalloonPolicy) *balloonController {
+	return &balloonController{policy: policy}
+}
+
+// target returns the size in MiB the balloon should be set to given the latest statistics.
+//
+// The guest needs memory back if it started swapping in since the last sample or if it has less
+// than TargetFreeMib available. Otherwise, the memory available beyond TargetFreeMib is reclaimed,
+// not counting disk caches, which the guest would need to drop first.
+func (c *balloonController) target(stats *proto.GetBalloonStatsResponse) int64 {
+	swappedIn := c.sampled && stats.SwapIn > c.lastSwapIn
+	c.sampled = true
+	c.lastSwapIn = stats.SwapIn
+
+	current := stats.TargetMib
+	available := stats.AvailableMemory / bytesPerMib
+
+	var delta int64
+	switch {
+	case swappedIn:
+		delta = -c.policy.TargetFreeMib
+	case available < c.policy.TargetFreeMib:
+		delta = available - c.policy.TargetFreeMib
+	default:
+		if surplus := available - stats.DiskCaches/bytesPerMib - c.policy.TargetFreeMib; surplus > 0 {
+			delta = surplus
+		}
+	}
+
+	if step := c.policy.MaxStepMib; step > 0 {
+		if delta > step {
+			delta = step
+		} else if delta < -step {
+			delta = -step
+		}
+	}
+
+	target := current + delta
+	if target < c.policy.MinMib {
+		target = c.policy.MinMib
+	}
+	if target > c.policy.MaxMib {
+		target = c.policy.MaxMib
+	}
+	return target
+}
+
+// runBalloonController periodically resizes the balloon device following the provided policy
+// until the shim exits.
+func (s *service) runBalloonController(policy *proto.BalloonPolicy, interval time.Duration) {
+	logger := s.log().WithField("component", "balloon-controller")
+	logger.Infof("starting balloon controller: MinMib=%d MaxMib=%d TargetFreeMib=%d interval=%s",
+		policy.MinMib, policy.MaxMib, policy.TargetFreeMib, interval)
+
+	controller := newBalloonController(policy)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shimCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := s.getBalloonStats(s.shimCtx)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get balloon statistics")
+			continue
+		}
+		if stats.AvailableMemory == 0 {
+			// the guest hasn't reported its memory statistics yet
+			continue
+		}
+
+		target := controller.target(stats)
+		if target == stats.TargetMib {
+			continue
+		}
+
+		logger.Debugf("resizing balloon from %d MiB to %d MiB", stats.TargetMib, target)
+		if err := s.updateBalloon(s.shimCtx, target); err != nil {
+			logger.WithError(err).Warn("failed to resize balloon")
+		}
+	}
+}
+
+// startBalloonController starts the balloon controller if the VM's balloon device has a policy.
+func (s *service) startBalloonController(device *proto.FirecrackerBalloonDevice) {
+	policy := device.GetBalloonPolicy()
+	if policy == nil {
+		return
+	}
+
+	interval := time.Duration(policy.IntervalSeconds) * time.Second
+	if interval == 0 {
+		interval = time.Duration(device.StatsPollingIntervals) * time.Second
+	}
+	go s.runBalloonController(policy, interval)
+}
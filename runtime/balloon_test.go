@@ -0,0 +1,112 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestBalloonControllerTarget(t *testing.T) {
+	policy := &proto.BalloonPolicy{
+		MinMib:        16,
+		MaxMib:        512,
+		TargetFreeMib: 128,
+	}
+
+	for _, tc := range []struct {
+		name     string
+		policy   *proto.BalloonPolicy
+		previous *proto.GetBalloonStatsResponse
+		stats    *proto.GetBalloonStatsResponse
+		expected int64
+	}{
+		{
+			name:     "inflate by surplus",
+			policy:   policy,
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 64, AvailableMemory: 328 * bytesPerMib},
+			expected: 264,
+		},
+		{
+			name:     "disk caches are not reclaimed",
+			policy:   policy,
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 64, AvailableMemory: 328 * bytesPerMib, DiskCaches: 200 * bytesPerMib},
+			expected: 64,
+		},
+		{
+			name:     "deflate below watermark",
+			policy:   policy,
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 256, AvailableMemory: 100 * bytesPerMib},
+			expected: 228,
+		},
+		{
+			name:     "deflate on swap in",
+			policy:   policy,
+			previous: &proto.GetBalloonStatsResponse{TargetMib: 256, AvailableMemory: 128 * bytesPerMib, SwapIn: 10},
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 256, AvailableMemory: 128 * bytesPerMib, SwapIn: 20},
+			expected: 128,
+		},
+		{
+			name:     "clamped to max",
+			policy:   policy,
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 400, AvailableMemory: 1024 * bytesPerMib},
+			expected: 512,
+		},
+		{
+			name:     "clamped to min",
+			policy:   policy,
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 32, AvailableMemory: 8 * bytesPerMib},
+			expected: 16,
+		},
+		{
+			name:     "limited by step",
+			policy:   &proto.BalloonPolicy{MinMib: 0, MaxMib: 512, TargetFreeMib: 128, MaxStepMib: 32},
+			stats:    &proto.GetBalloonStatsResponse{TargetMib: 64, AvailableMemory: 328 * bytesPerMib},
+			expected: 96,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := newBalloonController(tc.policy)
+			if tc.previous != nil {
+				c.target(tc.previous)
+			}
+			assert.Equal(t, tc.expected, c.target(tc.stats))
+		})
+	}
+}
+
+func TestValidateBalloonPolicy(t *testing.T) {
+	assert.NoError(t, validateBalloonPolicy(nil))
+	assert.NoError(t, validateBalloonPolicy(&proto.FirecrackerBalloonDevice{AmountMib: 64}))
+	assert.NoError(t, validateBalloonPolicy(&proto.FirecrackerBalloonDevice{
+		StatsPollingIntervals: 1,
+		BalloonPolicy:         &proto.BalloonPolicy{MaxMib: 512, TargetFreeMib: 128},
+	}))
+
+	assert.Error(t, validateBalloonPolicy(&proto.FirecrackerBalloonDevice{
+		BalloonPolicy: &proto.BalloonPolicy{MaxMib: 512, TargetFreeMib: 128},
+	}), "stats must be enabled")
+	assert.Error(t, validateBalloonPolicy(&proto.FirecrackerBalloonDevice{
+		StatsPollingIntervals: 1,
+		BalloonPolicy:         &proto.BalloonPolicy{MinMib: 256, MaxMib: 128, TargetFreeMib: 128},
+	}), "min larger than max")
+	assert.Error(t, validateBalloonPolicy(&proto.FirecrackerBalloonDevice{
+		StatsPollingIntervals: 1,
+		BalloonPolicy:         &proto.BalloonPolicy{MaxMib: 512},
+	}), "missing watermark")
+}
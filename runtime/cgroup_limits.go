@@ -0,0 +1,218 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// defaultCPUPeriod is the period, in microseconds, of the CPU quota of a cgroup if none is set.
+const defaultCPUPeriod = 100000
+
+// cgroupLimits are the resource limits of the cgroup of a jailed Firecracker VMM. A limit is
+// left unset if its value is 0.
+type cgroupLimits struct {
+	MemoryMax int64
+	CPUQuota  int64
+	CPUPeriod uint64
+	IOMax     []ioLimit
+	PidsMax   int64
+}
+
+// ioLimit limits the I/O of a cgroup on the block device of the provided numbers.
+type ioLimit struct {
+	Major     int64
+	Minor     int64
+	ReadBps   uint64
+	WriteBps  uint64
+	ReadIOPS  uint64
+	WriteIOPS uint64
+}
+
+// cgroupLimitsFromProto validates the limits of a jailer config and resolves their block devices.
+func cgroupLimitsFromProto(cfg *proto.JailerConfig) (cgroupLimits, error) {
+	limits := cgroupLimits{
+		MemoryMax: cfg.MemoryMax,
+		CPUQuota:  cfg.CPUQuota,
+		CPUPeriod: cfg.CPUPeriod,
+		PidsMax:   cfg.PidsMax,
+	}
+
+	if limits.MemoryMax < 0 {
+		return cgroupLimits{}, fmt.Errorf("invalid MemoryMax %d", limits.MemoryMax)
+	}
+	if limits.CPUQuota < 0 {
+		return cgroupLimits{}, fmt.Errorf("invalid CPUQuota %d", limits.CPUQuota)
+	}
+	if limits.PidsMax < 0 {
+		return cgroupLimits{}, fmt.Errorf("invalid PidsMax %d", limits.PidsMax)
+	}
+	if limits.CPUQuota == 0 && limits.CPUPeriod != 0 {
+		return cgroupLimits{}, errors.New("CPUPeriod cannot be set without CPUQuota")
+	}
+	if limits.CPUQuota != 0 && limits.CPUPeriod == 0 {
+		limits.CPUPeriod = defaultCPUPeriod
+	}
+
+	for _, io := range cfg.IOMax {
+		major, minor, err := blockDeviceNumbers(io.Device)
+		if err != nil {
+			return cgroupLimits{}, err
+		}
+		limits.IOMax = append(limits.IOMax, ioLimit{
+			Major:     major,
+			Minor:     minor,
+			ReadBps:   io.ReadBps,
+			WriteBps:  io.WriteBps,
+			ReadIOPS:  io.ReadIOPS,
+			WriteIOPS: io.WriteIOPS,
+		})
+	}
+
+	return limits, nil
+}
+
+// blockDeviceNumbers returns the major and minor numbers of a block device, provided either as
+// a path or as "major:minor".
+func blockDeviceNumbers(device string) (int64, int64, error) {
+	if major, minor, ok := strings.Cut(device, ":"); ok && !strings.Contains(device, "/") {
+		majorNum, majorErr := strconv.ParseInt(major, 10, 64)
+		minorNum, minorErr := strconv.ParseInt(minor, 10, 64)
+		if majorErr != nil || minorErr != nil {
+			return 0, 0, fmt.Errorf("invalid block device numbers %q", device)
+		}
+		return majorNum, minorNum, nil
+	}
+
+	var stat unix.Stat_t
+	if err := unix.Stat(device, &stat); err != nil {
+		return 0, 0, fmt.Errorf("failed to stat block device %q: %w", device, err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return 0, 0, fmt.Errorf("%q is not a block device", device)
+	}
+	return int64(unix.Major(stat.Rdev)), int64(unix.Minor(stat.Rdev)), nil
+}
+
+// applyToSpec sets the limits on the resources of a runc spec. runc translates them to the
+// files of either cgroup version.
+func (l cgroupLimits) applyToSpec(resources *specs.LinuxResources) {
+	if l.MemoryMax != 0 {
+		if resources.Memory == nil {
+			resources.Memory = &specs.LinuxMemory{}
+		}
+		limit := l.MemoryMax
+		resources.Memory.Limit = &limit
+	}
+
+	if l.CPUQuota != 0 {
+		if resources.CPU == nil {
+			resources.CPU = &specs.LinuxCPU{}
+		}
+		quota, period := l.CPUQuota, l.CPUPeriod
+		resources.CPU.Quota = &quota
+		resources.CPU.Period = &period
+	}
+
+	if len(l.IOMax) > 0 {
+		if resources.BlockIO == nil {
+			resources.BlockIO = &specs.LinuxBlockIO{}
+		}
+		blockIO := resources.BlockIO
+		for _, io := range l.IOMax {
+			device := specs.LinuxBlockIODevice{Major: io.Major, Minor: io.Minor}
+			blockIO.ThrottleReadBpsDevice = appendThrottleDevice(blockIO.ThrottleReadBpsDevice, device, io.ReadBps)
+			blockIO.ThrottleWriteBpsDevice = appendThrottleDevice(blockIO.ThrottleWriteBpsDevice, device, io.WriteBps)
+			blockIO.ThrottleReadIOPSDevice = appendThrottleDevice(blockIO.ThrottleReadIOPSDevice, device, io.ReadIOPS)
+			blockIO.ThrottleWriteIOPSDevice = appendThrottleDevice(blockIO.ThrottleWriteIOPSDevice, device, io.WriteIOPS)
+		}
+	}
+
+	if l.PidsMax != 0 {
+		resources.Pids = &specs.LinuxPids{Limit: l.PidsMax}
+	}
+}
+
+func appendThrottleDevice(devices []specs.LinuxThrottleDevice, device specs.LinuxBlockIODevice, rate uint64) []specs.LinuxThrottleDevice {
+	if rate == 0 {
+		return devices
+	}
+	return append(devices, specs.LinuxThrottleDevice{LinuxBlockIODevice: device, Rate: rate})
+}
+
+// cgroupFiles returns the limits as the values of the files of a cgroup of the provided
+// version, formatted as "file=value" like the --cgroup arguments of Firecracker's jailer.
+// IOMax is left out with cgroup v2, as the jailer cannot parse the values of io.max.
+func (l cgroupLimits) cgroupFiles(v2 bool) []string {
+	var files []string
+	if l.MemoryMax != 0 {
+		if v2 {
+			files = append(files, fmt.Sprintf("memory.max=%d", l.MemoryMax))
+		} else {
+			files = append(files, fmt.Sprintf("memory.limit_in_bytes=%d", l.MemoryMax))
+		}
+	}
+
+	if l.CPUQuota != 0 {
+		if v2 {
+			files = append(files, fmt.Sprintf("cpu.max=%d %d", l.CPUQuota, l.CPUPeriod))
+		} else {
+			files = append(files,
+				fmt.Sprintf("cpu.cfs_period_us=%d", l.CPUPeriod),
+				fmt.Sprintf("cpu.cfs_quota_us=%d", l.CPUQuota))
+		}
+	}
+
+	for _, io := range l.IOMax {
+		if v2 {
+			break
+		}
+
+		for _, limit := range []struct {
+			file string
+			rate uint64
+		}{
+			{"blkio.throttle.read_bps_device", io.ReadBps},
+			{"blkio.throttle.write_bps_device", io.WriteBps},
+			{"blkio.throttle.read_iops_device", io.ReadIOPS},
+			{"blkio.throttle.write_iops_device", io.WriteIOPS},
+		} {
+			if limit.rate != 0 {
+				files = append(files, fmt.Sprintf("%s=%d:%d %d", limit.file, io.Major, io.Minor, limit.rate))
+			}
+		}
+	}
+
+	if l.PidsMax != 0 {
+		files = append(files, fmt.Sprintf("pids.max=%d", l.PidsMax))
+	}
+
+	return files
+}
+
+// isCgroupV2 returns whether the cgroup hierarchy mounted at root is a unified cgroup v2 hierarchy.
+func isCgroupV2(root string) bool {
+	_, err := os.Stat(filepath.Join(root, "cgroup.controllers"))
+	return err == nil
+}
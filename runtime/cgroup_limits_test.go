@@ -0,0 +1,163 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+var testCgroupLimits = cgroupLimits{
+	MemoryMax: 512 << 20,
+	CPUQuota:  50000,
+	CPUPeriod: 100000,
+	IOMax:     []ioLimit{{Major: 8, Minor: 0, ReadBps: 1 << 20, WriteIOPS: 100}},
+	PidsMax:   64,
+}
+
+func TestCgroupLimitsFromProto(t *testing.T) {
+	limits, err := cgroupLimitsFromProto(&proto.JailerConfig{
+		MemoryMax: 512 << 20,
+		CPUQuota:  50000,
+		IOMax:     []*proto.CgroupIOLimit{{Device: "8:0", ReadBps: 1 << 20, WriteIOPS: 100}},
+		PidsMax:   64,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testCgroupLimits, limits, "the CPU period should default to 100ms")
+
+	limits, err = cgroupLimitsFromProto(&proto.JailerConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, cgroupLimits{}, limits)
+
+	for name, cfg := range map[string]*proto.JailerConfig{
+		"negative memory":        {MemoryMax: -1},
+		"negative CPU quota":     {CPUQuota: -1},
+		"negative pids":          {PidsMax: -1},
+		"period without quota":   {CPUPeriod: 100000},
+		"invalid device numbers": {IOMax: []*proto.CgroupIOLimit{{Device: "8:a"}}},
+		"missing device":         {IOMax: []*proto.CgroupIOLimit{{Device: "/dev/does-not-exist"}}},
+		"not a block device":     {IOMax: []*proto.CgroupIOLimit{{Device: "/dev/null"}}},
+	} {
+		_, err := cgroupLimitsFromProto(cfg)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCgroupLimitsApplyToSpec(t *testing.T) {
+	resources := &specs.LinuxResources{CPU: &specs.LinuxCPU{Cpus: "0-1"}}
+	testCgroupLimits.applyToSpec(resources)
+
+	require.NotNil(t, resources.Memory)
+	assert.Equal(t, int64(512<<20), *resources.Memory.Limit)
+	assert.Equal(t, "0-1", resources.CPU.Cpus)
+	assert.Equal(t, int64(50000), *resources.CPU.Quota)
+	assert.Equal(t, uint64(100000), *resources.CPU.Period)
+	assert.Equal(t, int64(64), resources.Pids.Limit)
+
+	device := specs.LinuxBlockIODevice{Major: 8, Minor: 0}
+	assert.Equal(t, []specs.LinuxThrottleDevice{{LinuxBlockIODevice: device, Rate: 1 << 20}}, resources.BlockIO.ThrottleReadBpsDevice)
+	assert.Empty(t, resources.BlockIO.ThrottleWriteBpsDevice)
+	assert.Empty(t, resources.BlockIO.ThrottleReadIOPSDevice)
+	assert.Equal(t, []specs.LinuxThrottleDevice{{LinuxBlockIODevice: device, Rate: 100}}, resources.BlockIO.ThrottleWriteIOPSDevice)
+
+	resources = &specs.LinuxResources{}
+	cgroupLimits{}.applyToSpec(resources)
+	assert.Equal(t, &specs.LinuxResources{}, resources, "unset limits should be left out of the spec")
+}
+
+func TestCgroupLimitsCgroupFiles(t *testing.T) {
+	assert.Equal(t, []string{
+		"memory.max=536870912",
+		"cpu.max=50000 100000",
+		"pids.max=64",
+	}, testCgroupLimits.cgroupFiles(true), "the jailer cannot parse io.max")
+
+	assert.Equal(t, []string{
+		"memory.limit_in_bytes=536870912",
+		"cpu.cfs_period_us=100000",
+		"cpu.cfs_quota_us=50000",
+		"blkio.throttle.read_bps_device=8:0 1048576",
+		"blkio.throttle.write_iops_device=8:0 100",
+		"pids.max=64",
+	}, testCgroupLimits.cgroupFiles(false))
+}
+
+// parseJailerCgroupArg parses a --cgroup argument of Firecracker's jailer the way the jailer
+// does, which splits it on "=" and requires exactly a file and a value.
+func parseJailerCgroupArg(arg string) (string, string, error) {
+	parts := strings.Split(arg, "=")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid cgroup argument %q", arg)
+	}
+	return parts[0], parts[1], nil
+}
+
+func TestFirecrackerJailerCgroupArgs(t *testing.T) {
+	for _, version := range []string{"1", "2"} {
+		j := &firecrackerJailer{
+			vmID: "vm-id",
+			Config: firecrackerJailerConfig{
+				CgroupVersion: version,
+				CPUs:          "0-1",
+				Mems:          "0",
+				Limits:        testCgroupLimits,
+			},
+		}
+		args := j.cgroupArgs()
+		assert.NotEmpty(t, args)
+		for _, arg := range args {
+			_, _, err := parseJailerCgroupArg(arg)
+			assert.NoError(t, err, "cgroup v%s", version)
+		}
+	}
+
+	_, err := newFirecrackerJailer(context.Background(), logrus.NewEntry(logrus.New()), "vm-id", firecrackerJailerConfig{
+		FirecrackerBinPath: "/usr/local/bin/firecracker",
+		CgroupVersion:      "2",
+		Limits:             testCgroupLimits,
+	}, nil)
+	assert.Error(t, err, "IOMax should be rejected with cgroup v2")
+}
+
+func TestFirecrackerJailerCgroupDirs(t *testing.T) {
+	j := &firecrackerJailer{
+		vmID: "vm-id",
+		Config: firecrackerJailerConfig{
+			CPUs:   "0",
+			Limits: testCgroupLimits,
+		},
+	}
+	assert.Equal(t, []string{
+		"/sys/fs/cgroup/cpuset/firecracker-containerd/vm-id",
+		"/sys/fs/cgroup/memory/firecracker-containerd/vm-id",
+		"/sys/fs/cgroup/cpu/firecracker-containerd/vm-id",
+		"/sys/fs/cgroup/blkio/firecracker-containerd/vm-id",
+		"/sys/fs/cgroup/pids/firecracker-containerd/vm-id",
+	}, j.cgroupDirs())
+
+	j.Config.CgroupVersion = "2"
+	assert.Equal(t, []string{"/sys/fs/cgroup/firecracker-containerd/vm-id"}, j.cgroupDirs())
+
+	j.Config = firecrackerJailerConfig{}
+	assert.Empty(t, j.cgroupDirs())
+}
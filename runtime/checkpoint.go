@@ -0,0 +1,430 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	taskAPI "github.com/containerd/containerd/api/runtime/task/v2"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/bundle"
+)
+
+const (
+	// checkpointFilesystemType is the filesystem of the scratch images checkpoints are copied
+	// in and out of the VM with.
+	checkpointFilesystemType = "ext4"
+
+	// checkpointImageOverhead is the space added to scratch images for the filesystem's metadata.
+	checkpointImageOverhead = 64 * 1024 * 1024
+
+	// checkpointImageDir is the directory of a checkpoint scratch image runc writes the
+	// checkpoint to, keeping it apart from the lost+found directory of the filesystem.
+	checkpointImageDir = "image"
+
+	// checkpointImageInodeSize is the size of the inodes of checkpoint images, each file copied
+	// out of an image is counted as an inode of that size against the image's allocated size.
+	checkpointImageInodeSize = 256
+)
+
+// errCheckpointImageExpanded occurs when the copy of a checkpoint image would be larger than the
+// space allocated to the image.
+var errCheckpointImageExpanded = fmt.Errorf("checkpoint image expands beyond its allocated size")
+
+// checkpointReservationID returns the ID the scratch drive a task is checkpointed to is reserved
+// with in the container stub drive handler. Container IDs cannot contain "/", so it never collides
+// with the reservation of a container's rootfs drive.
+func checkpointReservationID(taskID string) string {
+	return "checkpoint/" + taskID
+}
+
+// restoreReservationID returns the ID the drive holding the checkpoint a task is restored from is
+// reserved with in the container stub drive handler.
+func restoreReservationID(taskID string) string {
+	return "restore/" + taskID
+}
+
+// checkpoint has the agent checkpoint a task to a scratch drive, then copies the checkpoint out
+// of the drive to req.Path on the host.
+func (s *service) checkpoint(requestCtx context.Context, req *taskAPI.CheckpointTaskRequest) (err error) {
+	// Guest memory bounds the size of a checkpoint. The image is sparse, so only the space
+	// actually used by the checkpoint is allocated on the host.
+	size := firecracker.Int64Value(s.machineConfig.MachineCfg.MemSizeMib)*1024*1024 + checkpointImageOverhead
+	imagePath, err := s.createCheckpointImage(requestCtx, size, "")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if removeErr := os.Remove(imagePath); removeErr != nil {
+			err = multierror.Append(err, fmt.Errorf("failed to remove checkpoint image %q: %w", imagePath, removeErr))
+		}
+	}()
+
+	reservationID := checkpointReservationID(req.ID)
+	vmPath := bundle.VMBundleDir(req.ID).CheckpointPath()
+	err = s.containerStubHandler.reserveJailFile(requestCtx, reservationID,
+		filepath.Base(imagePath), vmPath, checkpointFilesystemType, s.driveMountClient, s.machine)
+	if errors.Is(err, ErrDrivesExhausted) {
+		return status.Errorf(codes.ResourceExhausted, "no spare drive to checkpoint task %q to", req.ID)
+	} else if err != nil {
+		return fmt.Errorf("failed to mount checkpoint drive: %w", err)
+	}
+
+	agent, err := s.agent()
+	if err == nil {
+		_, err = agent.Checkpoint(requestCtx, &taskAPI.CheckpointTaskRequest{
+			ID:      req.ID,
+			Path:    filepath.Join(vmPath, checkpointImageDir),
+			Options: req.Options,
+		})
+	}
+
+	// The drive is unmounted in the VM before the image is read on the host, so the
+	// checkpoint is entirely written to the image.
+	if releaseErr := s.containerStubHandler.Release(requestCtx, reservationID, s.driveMountClient, s.machine); releaseErr != nil {
+		err = multierror.Append(err, fmt.Errorf("failed to release checkpoint drive: %w", releaseErr))
+	}
+	if err != nil {
+		return err
+	}
+
+	return copyCheckpointImage(requestCtx, imagePath, checkpointImageDir, req.Path)
+}
+
+// reserveRestoreDrive exposes the checkpoint in checkpointDir on the host to the VM as a drive
+// and returns the path of the checkpoint in the VM. The drive is released with releaseRestoreDrive.
+func (s *service) reserveRestoreDrive(requestCtx context.Context, taskID, checkpointDir string) (string, error) {
+	checkpointSize, err := dirSize(checkpointDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get size of checkpoint %q: %w", checkpointDir, err)
+	}
+
+	imagePath, err := s.createCheckpointImage(requestCtx, checkpointSize+checkpointImageOverhead, checkpointDir)
+	if err != nil {
+		return "", err
+	}
+
+	vmPath := bundle.VMBundleDir(taskID).RestorePath()
+	err = s.containerStubHandler.reserveJailFile(requestCtx, restoreReservationID(taskID),
+		filepath.Base(imagePath), vmPath, checkpointFilesystemType, s.driveMountClient, s.machine)
+	if err != nil {
+		if removeErr := os.Remove(imagePath); removeErr != nil {
+			s.log().WithError(removeErr).Errorf("failed to remove checkpoint image %q", imagePath)
+		}
+		if errors.Is(err, ErrDrivesExhausted) {
+			return "", status.Errorf(codes.ResourceExhausted, "no spare drive to restore task %q from", taskID)
+		}
+		return "", fmt.Errorf("failed to mount checkpoint drive: %w", err)
+	}
+
+	return vmPath, nil
+}
+
+// releaseRestoreDrive releases the drive a task was restored from, if it still holds one.
+// runc restores a checkpoint when the task starts, so the drive is no longer used afterwards.
+func (s *service) releaseRestoreDrive(requestCtx context.Context, taskID string) error {
+	reservationID := restoreReservationID(taskID)
+	driveMount, ok := s.containerStubHandler.reservedDrive(reservationID)
+	if !ok {
+		return nil
+	}
+
+	err := s.containerStubHandler.Release(requestCtx, reservationID, s.driveMountClient, s.machine)
+	if err != nil {
+		return fmt.Errorf("failed to release checkpoint drive: %w", err)
+	}
+
+	return os.Remove(filepath.Join(s.jailer.JailPath().RootPath(), driveMount.HostPath))
+}
+
+// createCheckpointImage creates a sparse filesystem image of the given size in the root of the
+// jail, filled with the contents of contentDir unless it is empty, and returns its path.
+func (s *service) createCheckpointImage(ctx context.Context, size int64, contentDir string) (_ string, err error) {
+	f, err := os.CreateTemp(s.jailer.JailPath().RootPath(), "checkpoint*.img")
+	if err != nil {
+		return "", fmt.Errorf("failed to create checkpoint image: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	if err := f.Truncate(size); err != nil {
+		return "", fmt.Errorf("failed to resize checkpoint image: %w", err)
+	}
+
+	args := []string{"-F", "-q"}
+	if contentDir != "" {
+		args = append(args, "-d", contentDir)
+	}
+	args = append(args, f.Name())
+	if out, err := exec.CommandContext(ctx, "mkfs."+checkpointFilesystemType, args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to execute mkfs.%s: %s: %w", checkpointFilesystemType, out, err)
+	}
+
+	for _, opt := range s.jailer.StubDrivesOptions() {
+		if err := opt(f); err != nil {
+			return "", err
+		}
+	}
+
+	return f.Name(), nil
+}
+
+// copyCheckpointImage copies the directory dir of a checkpoint image to dst. The image is written
+// by the VM, so rather than being mounted, which would have the host kernel parse a filesystem
+// crafted by the guest, it is read in userspace with debugfs. Only the regular files and
+// directories a checkpoint is made of are copied, any other file fails the copy. The guest could
+// also make the copy far larger than the image, with sparse files of a huge size or many links
+// to the same file, so the copy fails once it outgrows the space allocated to the image.
+func copyCheckpointImage(ctx context.Context, imagePath, dir, dst string) error {
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("failed to get allocated size of checkpoint image %q", imagePath)
+	}
+	budget := &checkpointCopyBudget{bytes: stat.Blocks * 512}
+
+	entries, err := listCheckpointImageDir(ctx, imagePath, dir)
+	if err != nil {
+		return err
+	}
+
+	var root *checkpointImageEntry
+	for i := range entries {
+		if entries[i].name == "." {
+			root = &entries[i]
+		}
+	}
+	if root == nil {
+		return fmt.Errorf("checkpoint image %q has no directory %q", imagePath, dir)
+	}
+
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+	return copyCheckpointImageDir(ctx, imagePath, root.inode, dst, map[uint64]bool{root.inode: true}, budget)
+}
+
+// checkpointCopyBudget is what remains of the allocated size of a checkpoint image while it is
+// copied. Both the contents of the files copied and an inode per file are taken from it.
+type checkpointCopyBudget struct {
+	bytes int64
+}
+
+func (b *checkpointCopyBudget) take(n int64) error {
+	b.bytes -= n
+	if b.bytes < 0 {
+		return errCheckpointImageExpanded
+	}
+	return nil
+}
+
+// checkpointCopyWriter writes the contents of a file copied out of a checkpoint image, taking
+// them from the budget of the copy.
+type checkpointCopyWriter struct {
+	w      io.Writer
+	budget *checkpointCopyBudget
+}
+
+func (w *checkpointCopyWriter) Write(p []byte) (int, error) {
+	if err := w.budget.take(int64(len(p))); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
+
+// checkpointImageEntry is an entry of a directory of a checkpoint image.
+type checkpointImageEntry struct {
+	inode uint64
+	mode  os.FileMode
+	name  string
+}
+
+func copyCheckpointImageDir(ctx context.Context, imagePath string, inode uint64, dst string, visited map[uint64]bool, budget *checkpointCopyBudget) error {
+	entries, err := listCheckpointImageDir(ctx, imagePath, fmt.Sprintf("<%d>", inode))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.name == "." || entry.name == ".." {
+			continue
+		}
+		path := filepath.Join(dst, entry.name)
+		if err := budget.take(checkpointImageInodeSize); err != nil {
+			return fmt.Errorf("failed to copy %q out of checkpoint image %q: %w", path, imagePath, err)
+		}
+
+		switch {
+		case entry.mode.IsDir():
+			// a corrupted image could link a directory to one of its ancestors
+			if visited[entry.inode] {
+				return fmt.Errorf("checkpoint image %q has a directory loop at %q", imagePath, path)
+			}
+			visited[entry.inode] = true
+
+			if err := os.Mkdir(path, entry.mode.Perm()|0700); err != nil {
+				return err
+			}
+			if err := copyCheckpointImageDir(ctx, imagePath, entry.inode, path, visited, budget); err != nil {
+				return err
+			}
+		case entry.mode.IsRegular():
+			if err := copyCheckpointImageFile(ctx, imagePath, entry.inode, path, entry.mode.Perm(), budget); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("checkpoint image %q has unexpected file %q of mode %s", imagePath, path, entry.mode)
+		}
+	}
+
+	return nil
+}
+
+func copyCheckpointImageFile(ctx context.Context, imagePath string, inode uint64, dst string, perm os.FileMode, budget *checkpointCopyBudget) (err error) {
+	// the file is created by the shim, so the copy cannot be redirected by what is already in dst
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL|syscall.O_NOFOLLOW, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+	}()
+
+	err = debugfs(ctx, imagePath, fmt.Sprintf("cat <%d>", inode), &checkpointCopyWriter{w: f, budget: budget})
+	if budget.bytes < 0 {
+		// debugfs is stopped by the failed write rather than reporting it
+		return fmt.Errorf("failed to copy %q out of checkpoint image %q: %w", dst, imagePath, errCheckpointImageExpanded)
+	}
+	return err
+}
+
+// listCheckpointImageDir lists the entries of the directory at path in a checkpoint image, which
+// may be an inode number in debugfs's <inode> syntax.
+func listCheckpointImageDir(ctx context.Context, imagePath, path string) ([]checkpointImageEntry, error) {
+	var out bytes.Buffer
+	if err := debugfs(ctx, imagePath, "ls -p "+path, &out); err != nil {
+		return nil, err
+	}
+
+	var entries []checkpointImageEntry
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line == "" {
+			continue
+		}
+
+		// entries are listed as /inode/mode/uid/gid/name/size/
+		fields := strings.SplitN(strings.TrimSuffix(strings.TrimPrefix(line, "/"), "/"), "/", 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected debugfs output listing %q: %q", path, line)
+		}
+		name := fields[4][:max(strings.LastIndex(fields[4], "/"), 0)]
+		if name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid file name in checkpoint image: %q", line)
+		}
+
+		inode, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected debugfs output listing %q: %q: %w", path, line, err)
+		}
+		mode, err := strconv.ParseUint(fields[1], 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected debugfs output listing %q: %q: %w", path, line, err)
+		}
+
+		entries = append(entries, checkpointImageEntry{inode: inode, mode: fileMode(uint32(mode)), name: name})
+	}
+
+	return entries, nil
+}
+
+// fileMode converts the mode of a file as stored by Linux to an os.FileMode.
+func fileMode(mode uint32) os.FileMode {
+	fm := os.FileMode(mode & 0777)
+	switch mode & syscall.S_IFMT {
+	case syscall.S_IFREG:
+	case syscall.S_IFDIR:
+		fm |= os.ModeDir
+	case syscall.S_IFLNK:
+		fm |= os.ModeSymlink
+	default:
+		fm |= os.ModeIrregular
+	}
+	return fm
+}
+
+// debugfs runs a debugfs request against the filesystem image at imagePath, which is opened
+// read-only, and writes its output to stdout.
+func debugfs(ctx context.Context, imagePath, request string, stdout io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "debugfs", "-R", request, imagePath)
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to execute debugfs %q: %s: %w", request, stderr.String(), err)
+	}
+
+	// debugfs reports the errors of requests on stderr, after its version, but still succeeds
+	for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+		if line != "" && !strings.HasPrefix(line, "debugfs ") {
+			return fmt.Errorf("debugfs %q failed on %q: %s", request, imagePath, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	return nil
+}
+
+// dirSize returns the total size of the regular files in a directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
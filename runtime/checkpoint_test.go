@@ -0,0 +1,163 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pages-1.img"), make([]byte, 4096), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "inventory.img"), make([]byte, 100), 0600))
+	require.NoError(t, os.Symlink("pages-1.img", filepath.Join(dir, "link")))
+
+	size, err := dirSize(dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4196), size)
+
+	_, err = dirSize(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestCheckpointReservationIDs(t *testing.T) {
+	ids := map[string]bool{
+		"task":                             true,
+		checkpointReservationID("task"):    true,
+		restoreReservationID("task"):       true,
+		attachedDriveReservationID("task"): true,
+	}
+	assert.Len(t, ids, 4, "reservations of a task's drives should not collide")
+}
+
+// createTestCheckpointImage creates an ext4 image with the contents of dir, like the VM does when
+// runc writes a checkpoint to a scratch drive.
+func createTestCheckpointImage(t *testing.T, dir string) string {
+	for _, tool := range []string{"mkfs." + checkpointFilesystemType, "debugfs"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+
+	imagePath := filepath.Join(t.TempDir(), "checkpoint.img")
+	require.NoError(t, os.WriteFile(imagePath, nil, 0600))
+	require.NoError(t, os.Truncate(imagePath, checkpointImageOverhead))
+	out, err := exec.Command("mkfs."+checkpointFilesystemType, "-F", "-q", "-d", dir, imagePath).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return imagePath
+}
+
+func TestCopyCheckpointImage(t *testing.T) {
+	ctx := context.Background()
+
+	src := t.TempDir()
+	imageDir := filepath.Join(src, checkpointImageDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(imageDir, "sub dir"), 0700))
+	pages := make([]byte, 3*4096+5)
+	for i := range pages {
+		pages[i] = byte(i)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(imageDir, "pages-1.img"), pages, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(imageDir, "sub dir", "inventory.img"), []byte("inventory"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(imageDir, "empty.img"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "outside.img"), []byte("outside"), 0600))
+
+	dst := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, copyCheckpointImage(ctx, createTestCheckpointImage(t, src), checkpointImageDir, dst))
+
+	contents, err := os.ReadFile(filepath.Join(dst, "pages-1.img"))
+	require.NoError(t, err)
+	assert.Equal(t, pages, contents)
+	contents, err = os.ReadFile(filepath.Join(dst, "sub dir", "inventory.img"))
+	require.NoError(t, err)
+	assert.Equal(t, "inventory", string(contents))
+	assert.FileExists(t, filepath.Join(dst, "empty.img"))
+	assert.NoFileExists(t, filepath.Join(dst, "outside.img"), "only the checkpoint directory should be copied")
+
+	size, err := dirSize(dst)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(pages)+len("inventory")), size)
+}
+
+// editTestCheckpointImage runs debugfs requests writing to a checkpoint image, like a guest
+// crafting the image could.
+func editTestCheckpointImage(t *testing.T, imagePath string, requests ...string) {
+	cmdFile := filepath.Join(t.TempDir(), "requests")
+	require.NoError(t, os.WriteFile(cmdFile, []byte(strings.Join(requests, "\n")+"\n"), 0600))
+	out, err := exec.Command("debugfs", "-w", "-f", cmdFile, imagePath).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestCopyCheckpointImageBoundsSize(t *testing.T) {
+	src := t.TempDir()
+	imageDir := filepath.Join(src, checkpointImageDir)
+	require.NoError(t, os.MkdirAll(imageDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(imageDir, "pages-1.img"), bytes.Repeat([]byte{1}, 1024*1024), 0600))
+
+	for name, requests := range map[string][]string{
+		"sparse file": {"sif image/pages-1.img size 1073741824"},
+		"hard links": func() []string {
+			var links []string
+			for i := 0; i < 64; i++ {
+				links = append(links, fmt.Sprintf("ln image/pages-1.img image/link-%d", i))
+			}
+			return links
+		}(),
+	} {
+		requests := requests
+		t.Run(name, func(t *testing.T) {
+			imagePath := createTestCheckpointImage(t, src)
+			editTestCheckpointImage(t, imagePath, requests...)
+			info, err := os.Stat(imagePath)
+			require.NoError(t, err)
+			allocated := info.Sys().(*syscall.Stat_t).Blocks * 512
+
+			dst := filepath.Join(t.TempDir(), "checkpoint")
+			err = copyCheckpointImage(context.Background(), imagePath, checkpointImageDir, dst)
+			assert.ErrorIs(t, err, errCheckpointImageExpanded)
+
+			size, err := dirSize(dst)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, size, allocated)
+		})
+	}
+}
+
+func TestCopyCheckpointImageRejectsSymlinks(t *testing.T) {
+	src := t.TempDir()
+	imageDir := filepath.Join(src, checkpointImageDir)
+	require.NoError(t, os.MkdirAll(imageDir, 0700))
+	require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(imageDir, "pages-1.img")))
+
+	dst := filepath.Join(t.TempDir(), "checkpoint")
+	err := copyCheckpointImage(context.Background(), createTestCheckpointImage(t, src), checkpointImageDir, dst)
+	assert.ErrorContains(t, err, "unexpected file")
+	assert.NoFileExists(t, filepath.Join(dst, "pages-1.img"))
+
+	err = copyCheckpointImage(context.Background(), createTestCheckpointImage(t, src), "missing", dst)
+	assert.Error(t, err)
+}
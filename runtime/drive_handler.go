@@ -22,11 +22,13 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
 	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
 
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
 )
@@ -40,45 +42,71 @@ var (
 	// ErrDrivesExhausted occurs when there are no more drives left to use. This
 	// can happen by calling PatchStubDrive greater than the number of drives.
 	ErrDrivesExhausted = fmt.Errorf("There are no remaining drives to be used")
+
+	// ErrDrivesInUse occurs when a snapshot is requested while some of the drives
+	// are reserved by containers.
+	ErrDrivesInUse = fmt.Errorf("drives are in use by containers")
+
+	// ErrDriveReserved occurs when a drive is reserved with the ID of a drive which is
+	// still reserved.
+	ErrDriveReserved = fmt.Errorf("drive already reserved")
 )
 
 // CreateContainerStubs will create a StubDriveHandler for managing the stub drives
-// of container rootfs drives. The Firecracker drives are hardcoded to be read-write
-// and have no rate limiter configuration.
+// of container rootfs drives. Firecracker cannot attach drives to a running VM, so a
+// drive is attached for each of the maxCount containers the VM can run, but only
+// minCount of them are backed by stub drives at boot. The others are backed by sparse
+// placeholder files until Reserve runs out of stub drives. The Firecracker drives are
+// hardcoded to be read-write and have no rate limiter configuration.
 func CreateContainerStubs(
 	machineCfg *firecracker.Config,
 	jail jailer,
-	containerCount int,
+	minCount int,
+	maxCount int,
 	logger *logrus.Entry,
 ) (*StubDriveHandler, error) {
-	var containerStubs []*stubDrive
-	for i := 0; i < containerCount; i++ {
+	if maxCount < minCount {
+		maxCount = minCount
+	}
+
+	handler := &StubDriveHandler{
+		usedDrives:    make(map[string]*stubDrive),
+		privateCopies: make(map[string]string),
+		jail:          jail,
+		logger:        logger,
+	}
+
+	for i := 0; i < maxCount; i++ {
 		isWritable := true
 		var rateLimiter *proto.FirecrackerRateLimiter
-		stubFileName := fmt.Sprintf("ctrstub%d", i)
+		stubPath := filepath.Join(jail.JailPath().RootPath(), fmt.Sprintf("ctrstub%d", i))
+		pathOnHost := stubPath
 
-		stubDrive, err := newStubDrive(
-			filepath.Join(jail.JailPath().RootPath(), stubFileName),
-			jail, isWritable, rateLimiter, logger)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to create container stub drive: %w", err)
+		if i < minCount {
+			stubDrive, err := newStubDrive(stubPath, jail, isWritable, rateLimiter, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create container stub drive: %w", err)
+			}
+			handler.freeDrives = append(handler.freeDrives, stubDrive)
+		} else {
+			slot, err := newStubDriveSlot(stubPath, jail)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create container drive placeholder: %w", err)
+			}
+			handler.slots = append(handler.slots, slot)
+			pathOnHost = slot.placeholderPath
 		}
 
 		machineCfg.Drives = append(machineCfg.Drives, models.Drive{
-			DriveID:      firecracker.String(stubDrive.driveID),
-			PathOnHost:   firecracker.String(stubDrive.stubPath),
+			DriveID:      firecracker.String(stubPathToDriveID(stubPath)),
+			PathOnHost:   firecracker.String(pathOnHost),
 			IsReadOnly:   firecracker.Bool(!isWritable),
 			RateLimiter:  rateLimiterFromProto(rateLimiter),
 			IsRootDevice: firecracker.Bool(false),
 		})
-		containerStubs = append(containerStubs, stubDrive)
 	}
 
-	return &StubDriveHandler{
-		freeDrives: containerStubs,
-		usedDrives: make(map[string]*stubDrive),
-	}, nil
+	return handler, nil
 }
 
 // StubDriveHandler manages a set of stub drives. It currently only supports reserving
@@ -89,7 +117,15 @@ type StubDriveHandler struct {
 	freeDrives []*stubDrive
 	// map of id -> stub drive being used by that task
 	usedDrives map[string]*stubDrive
-	mu         sync.Mutex
+	// slots are the drives backed by placeholder files, which are turned into stub drives
+	// once all the free drives have been reserved
+	slots  []stubDriveSlot
+	jail   jailer
+	logger *logrus.Entry
+	mu     sync.Mutex
+
+	// map of id -> path of the private copy of the file backing the drive reserved with that id
+	privateCopies map[string]string
 }
 
 // Reserve pops a unused stub drive and returns a MountableStubDrive that can be
@@ -104,18 +140,125 @@ func (h *StubDriveHandler) Reserve(
 	driveMounter drivemount.DriveMounterService,
 	machine firecracker.MachineIface,
 ) error {
+	return h.reserveDrive(requestCtx, id, hostPath, vmPath, filesystemType, options, false, driveMounter, machine,
+		func(requestCtx context.Context, drive stubDrive) error {
+			return drive.PatchAndMount(requestCtx, machine, driveMounter)
+		})
+}
+
+// reserveJailFile reserves a stub drive for a file the shim created in the root of the jail, such
+// as a checkpoint image. Firecracker can already open the file, so it is patched in by its name
+// rather than exposed to the jail.
+func (h *StubDriveHandler) reserveJailFile(
+	requestCtx context.Context,
+	id string,
+	fileName string,
+	vmPath string,
+	filesystemType string,
+	driveMounter drivemount.DriveMounterService,
+	machine firecracker.MachineIface,
+) error {
+	return h.reserveDrive(requestCtx, id, fileName, vmPath, filesystemType, nil, false, driveMounter, machine,
+		func(requestCtx context.Context, drive stubDrive) error {
+			return drive.patchAndMount(requestCtx, machine, driveMounter)
+		})
+}
+
+// reservePrivateCopy reserves a stub drive for a private copy of the file at hostPath, made in the
+// root of the jail as copyName, and mounts it read-only. Stub drives are writable and the guest may
+// remount a read-only mount read-write, so files the VM must not write to, such as layers shared by
+// containers, are only exposed to it through private copies. The copy is removed once the drive is
+// released.
+func (h *StubDriveHandler) reservePrivateCopy(
+	requestCtx context.Context,
+	id string,
+	hostPath string,
+	copyName string,
+	vmPath string,
+	filesystemType string,
+	driveMounter drivemount.DriveMounterService,
+	machine firecracker.MachineIface,
+) error {
+	copyPath := filepath.Join(h.jail.JailPath().RootPath(), copyName)
+	if err := copyPrivateFile(hostPath, copyPath, h.jail.StubDrivesOptions()); err != nil {
+		return fmt.Errorf("failed to copy %q: %w", hostPath, err)
+	}
+
+	err := h.reserveDrive(requestCtx, id, copyName, vmPath, filesystemType, nil, true, driveMounter, machine,
+		func(requestCtx context.Context, drive stubDrive) error {
+			return drive.patchAndMount(requestCtx, machine, driveMounter)
+		})
+	if err != nil {
+		if removeErr := os.Remove(copyPath); removeErr != nil {
+			h.logger.WithError(removeErr).Errorf("failed to remove private copy %q", copyPath)
+		}
+		return err
+	}
+
 	h.mu.Lock()
-	defer h.mu.Unlock()
-	if len(h.freeDrives) == 0 {
-		return ErrDrivesExhausted
+	h.privateCopies[id] = copyPath
+	h.mu.Unlock()
+	return nil
+}
+
+// copyPrivateFile copies src to a new file dst, cloning it on filesystems supporting reflinks,
+// and applies opts to the copy.
+func copyPrivateFile(src, dst string, opts []FileOpt) (err error) {
+	if reflinkErr := reflinkFile(src, dst, 0600); reflinkErr != nil {
+		if err := copyFile(src, dst, 0600); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(dst)
+		}
+	}()
+
+	f, err := os.OpenFile(dst, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, opt := range opts {
+		if err := opt(f); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// reserveDrive pops a unused stub drive and mounts it with mountDrive.
+func (h *StubDriveHandler) reserveDrive(
+	requestCtx context.Context,
+	id string,
+	hostPath string,
+	vmPath string,
+	filesystemType string,
+	options []string,
+	readOnly bool,
+	driveMounter drivemount.DriveMounterService,
+	machine firecracker.MachineIface,
+	mountDrive func(context.Context, stubDrive) error,
+) (err error) {
+	requestCtx, span := tracing.StartSpan(requestCtx, "Reserve", attribute.String("id", id))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	if _, ok := h.usedDrives[id]; ok {
 		// This case means that drive wasn't released or removed properly
-		return fmt.Errorf("drive with ID %s already in use, a previous attempt to remove it may have failed", id)
+		return fmt.Errorf("%w: drive with ID %s already in use, a previous attempt to remove it may have failed", ErrDriveReserved, id)
+	}
+	if len(h.freeDrives) == 0 {
+		if err := h.provision(requestCtx, driveMounter, machine); err != nil {
+			return err
+		}
 	}
 
 	freeDrive := h.freeDrives[0]
-	options, err := setReadWriteOptions(options, freeDrive.driveMount.IsWritable)
+	options, err = setReadWriteOptions(options, freeDrive.driveMount.IsWritable && !readOnly)
 	if err != nil {
 		return err
 	}
@@ -128,7 +271,7 @@ func (h *StubDriveHandler) Reserve(
 	)
 	freeDrive = &stubDrive
 
-	err = stubDrive.PatchAndMount(requestCtx, machine, driveMounter)
+	err = mountDrive(requestCtx, stubDrive)
 	if err != nil {
 		err = fmt.Errorf("failed to mount drive inside vm: %w", err)
 		return err
@@ -136,6 +279,8 @@ func (h *StubDriveHandler) Reserve(
 
 	h.freeDrives = h.freeDrives[1:]
 	h.usedDrives[id] = freeDrive
+	stubDriveReservations.Inc()
+	stubDrivesReserved.Inc()
 	return nil
 }
 
@@ -168,12 +313,121 @@ func (h *StubDriveHandler) Release(
 
 	delete(h.usedDrives, id)
 	h.freeDrives = append(h.freeDrives, drive)
+	stubDrivesReserved.Dec()
+
+	if copyPath, ok := h.privateCopies[id]; ok {
+		delete(h.privateCopies, id)
+		if err := os.Remove(copyPath); err != nil {
+			return fmt.Errorf("failed to remove private copy of drive: %w", err)
+		}
+	}
+	return nil
+}
+
+// reservedDrive returns the mount configuration of the drive reserved with the provided id.
+func (h *StubDriveHandler) reservedDrive(id string) (*proto.FirecrackerDriveMount, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	drive, ok := h.usedDrives[id]
+	if !ok {
+		return nil, false
+	}
+	return drive.driveMount, true
+}
+
+// provision turns the first drive backed by a placeholder file into a stub drive and has
+// the agent discover it. h.mu must be held.
+func (h *StubDriveHandler) provision(
+	requestCtx context.Context,
+	driveMounter drivemount.DriveMounterService,
+	machine firecracker.MachineIface,
+) error {
+	if len(h.slots) == 0 {
+		return ErrDrivesExhausted
+	}
+
+	slot := h.slots[0]
+	drive, err := newStubDrive(slot.stubPath, h.jail, true, nil, h.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create container stub drive: %w", err)
+	}
+
+	err = machine.UpdateGuestDrive(requestCtx, drive.driveID, filepath.Base(drive.stubPath))
+	if err == nil {
+		_, err = driveMounter.DiscoverDrives(requestCtx, &drivemount.DiscoverDrivesRequest{})
+	}
+	if err != nil {
+		if removeErr := os.Remove(drive.stubPath); removeErr != nil {
+			h.logger.WithError(removeErr).Errorf("failed to remove %q", drive.stubPath)
+		}
+		return fmt.Errorf("failed to provision container stub drive: %w", err)
+	}
+
+	h.slots = h.slots[1:]
+	h.freeDrives = append(h.freeDrives, drive)
 	return nil
 }
 
+// prepareSnapshot patches each of the stub drives back to its path relative to the
+// jail and returns them. Firecracker records the path of every drive in its snapshots,
+// so relative paths let a snapshot be loaded from a different jail. Snapshots cannot
+// be taken while drives are reserved since the containers using them are not restored
+// along with the VM.
+func (h *StubDriveHandler) prepareSnapshot(
+	requestCtx context.Context,
+	machine firecracker.MachineIface,
+) ([]stubDrive, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.usedDrives) > 0 {
+		return nil, ErrDrivesInUse
+	}
+
+	var drives []stubDrive
+	for _, drive := range h.freeDrives {
+		err := machine.UpdateGuestDrive(requestCtx, drive.driveID, filepath.Base(drive.stubPath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to patch drive: %w", err)
+		}
+		drives = append(drives, *drive)
+	}
+
+	for _, slot := range h.slots {
+		err := machine.UpdateGuestDrive(requestCtx, slot.driveID, filepath.Base(slot.placeholderPath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to patch drive: %w", err)
+		}
+	}
+
+	return drives, nil
+}
+
+// driveCounts returns the number of stub drives and the total number of drives, including
+// the ones backed by placeholder files.
+func (h *StubDriveHandler) driveCounts() (int, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stubs := len(h.freeDrives) + len(h.usedDrives)
+	return stubs, stubs + len(h.slots)
+}
+
+// freeStubDrives returns the stub drives which have not been reserved.
+func (h *StubDriveHandler) freeStubDrives() []stubDrive {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var drives []stubDrive
+	for _, drive := range h.freeDrives {
+		drives = append(drives, *drive)
+	}
+	return drives
+}
+
 // CreateDriveMountStubs creates a set of MountableStubDrives from the provided DriveMount configs.
-// The RateLimiter and ReadOnly settings need to be provided up front here as they currently
-// cannot be patched after the Firecracker VM starts.
+// The ReadOnly setting needs to be provided up front here as it cannot be patched after the
+// Firecracker VM starts. The RateLimiter can be replaced later with UpdateRateLimiters.
 func CreateDriveMountStubs(
 	machineCfg *firecracker.Config,
 	jail jailer,
@@ -337,6 +591,41 @@ type stubDrive struct {
 	driveMount *proto.FirecrackerDriveMount
 }
 
+// stubDriveSlot is a container drive backed by a placeholder file. The placeholder is not a
+// stub drive, so the agent does not discover it until the drive is patched to stubPath.
+type stubDriveSlot struct {
+	stubPath        string
+	placeholderPath string
+	driveID         string
+}
+
+func newStubDriveSlot(stubPath string, jail jailer) (stubDriveSlot, error) {
+	placeholderPath := stubPath + "-placeholder"
+
+	f, err := os.OpenFile(placeholderPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return stubDriveSlot{}, err
+	}
+	defer f.Close()
+
+	// Firecracker will not show any drives smaller than 512 bytes
+	if err := f.Truncate(fcSectorSize); err != nil {
+		return stubDriveSlot{}, err
+	}
+
+	for _, opt := range jail.StubDrivesOptions() {
+		if err := opt(f); err != nil {
+			return stubDriveSlot{}, err
+		}
+	}
+
+	return stubDriveSlot{
+		stubPath:        stubPath,
+		placeholderPath: placeholderPath,
+		driveID:         stubPathToDriveID(stubPath),
+	}, nil
+}
+
 func (sd stubDrive) withMountConfig(
 	hostPath string,
 	vmPath string,
@@ -359,13 +648,26 @@ func (sd stubDrive) PatchAndMount(
 	requestCtx context.Context,
 	machine firecracker.MachineIface,
 	driveMounter drivemount.DriveMounterService,
-) error {
-	err := sd.jail.ExposeFileToJail(sd.driveMount.HostPath)
+) (err error) {
+	requestCtx, span := tracing.StartSpan(requestCtx, "PatchAndMount", attribute.String("drive_id", sd.driveID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	err = sd.jail.ExposeFileToJail(sd.driveMount.HostPath)
 	if err != nil {
 		return fmt.Errorf("failed to expose patched drive contents to jail: %w", err)
 	}
 
-	err = machine.UpdateGuestDrive(requestCtx, sd.driveID, sd.driveMount.HostPath)
+	return sd.patchAndMount(requestCtx, machine, driveMounter)
+}
+
+// patchAndMount patches the drive to its host path, which must already be visible to
+// Firecracker, and mounts it inside the VM.
+func (sd stubDrive) patchAndMount(
+	requestCtx context.Context,
+	machine firecracker.MachineIface,
+	driveMounter drivemount.DriveMounterService,
+) error {
+	err := machine.UpdateGuestDrive(requestCtx, sd.driveID, sd.driveMount.HostPath)
 	if err != nil {
 		return fmt.Errorf("failed to patch drive: %w", err)
 	}
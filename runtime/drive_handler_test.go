@@ -15,6 +15,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -22,6 +23,8 @@ import (
 
 	"github.com/containerd/containerd/protobuf/types"
 	"github.com/containerd/log"
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/bundle"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
@@ -71,7 +74,7 @@ func TestContainerStubs(t *testing.T) {
 	containerCount := 8
 	machineCfg := &firecracker.Config{}
 
-	stubDriveHandler, err := CreateContainerStubs(machineCfg, noopJailer, containerCount, logger)
+	stubDriveHandler, err := CreateContainerStubs(machineCfg, noopJailer, containerCount, containerCount, logger)
 	require.NoError(t, err, "failed to create stub drive handler")
 	dirents, err := os.ReadDir(stubDir)
 	require.NoError(t, err, "failed to read stub drive dir")
@@ -119,6 +122,238 @@ func TestContainerStubs(t *testing.T) {
 	}
 }
 
+type discoveringDriveMounter struct {
+	drivemount.DriveMounterService
+
+	discoverCalls int
+	mounts        []*drivemount.MountDriveRequest
+}
+
+func (m *discoveringDriveMounter) MountDrive(_ context.Context, req *drivemount.MountDriveRequest) (*types.Empty, error) {
+	m.mounts = append(m.mounts, req)
+	return &types.Empty{}, nil
+}
+
+func (m *discoveringDriveMounter) UnmountDrive(context.Context, *drivemount.UnmountDriveRequest) (*types.Empty, error) {
+	return &types.Empty{}, nil
+}
+
+func (m *discoveringDriveMounter) DiscoverDrives(context.Context, *drivemount.DiscoverDrivesRequest) (*types.Empty, error) {
+	m.discoverCalls++
+	return &types.Empty{}, nil
+}
+
+func TestContainerStubsProvisioning(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	stubDir := t.TempDir()
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(stubDir),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	minCount, maxCount := 1, 3
+	machineCfg := &firecracker.Config{}
+	stubDriveHandler, err := CreateContainerStubs(machineCfg, noopJailer, minCount, maxCount, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	// every drive is attached at boot, but only minCount of them are stub drives
+	require.Len(t, machineCfg.Drives, maxCount)
+	stubCount, driveCount := stubDriveHandler.driveCounts()
+	assert.Equal(t, minCount, stubCount)
+	assert.Equal(t, maxCount, driveCount)
+
+	slot := stubDriveHandler.slots[0]
+	assert.Equal(t, slot.driveID, firecracker.StringValue(machineCfg.Drives[minCount].DriveID))
+	assert.Equal(t, slot.placeholderPath, firecracker.StringValue(machineCfg.Drives[minCount].PathOnHost))
+	assert.FileExists(t, slot.placeholderPath)
+	assert.NoFileExists(t, slot.stubPath)
+
+	var patchedPaths []string
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				patchedPaths = append(patchedPaths, params.Body.PathOnHost)
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	driveMounter := &discoveringDriveMounter{}
+	for i := 0; i < maxCount; i++ {
+		id := strconv.Itoa(i)
+		err := stubDriveHandler.Reserve(ctx, id, "/host/"+id, "/vm/"+id, "ext4", nil, driveMounter, mockMachine)
+		require.NoError(t, err, "failed to reserve stub drive")
+	}
+
+	// the drives beyond minCount are patched to their new stub drive before being patched to the container's
+	assert.Equal(t, maxCount-minCount, driveMounter.discoverCalls)
+	assert.Equal(t, []string{"/host/0", "ctrstub1", "/host/1", "ctrstub2", "/host/2"}, patchedPaths)
+	assert.FileExists(t, slot.stubPath)
+
+	stubCount, driveCount = stubDriveHandler.driveCounts()
+	assert.Equal(t, maxCount, stubCount)
+	assert.Equal(t, maxCount, driveCount)
+
+	err = stubDriveHandler.Reserve(ctx, "extra", "/host/extra", "/vm/extra", "ext4", nil, driveMounter, mockMachine)
+	assert.ErrorIs(t, err, ErrDrivesExhausted)
+}
+
+func TestAttachedDriveReservation(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(t.TempDir()),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, noopJailer, 1, 1, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	mockDriveMounter := &MockDriveMounter{
+		t:                       t,
+		expectedDestinationPath: "/data",
+		expectedFilesystemType:  "ext4",
+		expectedOptions:         []string{"rw"},
+	}
+
+	// drives must be mounted read-write
+	err = stubDriveHandler.Reserve(ctx, "container", "/host/rootfs.img", "/rootfs", "ext4", []string{"ro"}, mockDriveMounter, mockMachine)
+	assert.Error(t, err)
+
+	id := attachedDriveReservationID("data")
+	err = stubDriveHandler.Reserve(ctx, id, "/host/data.img", "/data", "ext4", nil, mockDriveMounter, mockMachine)
+	require.NoError(t, err)
+
+	err = stubDriveHandler.Reserve(ctx, id, "/host/data.img", "/data", "ext4", nil, mockDriveMounter, mockMachine)
+	assert.ErrorIs(t, err, ErrDriveReserved)
+
+	driveMount, ok := stubDriveHandler.reservedDrive(id)
+	require.True(t, ok)
+	assert.Equal(t, "/host/data.img", driveMount.HostPath)
+	assert.True(t, driveMount.IsWritable)
+
+	_, ok = stubDriveHandler.reservedDrive(attachedDriveReservationID("other"))
+	assert.False(t, ok)
+}
+
+func TestReserveOverlayDrives(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	shimDir := vm.Dir(t.TempDir())
+	noopJailer := &noopJailer{
+		shimDir: shimDir,
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, noopJailer, 3, 3, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	driveMounter := &discoveringDriveMounter{}
+	s := &service{
+		logger:               logger,
+		config:               &config.Config{OverlayFilesystemType: "xfs"},
+		containerStubHandler: stubDriveHandler,
+		driveMountClient:     driveMounter,
+		machine:              mockMachine,
+	}
+
+	hostDir := t.TempDir()
+	var layers []string
+	for i := 0; i < 3; i++ {
+		layer := filepath.Join(hostDir, fmt.Sprintf("layer%d.img", i))
+		require.NoError(t, os.WriteFile(layer, []byte("layer"), 0644))
+		layers = append(layers, layer)
+	}
+
+	vmBundleDir := bundle.VMBundleDir("task")
+	driveIDs, vmOverlay, err := s.reserveOverlayDrives(ctx, "task", vmBundleDir, &vm.OverlayMount{
+		LowerDirs: layers[:2],
+		UpperDir:  "/host/upper.img",
+		WorkDir:   "/host/work",
+		Options:   []string{"index=off"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task#layer0", "task#layer1", "task#upper"}, driveIDs)
+	assert.Equal(t, &vm.OverlayMount{
+		LowerDirs: []string{vmBundleDir.LowerLayerPath(0), vmBundleDir.LowerLayerPath(1)},
+		UpperDir:  filepath.Join(vmBundleDir.UpperLayerPath(), "upper"),
+		WorkDir:   filepath.Join(vmBundleDir.UpperLayerPath(), "work"),
+		Options:   []string{"index=off"},
+	}, vmOverlay)
+
+	// the lower layers are private copies in the jail, mounted read-only
+	require.Len(t, driveMounter.mounts, 3)
+	assert.Equal(t, vmBundleDir.LowerLayerPath(0), driveMounter.mounts[0].DestinationPath)
+	assert.Equal(t, []string{"ro"}, driveMounter.mounts[0].Options)
+	assert.Equal(t, "xfs", driveMounter.mounts[0].FilesytemType)
+	assert.Equal(t, vmBundleDir.UpperLayerPath(), driveMounter.mounts[2].DestinationPath)
+	assert.Equal(t, []string{"rw"}, driveMounter.mounts[2].Options)
+	assert.Equal(t, "xfs", driveMounter.mounts[2].FilesytemType)
+
+	driveMount, ok := stubDriveHandler.reservedDrive("task#layer0")
+	require.True(t, ok)
+	assert.Equal(t, "task-layer0.img", driveMount.HostPath, "the shared layer must not be exposed to the VM")
+	content, err := os.ReadFile(filepath.Join(shimDir.RootPath(), "task-layer0.img"))
+	require.NoError(t, err)
+	assert.Equal(t, "layer", string(content))
+
+	for _, driveID := range driveIDs {
+		require.NoError(t, stubDriveHandler.Release(ctx, driveID, driveMounter, mockMachine))
+	}
+	assert.NoFileExists(t, filepath.Join(shimDir.RootPath(), "task-layer0.img"), "the copies should be removed on release")
+	assert.FileExists(t, layers[0])
+
+	// the drives of the layers are released if not every layer can be exposed to the VM
+	_, _, err = s.reserveOverlayDrives(ctx, "task", vmBundleDir, &vm.OverlayMount{
+		LowerDirs: layers,
+		UpperDir:  "/host/upper.img",
+	})
+	assert.ErrorIs(t, err, ErrDrivesExhausted)
+
+	_, ok = stubDriveHandler.reservedDrive("task#layer0")
+	assert.False(t, ok)
+	for i := range layers {
+		assert.NoFileExists(t, filepath.Join(shimDir.RootPath(), fmt.Sprintf("task-layer%d.img", i)))
+	}
+
+	// overlays of directories, such as the ones of the overlayfs snapshotter, are rejected
+	mountCount := len(driveMounter.mounts)
+	layersDir := t.TempDir()
+	for _, dir := range []string{"lower", "upper", "work"} {
+		require.NoError(t, os.Mkdir(filepath.Join(layersDir, dir), 0700))
+	}
+	_, _, err = s.reserveOverlayDrives(ctx, "task", vmBundleDir, &vm.OverlayMount{
+		LowerDirs: []string{filepath.Join(layersDir, "lower")},
+		UpperDir:  filepath.Join(layersDir, "upper"),
+		WorkDir:   filepath.Join(layersDir, "work"),
+	})
+	assert.ErrorContains(t, err, "is a directory")
+	assert.Len(t, driveMounter.mounts, mountCount, "no layer of a rejected overlay may be mounted")
+}
+
 func TestDriveMountStubs(t *testing.T) {
 	ctx := context.Background()
 	logger := log.G(ctx)
@@ -281,3 +516,52 @@ func TestStubPathToDriveID(t *testing.T) {
 			"unexpected invalid characters in drive ID")
 	}
 }
+
+// unexposingJailer is a jailer which cannot expose files to the jail.
+type unexposingJailer struct {
+	*noopJailer
+}
+
+func (j unexposingJailer) ExposeFileToJail(path string) error {
+	return fmt.Errorf("unexpected ExposeFileToJail(%q)", path)
+}
+
+func TestReserveJailFile(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	jail := unexposingJailer{&noopJailer{
+		shimDir: vm.Dir(t.TempDir()),
+		ctx:     ctx,
+		logger:  logger,
+	}}
+
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, jail, 1, 1, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	var patchedPaths []string
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				patchedPaths = append(patchedPaths, params.Body.PathOnHost)
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	driveMounter := &discoveringDriveMounter{}
+	err = stubDriveHandler.reserveJailFile(ctx, "scratch", "scratch.img", "/vm/scratch", "ext4", driveMounter, mockMachine)
+	require.NoError(t, err, "failed to reserve stub drive")
+
+	assert.Equal(t, []string{"scratch.img"}, patchedPaths)
+	require.Len(t, driveMounter.mounts, 1)
+	assert.Equal(t, "/vm/scratch", driveMounter.mounts[0].DestinationPath)
+	assert.Equal(t, []string{"rw"}, driveMounter.mounts[0].Options)
+
+	driveMount, ok := stubDriveHandler.reservedDrive("scratch")
+	require.True(t, ok)
+	assert.Equal(t, "scratch.img", driveMount.HostPath)
+
+	err = stubDriveHandler.Reserve(ctx, "container", "/host/container", "/vm/container", "ext4", nil, driveMounter, mockMachine)
+	assert.ErrorIs(t, err, ErrDrivesExhausted)
+}
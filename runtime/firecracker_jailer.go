@@ -0,0 +1,451 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/containerd/continuity/fs"
+	"github.com/hashicorp/go-multierror"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
+)
+
+const (
+	// firecrackerJailerRootFolder is the folder in which Firecracker's jailer chroots
+	// the VMM, under <ChrootBaseDir>/<exec file name>/<VM ID>.
+	firecrackerJailerRootFolder  = "root"
+	defaultFirecrackerCgroupPath = "firecracker-containerd"
+)
+
+// firecrackerJailer uses Firecracker's own jailer binary to set up a jailed environment
+// for the Firecracker VM.
+type firecrackerJailer struct {
+	ctx    context.Context
+	logger *logrus.Entry
+	Config firecrackerJailerConfig
+	vmID   string
+	pid    int
+
+	// started is set once the jailer started, after which files can no longer be bind mounted
+	// to the jail.
+	started bool
+	// bindMounts are the paths in the jail which files were bind mounted to. They are guarded by
+	// bindMountsMu as they are recorded in the shim's state file.
+	bindMounts   []string
+	bindMountsMu sync.Mutex
+}
+
+type firecrackerJailerConfig struct {
+	// ChrootBaseDir is the directory under which the jailer creates the jail.
+	ChrootBaseDir      string
+	JailerBinPath      string
+	FirecrackerBinPath string
+	CgroupVersion      string
+	UID                uint32
+	GID                uint32
+	CPUs               string
+	Mems               string
+	CgroupPath         string
+	// Limits are the resource limits of the cgroup of the VMM.
+	Limits cgroupLimits
+
+	// DriveExposePolicy defines how the jailer exposes files.
+	DriveExposePolicy proto.DriveExposePolicy
+}
+
+func newFirecrackerJailer(
+	ctx context.Context, logger *logrus.Entry, vmID string, cfg firecrackerJailerConfig,
+	mounts []*proto.FirecrackerDriveMount,
+) (*firecrackerJailer, error) {
+	if cfg.FirecrackerBinPath == "" {
+		return nil, errors.New("firecracker_binary_path must be set to use Firecracker's jailer")
+	}
+
+	// The jailer defaults to cgroup v1, which is not available on unified cgroup v2 hosts.
+	if cfg.CgroupVersion == "" && isCgroupV2(cgroupRoot) {
+		cfg.CgroupVersion = "2"
+	}
+	// The jailer splits each --cgroup argument on "=" and rejects the io.max values, which
+	// contain more of them.
+	if cfg.CgroupVersion == "2" && len(cfg.Limits.IOMax) > 0 {
+		return nil, errors.New("IOMax cannot be set with cgroup v2 and the firecracker jailer backend, use the runc backend instead")
+	}
+
+	j := &firecrackerJailer{
+		ctx:    ctx,
+		logger: logger.WithField("chrootBaseDir", cfg.ChrootBaseDir).WithField("jailerBinaryPath", cfg.JailerBinPath),
+		Config: cfg,
+		vmID:   vmID,
+	}
+
+	// The jailer creates the missing parents of the jail, but the jail itself must exist and be
+	// owned by the jailed user before any file can be exposed to it.
+	rootPath := j.RootPath()
+	j.logger.WithField("rootPath", rootPath).Debug("Creating root drive path")
+	if err := os.MkdirAll(filepath.Dir(rootPath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(rootPath), err)
+	}
+	if err := mkdirAndChown(rootPath, 0700, cfg.UID, cfg.GID); err != nil {
+		return nil, fmt.Errorf("%s failed to mkdirAndChown: %w", rootPath, err)
+	}
+
+	if cfg.DriveExposePolicy == proto.DriveExposePolicy_BIND {
+		for _, m := range mounts {
+			stat := syscall.Stat_t{}
+			if err := syscall.Stat(m.HostPath, &stat); err != nil {
+				return nil, err
+			}
+			// Block devices are exposed with mknod, like with the COPY policy.
+			if stat.Mode&syscall.S_IFMT == syscall.S_IFREG {
+				if err := j.ExposeFileToJail(m.HostPath); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return j, nil
+}
+
+// RootPath returns the root fs of the jailed system.
+func (j *firecrackerJailer) RootPath() string {
+	return filepath.Join(
+		j.Config.ChrootBaseDir,
+		filepath.Base(j.Config.FirecrackerBinPath),
+		j.vmID,
+		firecrackerJailerRootFolder,
+	)
+}
+
+// JailPath will return the jail's root path
+func (j *firecrackerJailer) JailPath() vm.Dir {
+	return vm.Dir(j.RootPath())
+}
+
+// BuildJailedMachine will return the needed options for a jailed Firecracker
+// instance. In addition, some configuration values will be overwritten to the
+// jailed values, like SocketPath in the machineConfig.
+func (j *firecrackerJailer) BuildJailedMachine(cfg *config.Config, machineConfig *firecracker.Config, vmID string) ([]firecracker.Opt, error) {
+	relSocketPath, err := j.JailPath().FirecrackerSockRelPath()
+	if err != nil {
+		return nil, err
+	}
+	machineConfig.SocketPath = relSocketPath
+
+	var debugSDK bool
+	if level, set := cfg.DebugHelper.GetFirecrackerSDKLogLevel(); set {
+		debugSDK = level == logrus.DebugLevel
+	}
+	// Build a new client since the socket path value was modified.
+	client := firecracker.NewClient(machineConfig.SocketPath, j.logger, debugSDK)
+
+	pidHandler := firecracker.Handler{
+		Name: "firecracker-containerd-jail-pid-handler",
+		Fn: func(_ context.Context, m *firecracker.Machine) error {
+			pid, err := m.PID()
+			if err != nil {
+				return err
+			}
+			j.pid = pid
+			return nil
+		},
+	}
+
+	return []firecracker.Opt{
+		firecracker.WithProcessRunner(j.jailerCommand(vmID, machineConfig.NetNS, cfg.DebugHelper.LogFirecrackerOutput())),
+		firecracker.WithClient(client),
+		func(m *firecracker.Machine) {
+			m.Handlers.FcInit = m.Handlers.FcInit.Prepend(j.buildJailedRootHandler())
+			// The fifos are linked to the jail once they are created.
+			m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(
+				firecracker.CreateLogFilesHandlerName,
+				linkFifoHandler(j.RootPath(), j.Config.UID, j.Config.GID),
+			)
+			m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.StartVMMHandlerName, pidHandler)
+		},
+	}, nil
+}
+
+// buildJailedRootHandler will populate the jail with the kernel image, drives and cache
+// topology. The jailer copies the Firecracker binary to the jail itself.
+func (j *firecrackerJailer) buildJailedRootHandler() firecracker.Handler {
+	rootPath := j.RootPath()
+
+	return firecracker.Handler{
+		Name: jailerHandlerName,
+		Fn: func(_ context.Context, m *firecracker.Machine) error {
+			newKernelImagePath := filepath.Join(rootPath, kernelImageFileName)
+			j.logger.WithField("newKernelImagePath", newKernelImagePath).Debug("copying kernel image")
+			if err := copyFileAndChown(m.Cfg.KernelImagePath, newKernelImagePath, 0400, j.Config.UID, j.Config.GID); err != nil {
+				return err
+			}
+			m.Cfg.KernelImagePath = kernelImageFileName
+
+			if err := exposeMachineFilesToJail(j.logger, m, rootPath, j.exposeFileToJail); err != nil {
+				return err
+			}
+
+			if err := setupCacheTopology(j.logger, rootPath, j.Config.UID, j.Config.GID); err != nil {
+				return err
+			}
+
+			j.logger.Info("Successfully ran jailer handler")
+			j.started = true
+
+			return nil
+		},
+	}
+}
+
+// jailerCommand returns the command running Firecracker with the jailer. The jailer
+// daemonizes Firecracker unless its output is logged.
+func (j *firecrackerJailer) jailerCommand(vmID, netNS string, isDebug bool) *exec.Cmd {
+	args := []string{
+		"--id", vmID,
+		"--uid", strconv.FormatUint(uint64(j.Config.UID), 10),
+		"--gid", strconv.FormatUint(uint64(j.Config.GID), 10),
+		"--exec-file", j.Config.FirecrackerBinPath,
+		"--chroot-base-dir", j.Config.ChrootBaseDir,
+	}
+
+	if j.Config.CgroupVersion != "" {
+		args = append(args, "--cgroup-version", j.Config.CgroupVersion)
+	}
+
+	if cgroupArgs := j.cgroupArgs(); len(cgroupArgs) > 0 {
+		args = append(args, "--parent-cgroup", j.parentCgroup())
+		for _, arg := range cgroupArgs {
+			args = append(args, "--cgroup", arg)
+		}
+	}
+
+	if netNS != "" {
+		args = append(args, "--netns", netNS)
+	}
+
+	if !isDebug {
+		args = append(args, "--daemonize")
+	}
+
+	// The jailer passes the VM ID to Firecracker, and Firecracker runs from the jail's root.
+	args = append(args, "--", "--api-sock", "/"+internal.FirecrackerSockName)
+
+	cmd := exec.CommandContext(j.ctx, j.Config.JailerBinPath, args...)
+	if isDebug {
+		cmd.Stdout = j.logger.WithField("vmm_stream", "stdout").WriterLevel(logrus.DebugLevel)
+		cmd.Stderr = j.logger.WithField("vmm_stream", "stderr").WriterLevel(logrus.DebugLevel)
+	}
+
+	return cmd
+}
+
+// cgroupArgs returns the cgroup files the jailer sets for the VM.
+func (j *firecrackerJailer) cgroupArgs() []string {
+	var args []string
+	if j.Config.CPUs != "" {
+		args = append(args, "cpuset.cpus="+j.Config.CPUs)
+	}
+	if j.Config.Mems != "" {
+		args = append(args, "cpuset.mems="+j.Config.Mems)
+	}
+	return append(args, j.Config.Limits.cgroupFiles(j.cgroupV2())...)
+}
+
+func (j *firecrackerJailer) cgroupV2() bool {
+	return j.Config.CgroupVersion == "2"
+}
+
+// cgroupDirs returns the directories of the cgroup the jailer creates for the VM: one
+// directory with cgroup v2, and one directory per controller of the files it sets with
+// cgroup v1.
+func (j *firecrackerJailer) cgroupDirs() []string {
+	cgroupPath := j.CgroupPath()
+	if cgroupPath == "" {
+		return nil
+	}
+	if j.cgroupV2() {
+		return []string{filepath.Join(cgroupRoot, cgroupPath)}
+	}
+
+	var dirs []string
+	for _, arg := range j.cgroupArgs() {
+		controller, _, _ := strings.Cut(arg, ".")
+		dir := filepath.Join(cgroupRoot, controller, cgroupPath)
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// parentCgroup returns the cgroup, relative to the cgroup filesystem, in which the
+// jailer creates the cgroup of the VM.
+func (j *firecrackerJailer) parentCgroup() string {
+	if j.Config.CgroupPath != "" {
+		return strings.TrimPrefix(j.Config.CgroupPath, "/")
+	}
+	return defaultFirecrackerCgroupPath
+}
+
+// CgroupPath returns the cgroup of the VM. The jailer only creates a cgroup for the VM
+// when it is given cgroup files to set.
+func (j *firecrackerJailer) CgroupPath() string {
+	if len(j.cgroupArgs()) == 0 {
+		return ""
+	}
+	return filepath.Join("/", j.parentCgroup(), j.vmID)
+}
+
+// StubDrivesOptions will return a set of options used to create a new stub
+// drive handler.
+func (j *firecrackerJailer) StubDrivesOptions() []FileOpt {
+	return chownFileOpts(j.Config.UID, j.Config.GID)
+}
+
+// ExposeFileToJail will expose the given file at the same path in the jail. Block devices
+// are created with mknod, while regular files are copied, bind mounted or linked depending on
+// the DriveExposePolicy.
+func (j *firecrackerJailer) ExposeFileToJail(srcPath string) error {
+	return exposeFileToJailRoot(j.RootPath(), srcPath, j.Config.UID, j.Config.GID, j.exposeFileToJail)
+}
+
+// RemoveFileFromJail removes the file exposed to the jail for srcPath.
+func (j *firecrackerJailer) RemoveFileFromJail(srcPath string) error {
+	// Never resolve to a file outside of the jail
+	dst, err := fs.RootPath(j.RootPath(), srcPath)
+	if err != nil {
+		return err
+	}
+
+	if err := j.unmount(dst); err != nil {
+		return err
+	}
+
+	err = os.Remove(dst)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// exposeFileToJail will make the file accessible from the jail.
+func (j *firecrackerJailer) exposeFileToJail(src, dst string, mode os.FileMode) error {
+	if j.Config.DriveExposePolicy == proto.DriveExposePolicy_BIND {
+		return j.bindMountFileToJail(src, dst)
+	}
+	return linkOrCopyFileAndChown(j.logger, j.Config.DriveExposePolicy, src, dst, mode, j.Config.UID, j.Config.GID)
+}
+
+// bindMountFileToJail bind mounts a file from src to dst. The jailer only carries the mounts
+// of the jail that exist when it starts over to the mount namespace of the VM, so files must
+// be bind mounted before it starts.
+func (j *firecrackerJailer) bindMountFileToJail(src, dst string) error {
+	if j.started {
+		_, err := os.Stat(dst)
+		if err != nil {
+			return fmt.Errorf("%q must be bind mounted before the jailer starts: %w", dst, err)
+		}
+		return nil
+	}
+
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := unix.Mount(src, dst, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("failed to bind mount %q to %q: %w", src, dst, err)
+	}
+	j.bindMountsMu.Lock()
+	j.bindMounts = append(j.bindMounts, dst)
+	j.bindMountsMu.Unlock()
+
+	return nil
+}
+
+// mountedFiles returns the paths in the jail which files are bind mounted to.
+func (j *firecrackerJailer) mountedFiles() []string {
+	j.bindMountsMu.Lock()
+	defer j.bindMountsMu.Unlock()
+	return append([]string(nil), j.bindMounts...)
+}
+
+// unmount unmounts dst if a file was bind mounted to it.
+func (j *firecrackerJailer) unmount(dst string) error {
+	j.bindMountsMu.Lock()
+	defer j.bindMountsMu.Unlock()
+
+	for i, mount := range j.bindMounts {
+		if mount != dst {
+			continue
+		}
+		if err := unix.Unmount(dst, unix.MNT_DETACH); err != nil {
+			return fmt.Errorf("failed to unmount %q: %w", dst, err)
+		}
+		j.bindMounts = append(j.bindMounts[:i], j.bindMounts[i+1:]...)
+		return nil
+	}
+	return nil
+}
+
+// Stop sends a signal to the jailed Firecracker process.
+func (j *firecrackerJailer) Stop(force bool) error {
+	return stopProcess(j.logger, j.pid, force)
+}
+
+// Close unmounts the files bind mounted to the jail, and removes the jail and the cgroup
+// the jailer created for the VM.
+func (j *firecrackerJailer) Close() error {
+	var result *multierror.Error
+	j.bindMountsMu.Lock()
+	for _, mount := range j.bindMounts {
+		if err := unix.Unmount(mount, unix.MNT_DETACH); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to unmount %q: %w", mount, err))
+		}
+	}
+	j.bindMounts = nil
+	j.bindMountsMu.Unlock()
+
+	if err := os.RemoveAll(j.Config.ChrootBaseDir); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	for _, dir := range j.cgroupDirs() {
+		if err := os.Remove(dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
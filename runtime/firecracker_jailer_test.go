@@ -0,0 +1,270 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/debug"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestJailerBackend(t *testing.T) {
+	for _, tc := range []struct {
+		configured string
+		requested  proto.JailerBackend
+		expected   proto.JailerBackend
+	}{
+		{"", proto.JailerBackend_DEFAULT_JAILER, proto.JailerBackend_RUNC_JAILER},
+		{config.RuncJailerBackend, proto.JailerBackend_DEFAULT_JAILER, proto.JailerBackend_RUNC_JAILER},
+		{config.FirecrackerJailerBackend, proto.JailerBackend_DEFAULT_JAILER, proto.JailerBackend_FIRECRACKER_JAILER},
+		{config.FirecrackerJailerBackend, proto.JailerBackend_RUNC_JAILER, proto.JailerBackend_RUNC_JAILER},
+		{config.RuncJailerBackend, proto.JailerBackend_FIRECRACKER_JAILER, proto.JailerBackend_FIRECRACKER_JAILER},
+	} {
+		backend, err := jailerBackend(tc.configured, tc.requested)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, backend, "configured %q, requested %s", tc.configured, tc.requested)
+	}
+
+	_, err := jailerBackend("chroot", proto.JailerBackend_DEFAULT_JAILER)
+	assert.Error(t, err)
+}
+
+func TestFirecrackerJailerCommand(t *testing.T) {
+	j := &firecrackerJailer{
+		ctx:    context.Background(),
+		logger: logrus.NewEntry(logrus.New()),
+		vmID:   "vm-id",
+		Config: firecrackerJailerConfig{
+			ChrootBaseDir:      "/shim/dir",
+			JailerBinPath:      "/usr/local/bin/jailer",
+			FirecrackerBinPath: "/usr/local/bin/firecracker",
+			CgroupVersion:      "2",
+			UID:                123,
+			GID:                456,
+			CPUs:               "0-1",
+			Mems:               "0",
+		},
+	}
+
+	cmd := j.jailerCommand(j.vmID, "/var/run/netns/vm-id", false)
+	assert.Equal(t, []string{
+		"/usr/local/bin/jailer",
+		"--id", "vm-id",
+		"--uid", "123",
+		"--gid", "456",
+		"--exec-file", "/usr/local/bin/firecracker",
+		"--chroot-base-dir", "/shim/dir",
+		"--cgroup-version", "2",
+		"--parent-cgroup", "firecracker-containerd",
+		"--cgroup", "cpuset.cpus=0-1",
+		"--cgroup", "cpuset.mems=0",
+		"--netns", "/var/run/netns/vm-id",
+		"--daemonize",
+		"--", "--api-sock", "/firecracker.sock",
+	}, cmd.Args)
+	assert.Equal(t, "/firecracker-containerd/vm-id", j.CgroupPath())
+	assert.Equal(t, "/shim/dir/firecracker/vm-id/root", j.RootPath())
+
+	j.Config.CPUs = ""
+	j.Config.Mems = ""
+	j.Config.CgroupVersion = ""
+	cmd = j.jailerCommand(j.vmID, "", true)
+	assert.Equal(t, []string{
+		"/usr/local/bin/jailer",
+		"--id", "vm-id",
+		"--uid", "123",
+		"--gid", "456",
+		"--exec-file", "/usr/local/bin/firecracker",
+		"--chroot-base-dir", "/shim/dir",
+		"--", "--api-sock", "/firecracker.sock",
+	}, cmd.Args, "the jailer should not daemonize Firecracker when its output is logged")
+	assert.Empty(t, j.CgroupPath(), "the jailer only creates a cgroup when given cgroup files")
+}
+
+func TestNewJailer_Firecracker(t *testing.T) {
+	internal.RequiresRoot(t)
+	dir := t.TempDir()
+
+	s := &service{
+		vmID: "vm-id",
+		config: &config.Config{
+			FirecrackerBinaryPath: "/usr/local/bin/firecracker",
+			JailerConfig: config.JailerConfig{
+				Backend:          config.FirecrackerJailerBackend,
+				JailerBinaryPath: "/usr/local/bin/jailer",
+			},
+		},
+	}
+	req := &proto.CreateVMRequest{
+		JailerConfig: &proto.JailerConfig{
+			UID:        123,
+			GID:        456,
+			CPUs:       "0",
+			CgroupPath: "/my/cgroup_path",
+		},
+	}
+
+	j, err := newJailer(context.Background(), logrus.NewEntry(logrus.New()), dir, s, req)
+	require.NoError(t, err)
+	fcJailer, ok := j.(*firecrackerJailer)
+	require.True(t, ok, "expected the firecracker jailer, got %T", j)
+	assert.Equal(t, "/my/cgroup_path/vm-id", fcJailer.CgroupPath())
+
+	info, err := os.Stat(fcJailer.RootPath())
+	require.NoError(t, err, "the jail should be created")
+	stat := info.Sys().(*syscall.Stat_t)
+	assert.Equal(t, uint32(123), stat.Uid)
+	assert.Equal(t, uint32(456), stat.Gid)
+
+	require.NoError(t, j.Close())
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "the jail should be removed")
+
+	req.JailerConfig.SeccompPolicy = proto.SeccompPolicy_DEFAULT_SECCOMP
+	_, err = newJailer(context.Background(), logrus.NewEntry(logrus.New()), dir, s, req)
+	assert.Error(t, err, "runc hardening options should be rejected")
+}
+
+func TestFirecrackerJailerBuildJailedMachine(t *testing.T) {
+	internal.RequiresRoot(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	kernelImagePath := filepath.Join(dir, "kernel-image")
+	require.NoError(t, os.WriteFile(kernelImagePath, []byte("kernel"), 0600))
+	rootDrivePath := filepath.Join(dir, "root-drive")
+	require.NoError(t, os.WriteFile(rootDrivePath, []byte("rootfs"), 0600))
+
+	j, err := newFirecrackerJailer(ctx, logrus.NewEntry(logrus.New()), "vm-id", firecrackerJailerConfig{
+		ChrootBaseDir:      filepath.Join(dir, "shim"),
+		JailerBinPath:      "jailer",
+		FirecrackerBinPath: "/usr/local/bin/firecracker",
+		UID:                123,
+		GID:                456,
+	}, nil)
+	require.NoError(t, err)
+
+	debugHelper, err := debug.New()
+	require.NoError(t, err)
+	cfg := &config.Config{FirecrackerBinaryPath: "/usr/local/bin/firecracker", DebugHelper: debugHelper}
+	machineConfig := firecracker.Config{
+		SocketPath:      "/path/to/api.socket",
+		KernelImagePath: kernelImagePath,
+		Drives: []models.Drive{{
+			PathOnHost:   firecracker.String(rootDrivePath),
+			IsRootDevice: firecracker.Bool(true),
+			IsReadOnly:   firecracker.Bool(true),
+		}},
+		VsockDevices: []firecracker.VsockDevice{{Path: "shim/firecracker.vsock", ID: "agent_api"}},
+	}
+	opts, err := j.BuildJailedMachine(cfg, &machineConfig, "vm-id")
+	require.NoError(t, err)
+	assert.NotEmpty(t, opts)
+
+	relSocketPath, err := j.JailPath().FirecrackerSockRelPath()
+	require.NoError(t, err)
+	assert.Equal(t, relSocketPath, machineConfig.SocketPath)
+
+	machine := firecracker.Machine{Cfg: machineConfig}
+	require.NoError(t, j.buildJailedRootHandler().Fn(ctx, &machine))
+
+	assert.Equal(t, kernelImageFileName, machine.Cfg.KernelImagePath)
+	assert.Equal(t, "root-drive", firecracker.StringValue(machine.Cfg.Drives[0].PathOnHost))
+	assert.Equal(t, "/firecracker.vsock", machine.Cfg.VsockDevices[0].Path)
+
+	contents, err := os.ReadFile(filepath.Join(j.RootPath(), kernelImageFileName))
+	require.NoError(t, err, "failed to copy kernel image")
+	assert.Equal(t, "kernel", string(contents))
+
+	contents, err = os.ReadFile(filepath.Join(j.RootPath(), "root-drive"))
+	require.NoError(t, err, "failed to copy root drive")
+	assert.Equal(t, "rootfs", string(contents))
+}
+
+func TestFirecrackerJailerContainerPlaceholders(t *testing.T) {
+	internal.RequiresRoot(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+
+	kernelImagePath := filepath.Join(dir, "kernel-image")
+	require.NoError(t, os.WriteFile(kernelImagePath, []byte("kernel"), 0600))
+
+	for _, policy := range []proto.DriveExposePolicy{proto.DriveExposePolicy_COPY, proto.DriveExposePolicy_LINK} {
+		vmID := "vm-" + policy.String()
+		j, err := newFirecrackerJailer(ctx, logger, vmID, firecrackerJailerConfig{
+			ChrootBaseDir:      filepath.Join(dir, "shim"),
+			JailerBinPath:      "jailer",
+			FirecrackerBinPath: "/usr/local/bin/firecracker",
+			UID:                123,
+			GID:                456,
+			DriveExposePolicy:  policy,
+		}, nil)
+		require.NoError(t, err)
+
+		// The second container drive is backed by a placeholder created in the jail.
+		machineConfig := firecracker.Config{KernelImagePath: kernelImagePath}
+		_, err = CreateContainerStubs(&machineConfig, j, 1, 2, logger)
+		require.NoError(t, err)
+		require.Len(t, machineConfig.Drives, 2)
+
+		machine := firecracker.Machine{Cfg: machineConfig}
+		require.NoError(t, j.buildJailedRootHandler().Fn(ctx, &machine), policy.String())
+
+		assert.Equal(t, "ctrstub0", firecracker.StringValue(machine.Cfg.Drives[0].PathOnHost))
+		assert.Equal(t, "ctrstub1-placeholder", firecracker.StringValue(machine.Cfg.Drives[1].PathOnHost))
+		assert.FileExists(t, filepath.Join(j.RootPath(), "ctrstub1-placeholder"))
+	}
+}
+
+func TestFirecrackerJailerExposeFileToJail(t *testing.T) {
+	internal.RequiresRoot(t)
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "drive.img")
+	require.NoError(t, os.WriteFile(src, []byte("drive"), 0600))
+
+	j, err := newFirecrackerJailer(context.Background(), logrus.NewEntry(logrus.New()), "vm-id", firecrackerJailerConfig{
+		ChrootBaseDir:      filepath.Join(dir, "shim"),
+		FirecrackerBinPath: "/usr/local/bin/firecracker",
+		UID:                123,
+		GID:                456,
+	}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, j.ExposeFileToJail(src))
+	dst := filepath.Join(j.RootPath(), src)
+	contents, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "drive", string(contents))
+
+	require.NoError(t, j.RemoveFileFromJail(src))
+	_, err = os.Stat(dst)
+	assert.True(t, os.IsNotExist(err), "the file should be removed from the jail")
+	_, err = os.Stat(src)
+	assert.NoError(t, err, "the source file should be left as is")
+}
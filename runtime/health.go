@@ -0,0 +1,165 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/protobuf"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	health "github.com/firecracker-microvm/firecracker-containerd/proto/service/health/ttrpc"
+)
+
+// healthStatus is the health of the VM agent reported by GetVMInfo.
+type healthStatus struct {
+	mu     sync.Mutex
+	health proto.VMHealth
+	err    string
+}
+
+func (s *healthStatus) set(health proto.VMHealth, err string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.health = health
+	s.err = err
+}
+
+func (s *healthStatus) get() (proto.VMHealth, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.health, s.err
+}
+
+// healthChecker computes the health of a VM agent from the results of its health checks. The
+// agent is unhealthy once threshold health checks failed in a row, and healthy again as soon
+// as one succeeds.
+type healthChecker struct {
+	threshold int
+
+	health      proto.VMHealth
+	failures    int
+	unhealthyAt time.Time
+}
+
+func newHealthChecker(threshold int) *healthChecker {
+	return &healthChecker{threshold: threshold}
+}
+
+// record records the result of a health check made at the provided time, and returns the
+// health of the agent before it.
+func (c *healthChecker) record(err error, now time.Time) proto.VMHealth {
+	previous := c.health
+	if err == nil {
+		c.failures = 0
+		c.health = proto.VMHealth_HEALTHY
+		return previous
+	}
+
+	c.failures++
+	if c.failures >= c.threshold && c.health != proto.VMHealth_UNHEALTHY {
+		c.health = proto.VMHealth_UNHEALTHY
+		c.unhealthyAt = now
+	}
+	return previous
+}
+
+// monitorAgentHealth polls the Health service of the VM agent until the shim exits, updating
+// the health reported by GetVMInfo and publishing an event whenever the VM becomes unhealthy
+// or recovers. Health checks are skipped while the VM is paused.
+func (s *service) monitorAgentHealth() {
+	cfg := s.config.HealthCheck
+	if cfg.IntervalSeconds <= 0 {
+		return
+	}
+
+	interval := time.Duration(cfg.IntervalSeconds) * time.Second
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	logger := s.log().WithField("component", "health-check")
+	logger.Debugf("starting agent health checks: interval=%s timeout=%s threshold=%d", interval, timeout, cfg.FailureThreshold)
+
+	checker := newHealthChecker(cfg.FailureThreshold)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shimCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.eventMu.Lock()
+		paused := !s.pausedAt.IsZero()
+		s.eventMu.Unlock()
+		if paused {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(s.shimCtx, timeout)
+		_, err := s.healthClient.GetHealth(ctx, &health.GetHealthRequest{})
+		cancel()
+		if s.shimCtx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+		previous := checker.record(err, now)
+		if err != nil {
+			logger.WithError(err).Warnf("agent health check failed (%d in a row)", checker.failures)
+			if checker.health == proto.VMHealth_UNHEALTHY {
+				s.healthStatus.set(checker.health, err.Error())
+			}
+		} else {
+			s.healthStatus.set(checker.health, "")
+		}
+
+		switch {
+		case previous != proto.VMHealth_UNHEALTHY && checker.health == proto.VMHealth_UNHEALTHY:
+			logger.WithError(err).Errorf("agent is unhealthy after %d failed health checks", checker.failures)
+			if publishErr := s.publishVMUnhealthy(err, checker.failures, now); publishErr != nil {
+				logger.WithError(publishErr).Error("failed to publish unhealthy VM event")
+			}
+		case previous == proto.VMHealth_UNHEALTHY && checker.health == proto.VMHealth_HEALTHY:
+			logger.Info("agent is healthy again")
+			if publishErr := s.publishVMHealthy(now.Sub(checker.unhealthyAt), now); publishErr != nil {
+				logger.WithError(publishErr).Error("failed to publish healthy VM event")
+			}
+		}
+	}
+}
+
+func (s *service) publishVMUnhealthy(checkErr error, failures int, unhealthyAt time.Time) error {
+	return s.eventExchange.Publish(s.shimCtx, UnhealthyEventName, &proto.VMUnhealthy{
+		VMID:                s.currentVMID(),
+		Namespace:           s.namespace,
+		Error:               checkErr.Error(),
+		ConsecutiveFailures: int64(failures),
+		UnhealthyAt:         protobuf.ToTimestamp(unhealthyAt),
+	})
+}
+
+func (s *service) publishVMHealthy(unhealthyDuration time.Duration, healthyAt time.Time) error {
+	return s.eventExchange.Publish(s.shimCtx, HealthyEventName, &proto.VMHealthy{
+		VMID:              s.currentVMID(),
+		Namespace:         s.namespace,
+		HealthyAt:         protobuf.ToTimestamp(healthyAt),
+		UnhealthyDuration: durationpb.New(unhealthyDuration),
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestHealthChecker(t *testing.T) {
+	checker := newHealthChecker(2)
+	checkErr := errors.New("mock health check error")
+	now := time.Now()
+
+	assert.Equal(t, proto.VMHealth_HEALTH_UNKNOWN, checker.record(checkErr, now))
+	assert.Equal(t, proto.VMHealth_HEALTH_UNKNOWN, checker.health, "a single failure should not make the VM unhealthy")
+
+	assert.Equal(t, proto.VMHealth_HEALTH_UNKNOWN, checker.record(nil, now))
+	assert.Equal(t, proto.VMHealth_HEALTHY, checker.health)
+
+	assert.Equal(t, proto.VMHealth_HEALTHY, checker.record(checkErr, now))
+	assert.Equal(t, proto.VMHealth_HEALTHY, checker.health, "failures should be counted from the last success")
+
+	unhealthyAt := now.Add(time.Second)
+	assert.Equal(t, proto.VMHealth_HEALTHY, checker.record(checkErr, unhealthyAt))
+	assert.Equal(t, proto.VMHealth_UNHEALTHY, checker.health)
+
+	assert.Equal(t, proto.VMHealth_UNHEALTHY, checker.record(checkErr, now.Add(2*time.Second)))
+	assert.Equal(t, unhealthyAt, checker.unhealthyAt, "the VM should stay unhealthy since the first failure past the threshold")
+	assert.Equal(t, 3, checker.failures)
+
+	assert.Equal(t, proto.VMHealth_UNHEALTHY, checker.record(nil, now.Add(3*time.Second)))
+	assert.Equal(t, proto.VMHealth_HEALTHY, checker.health)
+	assert.Equal(t, 0, checker.failures)
+}
+
+func TestHealthStatus(t *testing.T) {
+	var status healthStatus
+	health, err := status.get()
+	assert.Equal(t, proto.VMHealth_HEALTH_UNKNOWN, health)
+	assert.Empty(t, err)
+
+	status.set(proto.VMHealth_UNHEALTHY, "mock health check error")
+	health, err = status.get()
+	assert.Equal(t, proto.VMHealth_UNHEALTHY, health)
+	assert.Equal(t, "mock health check error", err)
+}
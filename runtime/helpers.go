@@ -30,6 +30,52 @@ const (
 	defaultCPUCount  = 1
 )
 
+// containerCounts returns the minimum and maximum number of container drives of the VM
+// described by the request.
+func containerCounts(req *proto.CreateVMRequest) (int, int, error) {
+	minCount := int(req.MinContainerCount)
+	maxCount := int(req.MaxContainerCount)
+	if minCount == 0 && maxCount == 0 {
+		minCount = int(req.ContainerCount)
+		maxCount = minCount
+	}
+
+	if minCount < 0 || maxCount < 0 {
+		return 0, 0, fmt.Errorf("container counts cannot be negative: min %d, max %d", minCount, maxCount)
+	}
+
+	if minCount < 1 {
+		// minCount should always be positive so that at least one container
+		// can run inside the VM. This makes the assumption that a task is going
+		// to be run, and to do that at least one container is needed.
+		minCount = 1
+	}
+
+	if maxCount == 0 {
+		maxCount = minCount
+	} else if maxCount < minCount {
+		return 0, 0, fmt.Errorf("MaxContainerCount %d is less than MinContainerCount %d", maxCount, minCount)
+	}
+
+	return minCount, maxCount, nil
+}
+
+// vmStateFromInstanceInfo converts the state reported by the Firecracker API to a proto VMState.
+func vmStateFromInstanceInfo(info models.InstanceInfo) proto.VMState {
+	if info.State == nil {
+		return proto.VMState_VM_STATE_UNKNOWN
+	}
+
+	switch *info.State {
+	case models.InstanceInfoStateRunning:
+		return proto.VMState_VM_STATE_RUNNING
+	case models.InstanceInfoStatePaused:
+		return proto.VMState_VM_STATE_PAUSED
+	default:
+		return proto.VMState_VM_STATE_UNKNOWN
+	}
+}
+
 func machineConfigurationFromProto(cfg *config.Config, req *proto.FirecrackerMachineConfiguration) models.MachineConfiguration {
 	config := models.MachineConfiguration{
 		CPUTemplate: models.CPUTemplate(cfg.CPUTemplate),
@@ -81,6 +127,13 @@ func networkConfigFromProto(nwIface *proto.FirecrackerNetworkInterface, _ string
 		}
 
 		if ipConf := staticConf.IPConfig; ipConf != nil {
+			if err := validateGuestIPConfig(staticConf); err != nil {
+				return nil, err
+			}
+		}
+
+		// The rest of the IP configuration is applied by the agent once the VM started
+		if ipConf := staticConf.IPConfig; ipConf != nil && ipConf.PrimaryAddr != "" {
 			ip, ipNet, err := net.ParseCIDR(ipConf.PrimaryAddr)
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse CIDR from %q: %w", ipConf.PrimaryAddr, err)
@@ -92,7 +145,7 @@ func networkConfigFromProto(nwIface *proto.FirecrackerNetworkInterface, _ string
 					Mask: ipNet.Mask,
 				},
 				Gateway:     net.ParseIP(ipConf.GatewayAddr),
-				Nameservers: ipConf.Nameservers,
+				Nameservers: kernelNameservers(ipConf),
 			}
 		}
 	}
@@ -33,6 +33,63 @@ const (
 	vcpuCount    = 2
 )
 
+func TestVMStateFromInstanceInfo(t *testing.T) {
+	testcases := []struct {
+		name     string
+		state    *string
+		expected proto.VMState
+	}{
+		{name: "nil state", state: nil, expected: proto.VMState_VM_STATE_UNKNOWN},
+		{name: "running", state: firecracker.String(models.InstanceInfoStateRunning), expected: proto.VMState_VM_STATE_RUNNING},
+		{name: "paused", state: firecracker.String(models.InstanceInfoStatePaused), expected: proto.VMState_VM_STATE_PAUSED},
+		{name: "not started", state: firecracker.String(models.InstanceInfoStateNotStarted), expected: proto.VMState_VM_STATE_UNKNOWN},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, vmStateFromInstanceInfo(models.InstanceInfo{State: tc.state}))
+		})
+	}
+}
+
+func TestContainerCounts(t *testing.T) {
+	testcases := []struct {
+		name        string
+		request     *proto.CreateVMRequest
+		expectedMin int
+		expectedMax int
+		expectErr   bool
+	}{
+		{name: "defaults", request: &proto.CreateVMRequest{}, expectedMin: 1, expectedMax: 1},
+		{name: "container count", request: &proto.CreateVMRequest{ContainerCount: 3}, expectedMin: 3, expectedMax: 3},
+		{name: "min only", request: &proto.CreateVMRequest{MinContainerCount: 2}, expectedMin: 2, expectedMax: 2},
+		{name: "max only", request: &proto.CreateVMRequest{MaxContainerCount: 4}, expectedMin: 1, expectedMax: 4},
+		{
+			name:        "min and max override container count",
+			request:     &proto.CreateVMRequest{ContainerCount: 8, MinContainerCount: 2, MaxContainerCount: 16},
+			expectedMin: 2,
+			expectedMax: 16,
+		},
+		{name: "max less than min", request: &proto.CreateVMRequest{MinContainerCount: 4, MaxContainerCount: 2}, expectErr: true},
+		{name: "negative", request: &proto.CreateVMRequest{MinContainerCount: -1}, expectErr: true},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			minCount, maxCount, err := containerCounts(tc.request)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMin, minCount)
+			assert.Equal(t, tc.expectedMax, maxCount)
+		})
+	}
+}
+
 func TestMachineConfigurationFromProto(t *testing.T) {
 	testcases := []struct {
 		name                  string
@@ -139,6 +196,60 @@ func TestNetworkConfigFromProto_Static(t *testing.T) {
 	assert.Nil(t, network.OutRateLimiter)
 }
 
+func TestNetworkConfigFromProto_GuestConfig(t *testing.T) {
+	network, err := networkConfigFromProto(&proto.FirecrackerNetworkInterface{
+		StaticConfig: &proto.StaticNetworkConfiguration{
+			MacAddress:  mac,
+			HostDevName: hostDevName,
+			IPConfig: &proto.IPConfiguration{
+				PrimaryAddr:     "198.51.100.2/24",
+				GatewayAddr:     "198.51.100.1",
+				AdditionalAddrs: []string{"2001:db8::2/64"},
+				IPv6GatewayAddr: "2001:db8::1",
+				Nameservers:     []string{"2001:db8::53", "192.0.2.1", "192.0.2.2", "192.0.2.3"},
+				SearchDomains:   []string{"example.com"},
+			},
+		},
+	}, "vmID")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, network.StaticConfiguration.IPConfiguration.Nameservers,
+		"only the IPv4 nameservers supported by the kernel should be passed to it")
+
+	network, err = networkConfigFromProto(&proto.FirecrackerNetworkInterface{
+		StaticConfig: &proto.StaticNetworkConfiguration{
+			MacAddress:  mac,
+			HostDevName: hostDevName,
+			IPConfig: &proto.IPConfiguration{
+				AdditionalAddrs: []string{"2001:db8::2/64"},
+				IPv6GatewayAddr: "2001:db8::1",
+			},
+		},
+	}, "vmID")
+	require.NoError(t, err)
+	assert.Nil(t, network.StaticConfiguration.IPConfiguration, "IPv6-only interfaces should not be configured by the kernel")
+
+	for name, ipConf := range map[string]*proto.IPConfiguration{
+		"invalid additional address": {AdditionalAddrs: []string{"2001:db8::2"}},
+		"IPv4 IPv6 gateway":          {IPv6GatewayAddr: "198.51.100.1"},
+		"invalid route destination":  {Routes: []*proto.IPRoute{{Destination: "203.0.113.0"}}},
+		"invalid route gateway":      {Routes: []*proto.IPRoute{{Destination: "203.0.113.0/24", GatewayAddr: "gateway"}}},
+		"invalid nameserver":         {Nameservers: []string{"dns.example.com"}},
+	} {
+		_, err := networkConfigFromProto(&proto.FirecrackerNetworkInterface{
+			StaticConfig: &proto.StaticNetworkConfiguration{MacAddress: mac, HostDevName: hostDevName, IPConfig: ipConf},
+		}, "vmID")
+		assert.Error(t, err, name)
+	}
+
+	_, err = networkConfigFromProto(&proto.FirecrackerNetworkInterface{
+		StaticConfig: &proto.StaticNetworkConfiguration{
+			HostDevName: hostDevName,
+			IPConfig:    &proto.IPConfiguration{AdditionalAddrs: []string{"2001:db8::2/64"}},
+		},
+	}, "vmID")
+	assert.Error(t, err, "MacAddress should be required to configure additional addresses")
+}
+
 func TestNetworkConfigFromProto_CNI(t *testing.T) {
 	networkName := "da-network"
 	ifName := "da-iface"
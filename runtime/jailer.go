@@ -15,12 +15,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
@@ -31,8 +37,28 @@ const (
 	jailerHandlerName     = "firecracker-containerd-jail-handler"
 	jailerFifoHandlerName = "firecracker-containerd-jail-fifo-handler"
 	rootfsFolder          = "rootfs"
+	cacheTopologyPath     = "/sys/devices/system/cpu/cpu0/cache"
+	cacheFolderPrefix     = "index"
 )
 
+var cacheTopologyPaths = []string{
+	"sys",
+	"devices",
+	"system",
+	"cpu",
+	"cpu0",
+	"cache",
+}
+
+var cacheTopologyFiles = []string{
+	"level",
+	"type",
+	"size",
+	"number_of_sets",
+	"shared_cpu_map",
+	"coherency_line_size",
+}
+
 // jailer will allow modification and provide options to the the Firecracker VM
 // to allow for jailing. In addition, this will allow for given files to be exposed
 // to the jailed filesystem.
@@ -45,6 +71,9 @@ type jailer interface {
 	// regular files and block devices. An error is returned if provided a path to a file
 	// with type that is not supported.
 	ExposeFileToJail(path string) error
+	// RemoveFileFromJail removes a file exposed with ExposeFileToJail once the VM
+	// no longer uses it.
+	RemoveFileFromJail(path string) error
 	// JailPath is used to return the directory we are supposed to be working in.
 	JailPath() vm.Dir
 	// StubDrivesOptions will return a set of options used to create a new stub
@@ -92,6 +121,43 @@ func newJailer(
 		return nil, fmt.Errorf("failed to create oci bundle path: %s: %w", ociBundlePath, err)
 	}
 
+	backend, err := jailerBackend(service.config.JailerConfig.Backend, request.JailerConfig.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := cgroupLimitsFromProto(request.JailerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cgroup limits: %w", err)
+	}
+
+	hardening, err := runcHardeningFromProto(request.JailerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jailer hardening options: %w", err)
+	}
+
+	if backend == proto.JailerBackend_FIRECRACKER_JAILER {
+		if hardening.isSet() {
+			return nil, errors.New("seccomp, capability and user namespace options are only supported by the runc jailer")
+		}
+
+		l := logger.WithField("jailer", "firecracker")
+		config := firecrackerJailerConfig{
+			ChrootBaseDir:      ociBundlePath,
+			JailerBinPath:      service.config.JailerConfig.JailerBinaryPath,
+			FirecrackerBinPath: service.config.FirecrackerBinaryPath,
+			CgroupVersion:      service.config.JailerConfig.CgroupVersion,
+			UID:                request.JailerConfig.UID,
+			GID:                request.JailerConfig.GID,
+			CPUs:               request.JailerConfig.CPUs,
+			Mems:               request.JailerConfig.Mems,
+			CgroupPath:         request.JailerConfig.CgroupPath,
+			Limits:             limits,
+			DriveExposePolicy:  request.JailerConfig.DriveExposePolicy,
+		}
+		return newFirecrackerJailer(ctx, l, service.vmID, config, request.DriveMounts)
+	}
+
 	l := logger.WithField("jailer", "runc")
 	config := runcJailerConfig{
 		OCIBundlePath:     ociBundlePath,
@@ -102,7 +168,348 @@ func newJailer(
 		CPUs:              request.JailerConfig.CPUs,
 		Mems:              request.JailerConfig.Mems,
 		CgroupPath:        request.JailerConfig.CgroupPath,
+		Limits:            limits,
+		Hardening:         hardening,
 		DriveExposePolicy: request.JailerConfig.DriveExposePolicy,
 	}
 	return newRuncJailer(ctx, l, service.vmID, config, request.DriveMounts)
 }
+
+// jailerBackend returns the jailer backend requested by a CreateVM request, or the backend
+// of the runtime config if the request doesn't select one.
+func jailerBackend(configured string, requested proto.JailerBackend) (proto.JailerBackend, error) {
+	if requested != proto.JailerBackend_DEFAULT_JAILER {
+		return requested, nil
+	}
+
+	switch configured {
+	case "", config.RuncJailerBackend:
+		return proto.JailerBackend_RUNC_JAILER, nil
+	case config.FirecrackerJailerBackend:
+		return proto.JailerBackend_FIRECRACKER_JAILER, nil
+	default:
+		return proto.JailerBackend_DEFAULT_JAILER, fmt.Errorf("unknown jailer backend %q", configured)
+	}
+}
+
+// stopProcess sends SIGTERM, or SIGKILL if force is set, to the Firecracker process of pid.
+func stopProcess(logger *logrus.Entry, pid int, force bool) error {
+	if pid == 0 {
+		return errors.New("the machine has not been started")
+	}
+
+	signal := syscall.SIGTERM
+	if force {
+		signal = syscall.SIGKILL
+	}
+	logger.Debugf("sending signal %d to %d", signal, pid)
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	err = p.Signal(signal)
+	if err == nil || err.Error() == "os: process already finished" {
+		return nil
+	}
+	return err
+}
+
+// exposeFileToJailRoot will inspect the given file, srcPath, and based on the
+// file type, proper handling will occur to ensure that the file is visible at the
+// same path in the jail rooted at rootPath. For block devices we will use mknod to
+// create the device and then set the correct permissions to ensure visibility in
+// the jail. Regular files are exposed with exposeFile.
+func exposeFileToJailRoot(
+	rootPath, srcPath string, uid, gid uint32,
+	exposeFile func(src, dst string, mode os.FileMode) error,
+) error {
+	stat := syscall.Stat_t{}
+	if err := syscall.Stat(srcPath, &stat); err != nil {
+		return err
+	}
+
+	// Checks file type using S_IFMT which is the bit mask for the file type.
+	switch stat.Mode & syscall.S_IFMT {
+	case syscall.S_IFBLK:
+		parentDir := filepath.Join(rootPath, filepath.Dir(srcPath))
+		if err := mkdirAllWithPermissions(parentDir, 0700, uid, gid); err != nil {
+			return err
+		}
+
+		dst := filepath.Join(parentDir, filepath.Base(srcPath))
+		if err := exposeBlockDeviceToJail(dst, int(stat.Rdev), int(uid), int(gid)); err != nil {
+			return err
+		}
+
+	case syscall.S_IFREG:
+		parentDir := filepath.Join(rootPath, filepath.Dir(srcPath))
+		if err := mkdirAllWithPermissions(parentDir, 0700, uid, gid); err != nil {
+			return err
+		}
+
+		dst := filepath.Join(parentDir, filepath.Base(srcPath))
+		if err := exposeFile(srcPath, dst, os.FileMode(stat.Mode)); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported mode: %v", stat.Mode)
+	}
+
+	return nil
+}
+
+// exposeMachineFilesToJail exposes the drives of the machine which are not in the jail rooted at
+// rootPath yet with exposeFile, and sets the paths of the drives and vsock devices relative to
+// the jail.
+func exposeMachineFilesToJail(
+	logger *logrus.Entry, m *firecracker.Machine, rootPath string,
+	exposeFile func(src, dst string, mode os.FileMode) error,
+) error {
+	// copy drives to new contents path
+	for i, d := range m.Cfg.Drives {
+		drivePath := firecracker.StringValue(d.PathOnHost)
+		fileName := filepath.Base(drivePath)
+		newDrivePath := filepath.Join(rootPath, fileName)
+
+		f, err := os.Open(drivePath)
+		if err != nil {
+			return fmt.Errorf("failed to open drive file: %w", err)
+		}
+
+		// This closes the file in the event an error occurred, otherwise we
+		// call close down below.
+		defer f.Close()
+
+		// Files the shim already created in the root of the jail, such as the placeholders of
+		// the container drives beyond MinContainerCount, are visible to Firecracker as is.
+		if filepath.Clean(drivePath) != newDrivePath && !internal.IsStubDrive(f) {
+			mode := 0600
+			if firecracker.BoolValue(d.IsReadOnly) {
+				mode = 0400
+			}
+			if err := exposeFile(drivePath, newDrivePath, os.FileMode(mode)); err != nil {
+				return err
+			}
+		}
+
+		if err := f.Close(); err != nil {
+			logger.WithError(err).Debug("failed to close drive file")
+		}
+
+		logger.WithField("drive", newDrivePath).Debug("Adding drive")
+		m.Cfg.Drives[i].PathOnHost = firecracker.String(fileName)
+	}
+
+	// Setting the proper path to where the vsock path should be
+	for i, v := range m.Cfg.VsockDevices {
+		logger.WithField("vsock path", v.Path).Debug("vsock device path being set relative to jailed directory")
+
+		filename := filepath.Base(v.Path)
+		v.Path = filepath.Join("/", filename)
+		m.Cfg.VsockDevices[i] = v
+	}
+
+	return nil
+}
+
+// makeLinkInJail creates a hard link to `src` inside the jail rooted at rootPath.
+func makeLinkInJail(rootPath, src, base string, uid, gid uint32) (string, error) {
+	if strings.ContainsRune(base, os.PathSeparator) {
+		return "", fmt.Errorf("%q must not contain %q", base, os.PathSeparator)
+	}
+
+	dst := filepath.Join(rootPath, base)
+
+	// Since Firecracker is unaware that we are in a jailed environment and
+	// what owner/group to set this as when creating, we will manually have
+	// to adjust the permission bits ourselves
+	if err := linkAndChown(src, dst, uid, gid); err != nil {
+		return "", err
+	}
+
+	// this path needs to be relative to the root path, and since we are
+	// placing the file in the root path the value should just be the file name.
+	return base, nil
+}
+
+// linkFifoHandler will return a new firecracker.Handler with the function
+// that will allow linking of the fifos to the jail rooted at rootPath, making
+// them visible to Firecracker.
+func linkFifoHandler(rootPath string, uid, gid uint32) firecracker.Handler {
+	return firecracker.Handler{
+		Name: jailerFifoHandlerName,
+		Fn: func(_ context.Context, m *firecracker.Machine) error {
+			logFifo, err := makeLinkInJail(rootPath, m.Cfg.LogPath, internal.FirecrackerLogFifoName, uid, gid)
+			if err != nil {
+				return err
+			}
+			m.Cfg.LogFifo = logFifo
+
+			metricsFifo, err := makeLinkInJail(rootPath, m.Cfg.MetricsPath, internal.FirecrackerMetricsFifoName, uid, gid)
+			if err != nil {
+				return err
+			}
+			m.Cfg.MetricsFifo = metricsFifo
+
+			return nil
+		},
+	}
+}
+
+// chownFileOpts returns the options used to create stub drives owned by the jailed user.
+func chownFileOpts(uid, gid uint32) []FileOpt {
+	return []FileOpt{
+		func(file *os.File) error {
+			err := unix.Fchown(int(file.Fd()), int(uid), int(gid))
+			if err != nil {
+				return fmt.Errorf("failed to chown stub file %q: %w", file.Name(), err)
+			}
+			return nil
+		},
+	}
+}
+
+// copyFileAndChown copies a file from src to dst, and chown the new file to the jail user.
+func copyFileAndChown(src, dst string, mode os.FileMode, uid, gid uint32) error {
+	if err := copyFile(src, dst, mode); err != nil {
+		return err
+	}
+	if err := os.Chown(dst, int(uid), int(gid)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// linkOrCopyFileAndChown exposes the regular file src at dst with the LINK or REFLINK policy,
+// and copies it if the policy cannot be applied to the file. New files are chowned to the jail
+// user, while linked files are left as is, as they share their inode with src. Writes to a
+// linked file would reach src, so only files exposed with a read-only mode are linked.
+func linkOrCopyFileAndChown(
+	logger *logrus.Entry, policy proto.DriveExposePolicy,
+	src, dst string, mode os.FileMode, uid, gid uint32,
+) error {
+	var err error
+	switch policy {
+	case proto.DriveExposePolicy_LINK:
+		if mode.Perm()&0222 != 0 {
+			return copyFileAndChown(src, dst, mode, uid, gid)
+		}
+		err = linkFile(src, dst, mode, uid, gid)
+		if err == nil {
+			return nil
+		}
+	case proto.DriveExposePolicy_REFLINK:
+		err = reflinkFile(src, dst, mode)
+	default:
+		return copyFileAndChown(src, dst, mode, uid, gid)
+	}
+
+	if err != nil {
+		logger.WithError(err).WithField("path", src).Warnf("failed to expose file with the %s policy, copying it", policy)
+		return copyFileAndChown(src, dst, mode, uid, gid)
+	}
+
+	return os.Chown(dst, int(uid), int(gid))
+}
+
+// linkFile hard-links src to dst. As both files share their owner and mode, src must already be
+// owned by the jail user and have the mode of dst.
+func linkFile(src, dst string, mode os.FileMode, uid, gid uint32) error {
+	var stat unix.Stat_t
+	if err := unix.Stat(src, &stat); err != nil {
+		return err
+	}
+	if stat.Uid != uid || stat.Gid != gid {
+		return fmt.Errorf("%q is owned by %d:%d instead of the jail user %d:%d", src, stat.Uid, stat.Gid, uid, gid)
+	}
+	if perm := os.FileMode(stat.Mode).Perm(); perm != mode.Perm() {
+		return fmt.Errorf("%q has mode %v instead of %v", src, perm, mode.Perm())
+	}
+	return os.Link(src, dst)
+}
+
+// reflinkFile clones src to a new file dst with FICLONE, which requires both files to be on
+// the same filesystem supporting reflinks. dst is removed if src cannot be cloned.
+func reflinkFile(src, dst string, mode os.FileMode) (err error) {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode.Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dstFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(dst)
+		}
+	}()
+
+	if err := unix.IoctlFileClone(int(dstFile.Fd()), int(srcFile.Fd())); err != nil {
+		return fmt.Errorf("failed to clone %q to %q: %w", src, dst, err)
+	}
+
+	// The mode of the new file is subject to the umask.
+	return dstFile.Chmod(mode.Perm())
+}
+
+// setupCacheTopology will copy indexed contents from the cacheTopologyPath to
+// the jailer. This is needed for arm architecture as arm does not
+// automatically setup any cache topology
+func setupCacheTopology(logger *logrus.Entry, path string, uid, gid uint32) error {
+	logger.WithField("path", path).Debug("Creating cache topology")
+	const mode = os.FileMode(0700)
+
+	// builds the cache topology path from the root directory
+	for _, p := range cacheTopologyPaths {
+		path = filepath.Join(path, p)
+		if err := mkdirAndChown(path, mode, uid, gid); err != nil {
+			return err
+		}
+	}
+
+	err := filepath.Walk(cacheTopologyPath, func(cachePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		folder := filepath.Base(cachePath)
+		if !strings.HasPrefix(folder, cacheFolderPrefix) {
+			return nil
+		}
+
+		indexPath := filepath.Join(path, folder)
+		if err := mkdirAndChown(indexPath, info.Mode(), uid, gid); err != nil {
+			return err
+		}
+
+		logger.WithField("src path", cachePath).WithField("dst path", indexPath).Debug("copying cache folder")
+		for _, file := range cacheTopologyFiles {
+			cacheFilePath := filepath.Join(cachePath, file)
+			info, err := os.Stat(cacheFilePath)
+			if err != nil {
+				return err
+			}
+
+			// This is suppose to be a hard copy as intructed by the Firecracker team.
+			// Bind mounting here may cause some issues on the host's machine
+			if err := copyFileAndChown(cacheFilePath, filepath.Join(indexPath, file), info.Mode(), uid, gid); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/integtest"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
@@ -91,6 +92,86 @@ func TestCopyFile_invalidPaths(t *testing.T) {
 	assert.Error(t, err, "copyFile should have returned an error")
 }
 
+func TestLinkOrCopyFileAndChown(t *testing.T) {
+	internal.RequiresRoot(t)
+	dir := t.TempDir()
+
+	// Owned by root and readable by everyone, so it cannot be linked to the jail as is.
+	src := filepath.Join(dir, "rootfs.img")
+	require.NoError(t, os.WriteFile(src, []byte("rootfs"), 0644))
+	require.NoError(t, os.Chmod(src, 0644))
+	// Already owned by the jail user with the mode of a writable drive.
+	jailSrc := filepath.Join(dir, "jail-rootfs.img")
+	require.NoError(t, os.WriteFile(jailSrc, []byte("rootfs"), 0600))
+	require.NoError(t, os.Chown(jailSrc, 123, 456))
+	// Already owned by the jail user with the mode of a read-only drive.
+	jailReadOnlySrc := filepath.Join(dir, "jail-ro-rootfs.img")
+	require.NoError(t, os.WriteFile(jailReadOnlySrc, []byte("rootfs"), 0400))
+	require.NoError(t, os.Chown(jailReadOnlySrc, 123, 456))
+	logger := logrus.NewEntry(logrus.New())
+
+	for _, tc := range []struct {
+		policy   proto.DriveExposePolicy
+		src      string
+		mode     os.FileMode
+		sameFile bool
+	}{
+		{proto.DriveExposePolicy_COPY, src, 0600, false},
+		{proto.DriveExposePolicy_LINK, src, 0600, false},
+		// The guest's writes to a writable drive must not reach its source.
+		{proto.DriveExposePolicy_LINK, jailSrc, 0600, false},
+		{proto.DriveExposePolicy_LINK, jailReadOnlySrc, 0400, true},
+		// Cloned or copied, depending on the filesystem of the test directory.
+		{proto.DriveExposePolicy_REFLINK, src, 0600, false},
+	} {
+		name := tc.policy.String() + " " + filepath.Base(tc.src)
+		srcInfo, err := os.Stat(tc.src)
+		require.NoError(t, err)
+		srcStat := *srcInfo.Sys().(*syscall.Stat_t)
+
+		dst := filepath.Join(dir, tc.policy.String()+"-"+filepath.Base(tc.src))
+		require.NoError(t, linkOrCopyFileAndChown(logger, tc.policy, tc.src, dst, tc.mode, 123, 456), name)
+
+		content, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		assert.Equal(t, "rootfs", string(content), name)
+
+		dstInfo, err := os.Stat(dst)
+		require.NoError(t, err)
+		assert.Equal(t, tc.sameFile, os.SameFile(srcInfo, dstInfo), name)
+		assert.Equal(t, tc.mode, dstInfo.Mode(), name)
+
+		stat := dstInfo.Sys().(*syscall.Stat_t)
+		assert.Equal(t, uint32(123), stat.Uid, name)
+		assert.Equal(t, uint32(456), stat.Gid, name)
+
+		srcInfo, err = os.Stat(tc.src)
+		require.NoError(t, err)
+		stat = srcInfo.Sys().(*syscall.Stat_t)
+		assert.Equal(t, srcStat.Uid, stat.Uid, "the owner of the source should be left as is: %s", name)
+		assert.Equal(t, srcStat.Gid, stat.Gid, "the group of the source should be left as is: %s", name)
+		assert.Equal(t, srcStat.Mode, stat.Mode, "the mode of the source should be left as is: %s", name)
+	}
+}
+
+func TestLinkOrCopyFileAndChown_fallback(t *testing.T) {
+	internal.RequiresRoot(t)
+	dir := t.TempDir()
+
+	// /proc can neither be linked nor cloned from.
+	dst := filepath.Join(dir, "version")
+	require.NoError(t, linkOrCopyFileAndChown(logrus.NewEntry(logrus.New()), proto.DriveExposePolicy_LINK, "/proc/version", dst, 0400, 123, 456))
+	content, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.NotEmpty(t, content, "the file should be copied")
+
+	dst = filepath.Join(dir, "reflinked")
+	src := filepath.Join(dir, "does-not-exist")
+	assert.Error(t, linkOrCopyFileAndChown(logrus.NewEntry(logrus.New()), proto.DriveExposePolicy_REFLINK, src, dst, 0400, 123, 456))
+	_, err = os.Stat(dst)
+	assert.True(t, os.IsNotExist(err), "a failed clone should not leave a file behind")
+}
+
 func TestJailer_invalidUIDGID(t *testing.T) {
 	req := proto.CreateVMRequest{
 		JailerConfig: &proto.JailerConfig{},
@@ -0,0 +1,304 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/fifo"
+	"github.com/containerd/ttrpc"
+	metrics "github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	// firecrackerMetricsPrefix prefixes the names of the metrics Firecracker writes to its metrics FIFO
+	firecrackerMetricsPrefix = "firecracker"
+
+	metricsServerReadHeaderTimeout = 2 * time.Second
+)
+
+var (
+	shimMetrics           = metrics.NewNamespace("firecracker_containerd", "shim", nil)
+	createVMTime          = shimMetrics.NewLabeledTimer("create_vm", "Time taken to create VMs", "result")
+	agentRPCTime          = shimMetrics.NewLabeledTimer("agent_rpc", "Time taken by the RPCs made to the VM agent", "method")
+	stubDriveReservations = shimMetrics.NewCounter("stub_drive_reservations", "Stub drives reserved for container rootfs and drive mounts")
+	stubDrivesReserved    = shimMetrics.NewGauge("stub_drives_reserved", "Stub drives currently reserved", metrics.Total)
+	ioProxyBytes          = shimMetrics.NewLabeledCounter("io_proxy_bytes", "Bytes proxied between the stdio of processes in the VM and their host FIFOs", "stream")
+	vsockDialRetries      = shimMetrics.NewCounter("vsock_dial_retries", "Vsock dials to the VM agent retried after a temporary failure")
+
+	// vmMetrics holds the metrics last written by Firecracker to its metrics FIFO
+	vmMetrics = newFirecrackerMetrics()
+)
+
+func init() {
+	metrics.Register(shimMetrics)
+	prometheus.MustRegister(vmMetrics)
+}
+
+// firecrackerMetrics is a prometheus.Collector exposing the metrics Firecracker writes to its
+// metrics FIFO as gauges. Firecracker periodically writes its metrics as a JSON object of groups
+// of metrics, each gauge is named after the path to its value in that object. Most of those values
+// count events since the previous time Firecracker wrote its metrics.
+type firecrackerMetrics struct {
+	mu     sync.Mutex
+	values map[string]float64
+	// sums holds the sum of the values of every flush. For the metrics counting events since
+	// the previous flush, these are totals since the VM started.
+	sums    map[string]float64
+	flushes uint64
+	// flushed is closed and replaced every time Firecracker writes its metrics.
+	flushed chan struct{}
+}
+
+func newFirecrackerMetrics() *firecrackerMetrics {
+	return &firecrackerMetrics{
+		values:  make(map[string]float64),
+		sums:    make(map[string]float64),
+		flushed: make(chan struct{}),
+	}
+}
+
+// nextFlush returns a channel closed once Firecracker next writes its metrics.
+func (m *firecrackerMetrics) nextFlush() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.flushed
+}
+
+// Describe implements prometheus.Collector. Nothing is described as the metrics depend on
+// the version of Firecracker and its devices, which makes the collector unchecked.
+func (m *firecrackerMetrics) Describe(_ chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector
+func (m *firecrackerMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, value := range m.values {
+		desc := prometheus.NewDesc(name, "Firecracker metric "+strings.TrimPrefix(name, firecrackerMetricsPrefix+"_"), nil, nil)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+}
+
+// update replaces the metrics with the ones in a JSON object written by Firecracker.
+func (m *firecrackerMetrics) update(raw map[string]interface{}) {
+	values := make(map[string]float64)
+	for group, value := range raw {
+		if group == "utc_timestamp_ms" {
+			continue
+		}
+		flattenMetrics(firecrackerMetricsPrefix+"_"+metricName(group), value, values)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values = values
+	for name, value := range values {
+		m.sums[name] += value
+	}
+	m.flushes++
+	close(m.flushed)
+	m.flushed = make(chan struct{})
+}
+
+// vmStats returns the totals of the VM-level metrics counted by Firecracker since the VM started.
+func (m *firecrackerMetrics) vmStats() *proto.FirecrackerVMMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sum := func(name string) uint64 {
+		return uint64(m.sums[firecrackerMetricsPrefix+"_"+name])
+	}
+	return &proto.FirecrackerVMMetrics{
+		Flushes:           m.flushes,
+		VcpuExitIoIn:      sum("vcpu_exit_io_in"),
+		VcpuExitIoOut:     sum("vcpu_exit_io_out"),
+		VcpuExitMmioRead:  sum("vcpu_exit_mmio_read"),
+		VcpuExitMmioWrite: sum("vcpu_exit_mmio_write"),
+		VcpuFailures:      sum("vcpu_failures"),
+		BlockReadBytes:    sum("block_read_bytes"),
+		BlockWriteBytes:   sum("block_write_bytes"),
+		BlockReadCount:    sum("block_read_count"),
+		BlockWriteCount:   sum("block_write_count"),
+		NetRxBytes:        sum("net_rx_bytes_count"),
+		NetTxBytes:        sum("net_tx_bytes_count"),
+		NetRxPackets:      sum("net_rx_packets_count"),
+		NetTxPackets:      sum("net_tx_packets_count"),
+	}
+}
+
+// flattenMetrics adds the numbers in value to values, named after their path from name.
+func flattenMetrics(name string, value interface{}, values map[string]float64) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flattenMetrics(name+"_"+metricName(key), child, values)
+		}
+	case float64:
+		values[name] = v
+	}
+}
+
+// metricName replaces the characters of a JSON key which aren't valid in Prometheus metric names,
+// such as the dashes of device IDs, with underscores.
+func metricName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// readFirecrackerMetrics reads the metrics Firecracker writes to the FIFO at path
+// until the FIFO is closed or ctx is canceled.
+func readFirecrackerMetrics(ctx context.Context, logger *logrus.Entry, path string, m *firecrackerMetrics) {
+	metricsFifo, err := fifo.OpenFifo(ctx, path, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		logger.WithError(err).Error("failed to open firecracker metrics fifo")
+		return
+	}
+	defer metricsFifo.Close()
+
+	decodeFirecrackerMetrics(ctx, logger, metricsFifo, m)
+}
+
+// decodeFirecrackerMetrics updates m with the metrics read from r until r is closed or ctx
+// is canceled. Firecracker writes its metrics as one JSON object per line, so a line which
+// can't be decoded is skipped and decoding resumes on the next one.
+func decodeFirecrackerMetrics(ctx context.Context, logger *logrus.Entry, r io.Reader, m *firecrackerMetrics) {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var raw map[string]interface{}
+			if decodeErr := json.Unmarshal(line, &raw); decodeErr != nil {
+				logger.WithError(decodeErr).Error("failed to decode firecracker metrics, skipping them")
+			} else {
+				m.update(raw)
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, os.ErrClosed) || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.WithError(err).Error("failed to read firecracker metrics")
+			return
+		}
+	}
+}
+
+// serveMetrics serves the shim's metrics, including the ones of the VM, at path until ctx is canceled.
+func serveMetrics(ctx context.Context, logger *logrus.Entry, path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           metrics.Handler(),
+		ReadHeaderTimeout: metricsServerReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.WithError(err).Error("metrics server error")
+		}
+	}()
+
+	return nil
+}
+
+// agentRPCInterceptor records the latency of the RPCs made to the VM agent.
+func agentRPCInterceptor(ctx context.Context, req *ttrpc.Request, resp *ttrpc.Response, info *ttrpc.UnaryClientInfo, invoker ttrpc.Invoker) error {
+	start := time.Now()
+	err := invoker(ctx, req, resp)
+	agentRPCTime.WithValues(strings.TrimPrefix(info.FullMethod, "/")).UpdateSince(start)
+	return err
+}
+
+func countVSockDialRetry() {
+	vsockDialRetries.Inc()
+}
+
+// countIO wraps an IOConnector so that the bytes read from and written to its connection are
+// counted as bytes of the provided stdio stream.
+func countIO(connector vm.IOConnector, stream string) vm.IOConnector {
+	return func(procCtx context.Context, logger *logrus.Entry) <-chan vm.IOConnectorResult {
+		returnCh := make(chan vm.IOConnectorResult, 1)
+
+		resultCh := connector(procCtx, logger)
+		go func() {
+			defer close(returnCh)
+
+			result := <-resultCh
+			if result.Err == nil && result.ReadWriteCloser != nil {
+				result.ReadWriteCloser = &countingReadWriteCloser{
+					ReadWriteCloser: result.ReadWriteCloser,
+					counter:         ioProxyBytes.WithValues(stream),
+				}
+			}
+			returnCh <- result
+		}()
+
+		return returnCh
+	}
+}
+
+type countingReadWriteCloser struct {
+	io.ReadWriteCloser
+	counter metrics.Counter
+}
+
+func (c *countingReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	if n > 0 {
+		c.counter.Inc(float64(n))
+	}
+	return n, err
+}
+
+func (c *countingReadWriteCloser) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	if n > 0 {
+		c.counter.Inc(float64(n))
+	}
+	return n, err
+}
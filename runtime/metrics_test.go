@@ -0,0 +1,114 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+const firecrackerMetricsJSON = `{
+	"utc_timestamp_ms": 1700000000000,
+	"api_server": {"process_startup_time_us": 1200, "sync_response_fails": 0},
+	"block_root-drive": {"read_count": 7},
+	"latencies_us": {"pause_vm": {"min_us": 10, "max_us": 30, "sum_us": 40}}
+}`
+
+func TestFirecrackerMetrics(t *testing.T) {
+	uut := newFirecrackerMetrics()
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(firecrackerMetricsJSON), &raw))
+	uut.update(raw)
+
+	expected := `# HELP firecracker_api_server_process_startup_time_us Firecracker metric api_server_process_startup_time_us
+# TYPE firecracker_api_server_process_startup_time_us gauge
+firecracker_api_server_process_startup_time_us 1200
+# HELP firecracker_block_root_drive_read_count Firecracker metric block_root_drive_read_count
+# TYPE firecracker_block_root_drive_read_count gauge
+firecracker_block_root_drive_read_count 7
+# HELP firecracker_latencies_us_pause_vm_max_us Firecracker metric latencies_us_pause_vm_max_us
+# TYPE firecracker_latencies_us_pause_vm_max_us gauge
+firecracker_latencies_us_pause_vm_max_us 30
+`
+	assert.NoError(t, testutil.CollectAndCompare(uut, strings.NewReader(expected),
+		"firecracker_api_server_process_startup_time_us",
+		"firecracker_block_root_drive_read_count",
+		"firecracker_latencies_us_pause_vm_max_us",
+	))
+	assert.Equal(t, 6, testutil.CollectAndCount(uut), "the timestamp shouldn't be exported")
+
+	// metrics missing from a later write are dropped
+	uut.update(map[string]interface{}{"api_server": map[string]interface{}{"sync_response_fails": 1.0}})
+	assert.Equal(t, 1, testutil.CollectAndCount(uut))
+}
+
+func TestDecodeFirecrackerMetrics(t *testing.T) {
+	uut := newFirecrackerMetrics()
+
+	// a malformed line doesn't stop the metrics written after it from being read
+	fifo := strings.NewReader(`{"block": {"read_count": 1}}
+{"block": {"read_count":
+{"block": {"read_count": 2}}
+`)
+	decodeFirecrackerMetrics(context.Background(), logrus.NewEntry(logrus.New()), fifo, uut)
+
+	stats := uut.vmStats()
+	assert.Equal(t, uint64(2), stats.Flushes)
+	assert.Equal(t, uint64(3), stats.BlockReadCount)
+}
+
+func TestCountIO(t *testing.T) {
+	var buf bytes.Buffer
+	connector := func(_ context.Context, _ *logrus.Entry) <-chan vm.IOConnectorResult {
+		returnCh := make(chan vm.IOConnectorResult, 1)
+		defer close(returnCh)
+		returnCh <- vm.IOConnectorResult{ReadWriteCloser: nopCloser{&buf}}
+		return returnCh
+	}
+
+	result := <-countIO(connector, "test")(context.Background(), logrus.NewEntry(logrus.New()))
+	require.NoError(t, result.Err)
+
+	_, err := io.WriteString(result, "hello")
+	require.NoError(t, err)
+	_, err = io.ReadAll(result)
+	require.NoError(t, err)
+
+	expected := `# HELP firecracker_containerd_shim_io_proxy_bytes_total Bytes proxied between the stdio of processes in the VM and their host FIFOs
+# TYPE firecracker_containerd_shim_io_proxy_bytes_total counter
+firecracker_containerd_shim_io_proxy_bytes_total{stream="test"} 10
+`
+	assert.NoError(t, testutil.CollectAndCompare(shimMetrics, strings.NewReader(expected),
+		"firecracker_containerd_shim_io_proxy_bytes_total"))
+}
+
+type nopCloser struct {
+	io.ReadWriter
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
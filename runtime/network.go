@@ -0,0 +1,240 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	guestnetwork "github.com/firecracker-microvm/firecracker-containerd/proto/service/guestnetwork/ttrpc"
+)
+
+// restartRequiredMessage explains why network interfaces cannot be added to or removed from a running VM.
+const restartRequiredMessage = "Firecracker cannot add or remove network interfaces of a running VM, " +
+	"the VM must be recreated with the new network interfaces"
+
+// GetVMNetwork returns the network interfaces of the VM as they were resolved when the VM started.
+func (s *service) GetVMNetwork(_ context.Context, _ *proto.GetVMNetworkRequest) (*proto.GetVMNetworkResponse, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	resp := &proto.GetVMNetworkResponse{VMID: s.currentVMID()}
+	for i, iface := range s.machine.Cfg.NetworkInterfaces {
+		resp.NetworkInterfaces = append(resp.NetworkInterfaces, vmNetworkInterface(i, iface))
+	}
+
+	return resp, nil
+}
+
+// AddNetworkInterface always fails, as Firecracker cannot add network interfaces to a running VM.
+// The rate limiters of the network interfaces the VM already has can be changed with UpdateRateLimiters.
+func (s *service) AddNetworkInterface(_ context.Context, req *proto.AddNetworkInterfaceRequest) (*proto.AddNetworkInterfaceResponse, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if req.NetworkInterface == nil {
+		return nil, status.Error(codes.InvalidArgument, "NetworkInterface must be set")
+	}
+	return nil, status.Error(codes.FailedPrecondition, restartRequiredMessage)
+}
+
+// RemoveNetworkInterface always fails, as Firecracker cannot remove network interfaces from a running VM.
+func (s *service) RemoveNetworkInterface(_ context.Context, req *proto.RemoveNetworkInterfaceRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if int(req.Index) >= len(s.createVMRequest.NetworkInterfaces) {
+		return nil, status.Errorf(codes.NotFound, "VM has no network interface at index %d", req.Index)
+	}
+	return nil, status.Error(codes.FailedPrecondition, restartRequiredMessage)
+}
+
+// vmNetworkInterface converts the configuration of the index-th network interface of a started VM.
+func vmNetworkInterface(index int, iface firecracker.NetworkInterface) *proto.VMNetworkInterface {
+	result := &proto.VMNetworkInterface{
+		Index:     uint32(index),
+		AllowMMDS: iface.AllowMMDS,
+	}
+
+	if cni := iface.CNIConfiguration; cni != nil {
+		result.CNINetworkName = cni.NetworkName
+		result.CNIInterfaceName = cni.IfName
+	}
+
+	static := iface.StaticConfiguration
+	if static == nil {
+		return result
+	}
+	result.TapName = static.HostDevName
+	result.MacAddress = static.MacAddress
+
+	if ip := static.IPConfiguration; ip != nil {
+		result.IPAddress = ip.IPAddr.String()
+		if ip.Gateway != nil {
+			result.Gateway = ip.Gateway.String()
+		}
+		result.Nameservers = ip.Nameservers
+		result.VMInterfaceName = ip.IfName
+	}
+
+	return result
+}
+
+// maxKernelNameservers is the number of nameservers the kernel can provide to the VM via /proc/net/pnp.
+const maxKernelNameservers = 2
+
+// hasGuestInterfaceConfig returns whether the agent needs to configure the network interface itself
+// inside the VM, as opposed to the kernel configuring it at boot.
+func hasGuestInterfaceConfig(ipConf *proto.IPConfiguration) bool {
+	return len(ipConf.AdditionalAddrs) > 0 || ipConf.IPv6GatewayAddr != "" || len(ipConf.Routes) > 0
+}
+
+// hasGuestDNSConfig returns whether the agent needs to write the DNS configuration of the VM itself,
+// as the kernel cannot provide it via /proc/net/pnp.
+func hasGuestDNSConfig(ipConf *proto.IPConfiguration) bool {
+	if len(ipConf.SearchDomains) > 0 {
+		return true
+	}
+	if len(ipConf.Nameservers) > 0 && ipConf.PrimaryAddr == "" {
+		return true
+	}
+	for _, nameserver := range ipConf.Nameservers {
+		if ip := net.ParseIP(nameserver); ip != nil && ip.To4() == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// kernelNameservers returns the nameservers provided to the VM via /proc/net/pnp. When the agent
+// writes the DNS configuration, the kernel is only provided the IPv4 nameservers it supports.
+func kernelNameservers(ipConf *proto.IPConfiguration) []string {
+	if !hasGuestDNSConfig(ipConf) {
+		return ipConf.Nameservers
+	}
+
+	var nameservers []string
+	for _, nameserver := range ipConf.Nameservers {
+		if ip := net.ParseIP(nameserver); ip != nil && ip.To4() != nil && len(nameservers) < maxKernelNameservers {
+			nameservers = append(nameservers, nameserver)
+		}
+	}
+	return nameservers
+}
+
+// validateGuestIPConfig validates the parts of a static IP configuration applied by the agent.
+func validateGuestIPConfig(staticConf *proto.StaticNetworkConfiguration) error {
+	ipConf := staticConf.IPConfig
+	if hasGuestInterfaceConfig(ipConf) && staticConf.MacAddress == "" {
+		return errors.New("MacAddress must be set to configure additional addresses, an IPv6 gateway or routes")
+	}
+
+	for _, addr := range ipConf.AdditionalAddrs {
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return fmt.Errorf("failed to parse CIDR from %q: %w", addr, err)
+		}
+	}
+
+	if gateway := ipConf.IPv6GatewayAddr; gateway != "" {
+		if ip := net.ParseIP(gateway); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid IPv6 gateway %q", gateway)
+		}
+	}
+
+	for _, route := range ipConf.Routes {
+		if _, _, err := net.ParseCIDR(route.Destination); err != nil {
+			return fmt.Errorf("failed to parse route destination from %q: %w", route.Destination, err)
+		}
+		if route.GatewayAddr != "" && net.ParseIP(route.GatewayAddr) == nil {
+			return fmt.Errorf("invalid route gateway %q", route.GatewayAddr)
+		}
+	}
+
+	for _, nameserver := range ipConf.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("invalid nameserver %q", nameserver)
+		}
+	}
+
+	return nil
+}
+
+// guestNetworkRequest returns the parts of the static IP configuration of the network interfaces
+// applied by the agent, or nil if there are none.
+func guestNetworkRequest(ifaces []*proto.FirecrackerNetworkInterface) *guestnetwork.ConfigureNetworkRequest {
+	req := &guestnetwork.ConfigureNetworkRequest{}
+	dns := &guestnetwork.GuestDNSConfiguration{}
+	for _, iface := range ifaces {
+		ipConf := iface.GetStaticConfig().GetIPConfig()
+		if ipConf == nil {
+			continue
+		}
+
+		if hasGuestInterfaceConfig(ipConf) {
+			req.NetworkInterfaces = append(req.NetworkInterfaces, &guestnetwork.GuestNetworkInterface{
+				MacAddress:      iface.StaticConfig.MacAddress,
+				Addrs:           ipConf.AdditionalAddrs,
+				IPv6GatewayAddr: ipConf.IPv6GatewayAddr,
+				Routes:          ipConf.Routes,
+			})
+		}
+
+		if hasGuestDNSConfig(ipConf) {
+			dns.Nameservers = appendMissing(dns.Nameservers, ipConf.Nameservers...)
+			dns.SearchDomains = appendMissing(dns.SearchDomains, ipConf.SearchDomains...)
+		}
+	}
+
+	if len(dns.Nameservers) > 0 || len(dns.SearchDomains) > 0 {
+		req.DNS = dns
+	}
+	if len(req.NetworkInterfaces) == 0 && req.DNS == nil {
+		return nil
+	}
+	return req
+}
+
+// appendMissing appends the values which are not in the slice yet.
+func appendMissing(slice []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(slice, value) {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
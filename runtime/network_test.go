@@ -0,0 +1,153 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/containerd/log"
+	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	guestnetwork "github.com/firecracker-microvm/firecracker-containerd/proto/service/guestnetwork/ttrpc"
+)
+
+func TestVMNetworkInterface(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("10.0.0.2/24")
+	require.NoError(t, err)
+	ipNet.IP = net.ParseIP("10.0.0.2")
+
+	iface := vmNetworkInterface(1, firecracker.NetworkInterface{
+		CNIConfiguration: &firecracker.CNIConfiguration{NetworkName: "fcnet", IfName: "veth0"},
+		StaticConfiguration: &firecracker.StaticNetworkConfiguration{
+			HostDevName: "tap0",
+			MacAddress:  "aa:bb:cc:dd:ee:ff",
+			IPConfiguration: &firecracker.IPConfiguration{
+				IPAddr:      *ipNet,
+				Gateway:     net.ParseIP("10.0.0.1"),
+				Nameservers: []string{"8.8.8.8"},
+				IfName:      "eth0",
+			},
+		},
+		AllowMMDS: true,
+	})
+
+	assert.Equal(t, &proto.VMNetworkInterface{
+		Index:            1,
+		TapName:          "tap0",
+		MacAddress:       "aa:bb:cc:dd:ee:ff",
+		CNINetworkName:   "fcnet",
+		CNIInterfaceName: "veth0",
+		IPAddress:        "10.0.0.2/24",
+		Gateway:          "10.0.0.1",
+		Nameservers:      []string{"8.8.8.8"},
+		VMInterfaceName:  "eth0",
+		AllowMMDS:        true,
+	}, iface)
+
+	assert.Equal(t, &proto.VMNetworkInterface{}, vmNetworkInterface(0, firecracker.NetworkInterface{}))
+}
+
+func TestAddAndRemoveNetworkInterface(t *testing.T) {
+	vmReady := make(chan struct{})
+	close(vmReady)
+	uut := &service{
+		logger:  log.G(context.Background()),
+		vmReady: vmReady,
+		createVMRequest: &proto.CreateVMRequest{
+			NetworkInterfaces: []*proto.FirecrackerNetworkInterface{
+				{StaticConfig: &proto.StaticNetworkConfiguration{HostDevName: "tap0", MacAddress: "aa:bb:cc:dd:ee:ff"}},
+			},
+		},
+	}
+
+	_, err := uut.AddNetworkInterface(context.Background(), &proto.AddNetworkInterfaceRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = uut.AddNetworkInterface(context.Background(), &proto.AddNetworkInterfaceRequest{
+		NetworkInterface: &proto.FirecrackerNetworkInterface{
+			StaticConfig:  &proto.StaticNetworkConfiguration{HostDevName: "tap0", MacAddress: "aa:bb:cc:dd:ee:ff"},
+			InRateLimiter: &proto.FirecrackerRateLimiter{Bandwidth: &proto.FirecrackerTokenBucket{Capacity: 1024}},
+		},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "even the rate limiters of an existing interface cannot be changed")
+
+	_, err = uut.RemoveNetworkInterface(context.Background(), &proto.RemoveNetworkInterfaceRequest{Index: 0})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = uut.RemoveNetworkInterface(context.Background(), &proto.RemoveNetworkInterfaceRequest{Index: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGuestNetworkRequest(t *testing.T) {
+	assert.Nil(t, guestNetworkRequest([]*proto.FirecrackerNetworkInterface{{
+		StaticConfig: &proto.StaticNetworkConfiguration{
+			MacAddress: "AA:FC:00:00:00:01",
+			IPConfig: &proto.IPConfiguration{
+				PrimaryAddr: "198.51.100.2/24",
+				GatewayAddr: "198.51.100.1",
+				Nameservers: []string{"192.0.2.1"},
+			},
+		},
+	}}), "the kernel configuration should be enough")
+
+	routes := []*proto.IPRoute{{Destination: "203.0.113.0/24", GatewayAddr: "198.51.100.254", Metric: 10}}
+	req := guestNetworkRequest([]*proto.FirecrackerNetworkInterface{
+		{
+			StaticConfig: &proto.StaticNetworkConfiguration{
+				MacAddress: "AA:FC:00:00:00:01",
+				IPConfig: &proto.IPConfiguration{
+					PrimaryAddr:     "198.51.100.2/24",
+					GatewayAddr:     "198.51.100.1",
+					AdditionalAddrs: []string{"198.51.100.3/24", "2001:db8::2/64"},
+					IPv6GatewayAddr: "2001:db8::1",
+					Routes:          routes,
+					Nameservers:     []string{"2001:db8::53", "192.0.2.1"},
+					SearchDomains:   []string{"example.com"},
+				},
+			},
+		},
+		{
+			CNIConfig: &proto.CNIConfiguration{NetworkName: "fcnet", InterfaceName: "veth0"},
+		},
+		{
+			StaticConfig: &proto.StaticNetworkConfiguration{
+				MacAddress: "AA:FC:00:00:00:03",
+				IPConfig: &proto.IPConfiguration{
+					Nameservers:   []string{"192.0.2.1", "192.0.2.2"},
+					SearchDomains: []string{"example.com", "example.org"},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, &guestnetwork.ConfigureNetworkRequest{
+		NetworkInterfaces: []*guestnetwork.GuestNetworkInterface{{
+			MacAddress:      "AA:FC:00:00:00:01",
+			Addrs:           []string{"198.51.100.3/24", "2001:db8::2/64"},
+			IPv6GatewayAddr: "2001:db8::1",
+			Routes:          routes,
+		}},
+		DNS: &guestnetwork.GuestDNSConfiguration{
+			Nameservers:   []string{"2001:db8::53", "192.0.2.1", "192.0.2.2"},
+			SearchDomains: []string{"example.com", "example.org"},
+		},
+	}, req)
+}
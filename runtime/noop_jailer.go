@@ -15,9 +15,7 @@ package main
 
 import (
 	"context"
-	"errors"
 	"os"
-	"syscall"
 
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	"github.com/sirupsen/logrus"
@@ -96,31 +94,18 @@ func (j *noopJailer) ExposeFileToJail(_ string) error {
 	return nil
 }
 
+func (j *noopJailer) RemoveFileFromJail(_ string) error {
+	j.logger.Debug("noop operation for RemoveFileFromJail")
+	return nil
+}
+
 func (j *noopJailer) StubDrivesOptions() []FileOpt {
 	j.logger.Debug("noop operation for StubDrivesOptions")
 	return []FileOpt{}
 }
 
 func (j *noopJailer) Stop(force bool) error {
-	if j.pid == 0 {
-		return errors.New("the machine has not been started")
-	}
-
-	signal := syscall.SIGTERM
-	if force {
-		signal = syscall.SIGKILL
-	}
-	j.logger.Debugf("sending signal %d to %d", signal, j.pid)
-	p, err := os.FindProcess(j.pid)
-	if err != nil {
-		return err
-	}
-
-	err = p.Signal(signal)
-	if err == nil || err.Error() == "os: process already finished" {
-		return nil
-	}
-	return err
+	return stopProcess(j.logger, j.pid, force)
 }
 
 func (j *noopJailer) Close() error {
@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// rootDriveVMPath identifies the root drive in UpdateRateLimiters requests.
+const rootDriveVMPath = "/"
+
+// UpdateRateLimiters replaces the rate limiters of drives and network interfaces of the running VM.
+func (s *service) UpdateRateLimiters(requestCtx context.Context, req *proto.UpdateRateLimitersRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	s.log().Infof("Updating rate limiters of %d drives and %d network interfaces", len(req.Drives), len(req.NetworkInterfaces))
+	if err := s.updateRateLimiters(requestCtx, req); err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	return &types.Empty{}, nil
+}
+
+// updateRateLimiters patches the rate limiters of the drives and network interfaces of the request.
+// Every target is validated before any of them is patched.
+func (s *service) updateRateLimiters(ctx context.Context, req *proto.UpdateRateLimitersRequest) error {
+	driveIDs, err := s.rateLimitedDriveIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, drive := range req.Drives {
+		if _, ok := driveIDs[drive.VMPath]; !ok {
+			return status.Errorf(codes.NotFound, "VM has no drive mounted at %q", drive.VMPath)
+		}
+	}
+
+	for _, iface := range req.NetworkInterfaces {
+		if int(iface.Index) >= len(s.machineConfig.NetworkInterfaces) {
+			return status.Errorf(codes.NotFound, "VM has no network interface at index %d", iface.Index)
+		}
+	}
+
+	for _, drive := range req.Drives {
+		if err := updateDriveRateLimiter(ctx, s.machine, driveIDs[drive.VMPath], drive.RateLimiter); err != nil {
+			return fmt.Errorf("failed to update rate limiter of drive mounted at %q: %w", drive.VMPath, err)
+		}
+	}
+
+	for _, iface := range req.NetworkInterfaces {
+		if err := updateNetworkInterfaceRateLimiters(ctx, s.machine, iface); err != nil {
+			return fmt.Errorf("failed to update rate limiters of network interface %d: %w", iface.Index, err)
+		}
+	}
+
+	return nil
+}
+
+// rateLimitedDriveIDs returns the Firecracker IDs of the drives whose rate limiters can be updated,
+// keyed by the path they are mounted at in the VM. Container drives and drives attached with
+// AttachDrive are not included as they are backed by stub drives shared between containers.
+func (s *service) rateLimitedDriveIDs() (map[string]string, error) {
+	driveIDs := make(map[string]string)
+	for _, drive := range s.machineConfig.Drives {
+		if firecracker.BoolValue(drive.IsRootDevice) {
+			driveIDs[rootDriveVMPath] = firecracker.StringValue(drive.DriveID)
+		}
+	}
+
+	driveMountStubs, err := mountableStubDrives(s.driveMountStubs)
+	if err != nil {
+		return nil, err
+	}
+	for _, drive := range driveMountStubs {
+		driveIDs[drive.driveMount.VMPath] = drive.driveID
+	}
+
+	return driveIDs, nil
+}
+
+// updateDriveRateLimiter patches the rate limiter of a drive, leaving its backing file as is.
+func updateDriveRateLimiter(ctx context.Context, machine firecracker.MachineIface, driveID string, rl *proto.FirecrackerRateLimiter) error {
+	if rl == nil {
+		return nil
+	}
+
+	return machine.UpdateGuestDrive(ctx, driveID, "", func(params *ops.PatchGuestDriveByIDParams) {
+		params.Body.RateLimiter = rateLimiterFromProto(rl)
+	})
+}
+
+// updateNetworkInterfaceRateLimiters patches the rate limiters of a network interface. The SDK
+// names network interfaces after their 1-based index in the machine configuration.
+func updateNetworkInterfaceRateLimiters(ctx context.Context, machine firecracker.MachineIface, iface *proto.NetworkInterfaceRateLimiterUpdate) error {
+	if iface.InRateLimiter == nil && iface.OutRateLimiter == nil {
+		return nil
+	}
+
+	ifaceID := strconv.Itoa(int(iface.Index) + 1)
+	// The rate limiters are set on the request body directly as UpdateGuestNetworkInterfaceRateLimit
+	// sets the outgoing rate limiter to the incoming one.
+	return machine.UpdateGuestNetworkInterfaceRateLimit(ctx, ifaceID, firecracker.RateLimiterSet{},
+		func(params *ops.PatchGuestNetworkInterfaceByIDParams) {
+			params.Body.RxRateLimiter = rateLimiterFromProto(iface.InRateLimiter)
+			params.Body.TxRateLimiter = rateLimiterFromProto(iface.OutRateLimiter)
+		})
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/log"
+	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func newRateLimitedService(t *testing.T, client *fctesting.MockClient) *service {
+	machine, err := firecracker.NewMachine(context.Background(), firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(client))))
+	require.NoError(t, err, "failed to create new machine")
+
+	return &service{
+		logger:  log.G(context.Background()),
+		machine: machine,
+		machineConfig: &firecracker.Config{
+			Drives: []models.Drive{{
+				DriveID:      firecracker.String("root_drive"),
+				IsRootDevice: firecracker.Bool(true),
+			}},
+			NetworkInterfaces: firecracker.NetworkInterfaces{{}, {}},
+		},
+		driveMountStubs: []MountableStubDrive{stubDrive{
+			driveID:    "data_drive",
+			driveMount: &proto.FirecrackerDriveMount{VMPath: "/data"},
+		}},
+	}
+}
+
+func TestUpdateRateLimiters(t *testing.T) {
+	patchedDrives := make(map[string]*models.RateLimiter)
+	patchedIfaces := make(map[string]*models.PartialNetworkInterface)
+	uut := newRateLimitedService(t, &fctesting.MockClient{
+		PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+			assert.Empty(t, params.Body.PathOnHost, "backing file of the drive should not be patched")
+			patchedDrives[params.DriveID] = params.Body.RateLimiter
+			return nil, nil
+		},
+		PatchGuestNetworkInterfaceByIDFn: func(params *ops.PatchGuestNetworkInterfaceByIDParams) (*ops.PatchGuestNetworkInterfaceByIDNoContent, error) {
+			patchedIfaces[params.IfaceID] = params.Body
+			return nil, nil
+		},
+	})
+
+	limiter := &proto.FirecrackerRateLimiter{
+		Bandwidth: &proto.FirecrackerTokenBucket{Capacity: 1024, RefillTime: 100},
+	}
+	err := uut.updateRateLimiters(context.Background(), &proto.UpdateRateLimitersRequest{
+		Drives: []*proto.DriveRateLimiterUpdate{
+			{VMPath: "/", RateLimiter: limiter},
+			{VMPath: "/data", RateLimiter: limiter},
+		},
+		NetworkInterfaces: []*proto.NetworkInterfaceRateLimiterUpdate{
+			{Index: 1, OutRateLimiter: limiter},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, patchedDrives, 2)
+	for _, driveID := range []string{"root_drive", "data_drive"} {
+		require.NotNil(t, patchedDrives[driveID], "drive %s should have been patched", driveID)
+		assert.Equal(t, int64(1024), firecracker.Int64Value(patchedDrives[driveID].Bandwidth.Size))
+		assert.Equal(t, int64(100), firecracker.Int64Value(patchedDrives[driveID].Bandwidth.RefillTime))
+	}
+
+	require.Len(t, patchedIfaces, 1)
+	iface := patchedIfaces["2"]
+	require.NotNil(t, iface, "second network interface should have been patched")
+	assert.Nil(t, iface.RxRateLimiter)
+	require.NotNil(t, iface.TxRateLimiter)
+	assert.Equal(t, int64(1024), firecracker.Int64Value(iface.TxRateLimiter.Bandwidth.Size))
+}
+
+func TestUpdateRateLimitersUnknownTargets(t *testing.T) {
+	patched := false
+	uut := newRateLimitedService(t, &fctesting.MockClient{
+		PatchGuestDriveByIDFn: func(*ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+			patched = true
+			return nil, nil
+		},
+	})
+
+	limiter := &proto.FirecrackerRateLimiter{Ops: &proto.FirecrackerTokenBucket{Capacity: 10}}
+	err := uut.updateRateLimiters(context.Background(), &proto.UpdateRateLimitersRequest{
+		Drives: []*proto.DriveRateLimiterUpdate{
+			{VMPath: "/data", RateLimiter: limiter},
+			{VMPath: "/missing", RateLimiter: limiter},
+		},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = uut.updateRateLimiters(context.Background(), &proto.UpdateRateLimitersRequest{
+		Drives:            []*proto.DriveRateLimiterUpdate{{VMPath: "/data", RateLimiter: limiter}},
+		NetworkInterfaces: []*proto.NetworkInterfaceRateLimiterUpdate{{Index: 2, InRateLimiter: limiter}},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.False(t, patched, "no drive should be patched when a target is unknown")
+}
@@ -0,0 +1,260 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const userNamespaceRuncName = "user"
+
+// capabilityNamePattern matches the names of capabilities, such as CAP_NET_ADMIN.
+var capabilityNamePattern = regexp.MustCompile(`^CAP_[A-Z_]+$`)
+
+// firecrackerSyscalls are the system calls allowed by the default seccomp profile. They are the
+// system calls made by Firecracker, which installs its own, stricter, filters once started, along
+// with the ones made by runc between the installation of the profile and the execution of Firecracker.
+// The system calls unknown to an architecture are ignored by runc.
+var firecrackerSyscalls = []string{
+	"accept4", "access", "arch_prctl", "bind", "brk", "capget", "chdir", "clock_gettime",
+	"clock_nanosleep", "clone", "clone3", "close", "close_range", "connect", "dup", "dup2", "dup3",
+	"epoll_create1", "epoll_ctl", "epoll_pwait", "epoll_wait", "eventfd2", "execve", "exit",
+	"exit_group", "faccessat", "faccessat2", "fallocate", "fcntl", "fdatasync", "fstat", "fstatfs",
+	"fsync", "ftruncate", "futex", "getcwd", "getdents64", "getegid", "geteuid", "getgid", "getpid",
+	"getppid", "getrandom", "getrlimit", "getsockname", "getsockopt", "gettid", "gettimeofday",
+	"getuid", "io_uring_enter", "io_uring_register", "io_uring_setup", "ioctl", "kill", "listen",
+	"lseek", "lstat", "madvise", "membarrier", "memfd_create", "mkdir", "mkdirat", "mmap", "mprotect",
+	"mremap", "munmap", "nanosleep", "newfstatat", "open", "openat", "pipe", "pipe2", "poll", "ppoll",
+	"prctl", "pread64", "preadv", "prlimit64", "pwrite64", "pwritev", "read", "readlink",
+	"readlinkat", "readv", "recvfrom", "recvmsg", "restart_syscall", "rseq", "rt_sigaction",
+	"rt_sigprocmask", "rt_sigreturn", "sched_getaffinity", "sched_yield", "seccomp", "sendmsg",
+	"sendto", "set_robust_list", "set_tid_address", "setsockopt", "shutdown", "sigaltstack",
+	"socket", "socketpair", "stat", "statx", "sysinfo", "tgkill", "timerfd_create",
+	"timerfd_settime", "tkill", "uname", "unlink", "unlinkat", "write", "writev",
+}
+
+// defaultSeccompProfile returns the seccomp profile allowing the system calls needed by
+// Firecracker only, and failing the other ones with EPERM.
+func defaultSeccompProfile() *specs.LinuxSeccomp {
+	syscalls := make([]string, len(firecrackerSyscalls))
+	copy(syscalls, firecrackerSyscalls)
+
+	return &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Architectures: []specs.Arch{specs.ArchX86_64, specs.ArchAARCH64},
+		Syscalls: []specs.LinuxSyscall{{
+			Names:  syscalls,
+			Action: specs.ActAllow,
+		}},
+	}
+}
+
+// runcHardening restricts the privileges of the Firecracker VMM jailed by runc beyond the
+// runc config template.
+type runcHardening struct {
+	// Seccomp is the seccomp profile of the VMM. The profile of the template is kept if nil.
+	Seccomp *specs.LinuxSeccomp
+	// DropCapabilities limits the capabilities of the VMM to Capabilities.
+	DropCapabilities bool
+	Capabilities     []string
+	// UIDMappings and GIDMappings run the VMM in a new user namespace if set.
+	UIDMappings []specs.LinuxIDMapping
+	GIDMappings []specs.LinuxIDMapping
+}
+
+// isSet returns whether any hardening option is set.
+func (h runcHardening) isSet() bool {
+	return h.Seccomp != nil || h.DropCapabilities || len(h.UIDMappings) > 0
+}
+
+// runcHardeningFromProto validates the hardening options of a jailer config and loads its
+// seccomp profile.
+func runcHardeningFromProto(cfg *proto.JailerConfig) (runcHardening, error) {
+	var h runcHardening
+
+	switch cfg.SeccompPolicy {
+	case proto.SeccompPolicy_TEMPLATE_SECCOMP, proto.SeccompPolicy_DEFAULT_SECCOMP:
+		if cfg.SeccompProfilePath != "" {
+			return runcHardening{}, fmt.Errorf("SeccompProfilePath cannot be set with the %s policy", cfg.SeccompPolicy)
+		}
+		if cfg.SeccompPolicy == proto.SeccompPolicy_DEFAULT_SECCOMP {
+			h.Seccomp = defaultSeccompProfile()
+		}
+	case proto.SeccompPolicy_CUSTOM_SECCOMP:
+		profile, err := loadSeccompProfile(cfg.SeccompProfilePath)
+		if err != nil {
+			return runcHardening{}, err
+		}
+		h.Seccomp = profile
+	default:
+		return runcHardening{}, fmt.Errorf("invalid seccomp policy %s", cfg.SeccompPolicy)
+	}
+
+	if !cfg.DropCapabilities && len(cfg.AllowedCapabilities) > 0 {
+		return runcHardening{}, errors.New("AllowedCapabilities cannot be set without DropCapabilities")
+	}
+	h.DropCapabilities = cfg.DropCapabilities
+	for _, name := range cfg.AllowedCapabilities {
+		capability := strings.ToUpper(name)
+		if !strings.HasPrefix(capability, "CAP_") {
+			capability = "CAP_" + capability
+		}
+		if !capabilityNamePattern.MatchString(capability) {
+			return runcHardening{}, fmt.Errorf("invalid capability %q", name)
+		}
+		h.Capabilities = append(h.Capabilities, capability)
+	}
+
+	if len(cfg.UIDMappings) == 0 && len(cfg.GIDMappings) == 0 {
+		return h, nil
+	}
+	if len(cfg.UIDMappings) == 0 || len(cfg.GIDMappings) == 0 {
+		return runcHardening{}, errors.New("UIDMappings and GIDMappings must be set together")
+	}
+	h.UIDMappings = idMappingsFromProto(cfg.UIDMappings)
+	h.GIDMappings = idMappingsFromProto(cfg.GIDMappings)
+
+	for _, ids := range []struct {
+		kind     string
+		mappings []specs.LinuxIDMapping
+		hostID   uint32
+	}{{"UID", h.UIDMappings, cfg.UID}, {"GID", h.GIDMappings, cfg.GID}} {
+		for _, m := range ids.mappings {
+			if m.Size == 0 {
+				return runcHardening{}, fmt.Errorf("%s mapping of %d to %d has no IDs", ids.kind, m.ContainerID, m.HostID)
+			}
+		}
+		if _, ok := hostIDOf(ids.mappings, 0); !ok {
+			return runcHardening{}, fmt.Errorf("%s mappings must map the root of the user namespace", ids.kind)
+		}
+		containerID, ok := containerIDOf(ids.mappings, ids.hostID)
+		if !ok {
+			return runcHardening{}, fmt.Errorf("%s mappings must map the jailer %s %d", ids.kind, ids.kind, ids.hostID)
+		}
+		if containerID == 0 {
+			return runcHardening{}, fmt.Errorf("jailer %s %d cannot be mapped to the root of the user namespace", ids.kind, ids.hostID)
+		}
+	}
+
+	return h, nil
+}
+
+// loadSeccompProfile reads a seccomp profile formatted as the "seccomp" object of a runtime spec.
+func loadSeccompProfile(path string) (*specs.LinuxSeccomp, error) {
+	if path == "" {
+		return nil, errors.New("SeccompProfilePath must be set with the CUSTOM_SECCOMP policy")
+	}
+
+	profileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seccomp profile %s: %w", path, err)
+	}
+
+	var profile specs.LinuxSeccomp
+	if err := json.Unmarshal(profileBytes, &profile); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal seccomp profile %s: %w", path, err)
+	}
+	if profile.DefaultAction == "" {
+		return nil, fmt.Errorf("seccomp profile %s has no defaultAction", path)
+	}
+
+	return &profile, nil
+}
+
+func idMappingsFromProto(mappings []*proto.IDMapping) []specs.LinuxIDMapping {
+	result := make([]specs.LinuxIDMapping, 0, len(mappings))
+	for _, m := range mappings {
+		result = append(result, specs.LinuxIDMapping{
+			ContainerID: m.ContainerID,
+			HostID:      m.HostID,
+			Size:        m.Size,
+		})
+	}
+	return result
+}
+
+// containerIDOf returns the ID of a user namespace mapped to the provided host ID.
+func containerIDOf(mappings []specs.LinuxIDMapping, hostID uint32) (uint32, bool) {
+	for _, m := range mappings {
+		if hostID >= m.HostID && uint64(hostID) < uint64(m.HostID)+uint64(m.Size) {
+			return m.ContainerID + (hostID - m.HostID), true
+		}
+	}
+	return 0, false
+}
+
+// hostIDOf returns the host ID mapped to the provided ID of a user namespace.
+func hostIDOf(mappings []specs.LinuxIDMapping, containerID uint32) (uint32, bool) {
+	for _, m := range mappings {
+		if containerID >= m.ContainerID && uint64(containerID) < uint64(m.ContainerID)+uint64(m.Size) {
+			return m.HostID + (containerID - m.ContainerID), true
+		}
+	}
+	return 0, false
+}
+
+// applyToSpec sets the hardening options on a runc spec. The user of the process of the spec
+// must be set to the host IDs of the jailer beforehand, since they are translated to the IDs
+// of the user namespace.
+func (h runcHardening) applyToSpec(spec *specs.Spec) {
+	if h.Seccomp != nil {
+		spec.Linux.Seccomp = h.Seccomp
+	}
+
+	if h.DropCapabilities {
+		capabilities := h.Capabilities
+		if capabilities == nil {
+			capabilities = []string{}
+		}
+		spec.Process.Capabilities = &specs.LinuxCapabilities{
+			Bounding:    capabilities,
+			Effective:   capabilities,
+			Inheritable: capabilities,
+			Permitted:   capabilities,
+			// The process doesn't run as root, so it loses the capabilities it has
+			// unless they are also ambient.
+			Ambient: capabilities,
+		}
+	}
+
+	if len(h.UIDMappings) == 0 {
+		return
+	}
+
+	hasUserNamespace := false
+	for _, ns := range spec.Linux.Namespaces {
+		if ns.Type == userNamespaceRuncName {
+			hasUserNamespace = true
+			break
+		}
+	}
+	if !hasUserNamespace {
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, specs.LinuxNamespace{Type: userNamespaceRuncName})
+	}
+	spec.Linux.UIDMappings = h.UIDMappings
+	spec.Linux.GIDMappings = h.GIDMappings
+
+	// Both IDs were checked to be mapped by runcHardeningFromProto.
+	spec.Process.User.UID, _ = containerIDOf(h.UIDMappings, spec.Process.User.UID)
+	spec.Process.User.GID, _ = containerIDOf(h.GIDMappings, spec.Process.User.GID)
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestRuncHardeningFromProto(t *testing.T) {
+	h, err := runcHardeningFromProto(&proto.JailerConfig{UID: 123, GID: 456})
+	require.NoError(t, err)
+	assert.Equal(t, runcHardening{}, h)
+	assert.False(t, h.isSet())
+
+	h, err = runcHardeningFromProto(&proto.JailerConfig{
+		UID:                 123,
+		GID:                 456,
+		SeccompPolicy:       proto.SeccompPolicy_DEFAULT_SECCOMP,
+		DropCapabilities:    true,
+		AllowedCapabilities: []string{"net_admin", "CAP_SYS_RESOURCE"},
+		UIDMappings:         []*proto.IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}, {ContainerID: 65536, HostID: 123, Size: 1}},
+		GIDMappings:         []*proto.IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}, {ContainerID: 65536, HostID: 456, Size: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultSeccompProfile(), h.Seccomp)
+	assert.Equal(t, []string{"CAP_NET_ADMIN", "CAP_SYS_RESOURCE"}, h.Capabilities)
+	assert.Len(t, h.UIDMappings, 2)
+	assert.True(t, h.isSet())
+
+	profilePath := filepath.Join(t.TempDir(), "seccomp.json")
+	require.NoError(t, os.WriteFile(profilePath, []byte(`{"defaultAction": "SCMP_ACT_KILL", "syscalls": [{"names": ["read"], "action": "SCMP_ACT_ALLOW"}]}`), 0600))
+	h, err = runcHardeningFromProto(&proto.JailerConfig{SeccompPolicy: proto.SeccompPolicy_CUSTOM_SECCOMP, SeccompProfilePath: profilePath})
+	require.NoError(t, err)
+	assert.Equal(t, specs.ActKill, h.Seccomp.DefaultAction)
+	assert.Equal(t, []string{"read"}, h.Seccomp.Syscalls[0].Names)
+
+	invalidProfilePath := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalidProfilePath, []byte(`{"syscalls": []}`), 0600))
+
+	rootOnly := []*proto.IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	for name, cfg := range map[string]*proto.JailerConfig{
+		"path with default seccomp":   {SeccompPolicy: proto.SeccompPolicy_DEFAULT_SECCOMP, SeccompProfilePath: profilePath},
+		"custom seccomp without path": {SeccompPolicy: proto.SeccompPolicy_CUSTOM_SECCOMP},
+		"missing seccomp profile":     {SeccompPolicy: proto.SeccompPolicy_CUSTOM_SECCOMP, SeccompProfilePath: "/does/not/exist.json"},
+		"profile without action":      {SeccompPolicy: proto.SeccompPolicy_CUSTOM_SECCOMP, SeccompProfilePath: invalidProfilePath},
+		"capabilities without drop":   {AllowedCapabilities: []string{"CAP_NET_ADMIN"}},
+		"invalid capability":          {DropCapabilities: true, AllowedCapabilities: []string{"CAP_NET-ADMIN"}},
+		"UID mappings only":           {UID: 123, GID: 456, UIDMappings: rootOnly},
+		"unmapped jailer UID":         {UID: 123, GID: 100001, UIDMappings: rootOnly, GIDMappings: rootOnly},
+		"unmapped root":               {UID: 123, GID: 456, UIDMappings: []*proto.IDMapping{{ContainerID: 1, HostID: 123, Size: 1}}, GIDMappings: []*proto.IDMapping{{ContainerID: 1, HostID: 456, Size: 1}}},
+		"jailer UID mapped to root":   {UID: 100000, GID: 100000, UIDMappings: rootOnly, GIDMappings: rootOnly},
+		"empty mapping":               {UID: 100001, GID: 100001, UIDMappings: append(rootOnly, &proto.IDMapping{ContainerID: 70000}), GIDMappings: rootOnly},
+	} {
+		_, err := runcHardeningFromProto(cfg)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRuncHardeningApplyToSpec(t *testing.T) {
+	h := runcHardening{
+		Seccomp:          defaultSeccompProfile(),
+		DropCapabilities: true,
+		Capabilities:     []string{"CAP_NET_ADMIN"},
+		UIDMappings:      []specs.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		GIDMappings:      []specs.LinuxIDMapping{{ContainerID: 0, HostID: 200000, Size: 65536}},
+	}
+
+	spec := specs.Spec{
+		Process: &specs.Process{
+			User:         specs.User{UID: 100123, GID: 200456},
+			Capabilities: &specs.LinuxCapabilities{Bounding: []string{"CAP_SYS_ADMIN"}},
+		},
+		Linux: &specs.Linux{
+			Namespaces: []specs.LinuxNamespace{{Type: "pid"}, {Type: networkNamespaceRuncName}},
+		},
+	}
+	h.applyToSpec(&spec)
+
+	assert.Equal(t, specs.ActErrno, spec.Linux.Seccomp.DefaultAction)
+	assert.Contains(t, spec.Linux.Seccomp.Syscalls[0].Names, "ioctl")
+	assert.Equal(t, []string{"CAP_NET_ADMIN"}, spec.Process.Capabilities.Bounding)
+	assert.Equal(t, []string{"CAP_NET_ADMIN"}, spec.Process.Capabilities.Ambient)
+	assert.Equal(t, specs.LinuxNamespace{Type: userNamespaceRuncName}, spec.Linux.Namespaces[2])
+	assert.Equal(t, h.UIDMappings, spec.Linux.UIDMappings)
+	assert.Equal(t, h.GIDMappings, spec.Linux.GIDMappings)
+	assert.Equal(t, specs.User{UID: 123, GID: 456}, spec.Process.User, "the user should be translated to the IDs of the user namespace")
+
+	spec = specs.Spec{
+		Process: &specs.Process{Capabilities: &specs.LinuxCapabilities{Bounding: []string{"CAP_SYS_ADMIN"}}},
+		Linux:   &specs.Linux{},
+	}
+	runcHardening{DropCapabilities: true}.applyToSpec(&spec)
+	assert.Equal(t, []string{}, spec.Process.Capabilities.Bounding, "all the capabilities should be dropped")
+	assert.Nil(t, spec.Linux.Seccomp)
+	assert.Empty(t, spec.Linux.Namespaces)
+}
@@ -16,6 +16,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -29,38 +30,14 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/sys/unix"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
-	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
 )
 
-const (
-	networkNamespaceRuncName = "network"
-	cacheTopologyPath        = "/sys/devices/system/cpu/cpu0/cache"
-	cacheFolderPrefix        = "index"
-)
-
-var cacheTopologyPaths = []string{
-	"sys",
-	"devices",
-	"system",
-	"cpu",
-	"cpu0",
-	"cache",
-}
-
-var cacheTopologyFiles = []string{
-	"level",
-	"type",
-	"size",
-	"number_of_sets",
-	"shared_cpu_map",
-	"coherency_line_size",
-}
+const networkNamespaceRuncName = "network"
 
 // runcJailer uses runc to set up a jailed environment for the Firecracker VM.
 type runcJailer struct {
@@ -84,6 +61,10 @@ type runcJailerConfig struct {
 	CPUs           string
 	Mems           string
 	CgroupPath     string
+	// Limits are the resource limits of the cgroup of the container.
+	Limits cgroupLimits
+	// Hardening restricts the privileges of the container.
+	Hardening runcHardening
 
 	// DriveExposePolicy defines how the jailer exposes files.
 	DriveExposePolicy proto.DriveExposePolicy
@@ -243,49 +224,11 @@ func (j *runcJailer) BuildJailedRootHandler(cfg *config.Config, machineConfig *f
 
 			m.Cfg.KernelImagePath = kernelImageFileName
 
-			// copy drives to new contents path
-			for i, d := range m.Cfg.Drives {
-				drivePath := firecracker.StringValue(d.PathOnHost)
-				fileName := filepath.Base(drivePath)
-				newDrivePath := filepath.Join(rootPath, fileName)
-
-				f, err := os.Open(drivePath)
-				if err != nil {
-					return fmt.Errorf("failed to open drive file: %w", err)
-				}
-
-				// This closes the file in the event an error occurred, otherwise we
-				// call close down below.
-				defer f.Close()
-
-				if !internal.IsStubDrive(f) {
-					mode := 0600
-					if firecracker.BoolValue(d.IsReadOnly) {
-						mode = 0400
-					}
-					if err := j.exposeFileToJail(drivePath, newDrivePath, os.FileMode(mode)); err != nil {
-						return err
-					}
-				}
-
-				if err := f.Close(); err != nil {
-					j.logger.WithError(err).Debug("failed to close drive file")
-				}
-
-				j.logger.WithField("drive", newDrivePath).Debug("Adding drive")
-				m.Cfg.Drives[i].PathOnHost = firecracker.String(fileName)
-			}
-
-			// Setting the proper path to where the vsock path should be
-			for i, v := range m.Cfg.VsockDevices {
-				j.logger.WithField("vsock path", v.Path).Debug("vsock device path being set relative to jailed directory")
-
-				filename := filepath.Base(v.Path)
-				v.Path = filepath.Join("/", filename)
-				m.Cfg.VsockDevices[i] = v
+			if err := exposeMachineFilesToJail(j.logger, m, rootPath, j.exposeFileToJail); err != nil {
+				return err
 			}
 
-			if err := j.setupCacheTopology(rootPath); err != nil {
+			if err := setupCacheTopology(j.logger, rootPath, j.Config.UID, j.Config.GID); err != nil {
 				return err
 			}
 
@@ -304,63 +247,16 @@ func (j *runcJailer) BuildJailedRootHandler(cfg *config.Config, machineConfig *f
 	}
 }
 
-// makeLinkInJail creates a hard link to `src` inside the jail directory.
-func (j *runcJailer) makeLinkInJail(src, base string) (string, error) {
-	root := j.RootPath()
-
-	if strings.ContainsRune(base, os.PathSeparator) {
-		return "", fmt.Errorf("%q must not contain %q", base, os.PathSeparator)
-	}
-
-	dst := filepath.Join(root, base)
-
-	// Since Firecracker is unaware that we are in a jailed environment and
-	// what owner/group to set this as when creating, we will manually have
-	// to adjust the permission bits ourselves
-	if err := linkAndChown(src, dst, j.Config.UID, j.Config.GID); err != nil {
-		return "", err
-	}
-
-	// this path needs to be relative to the root path, and since we are
-	// placing the file in the root path the value should just be the file name.
-	return base, nil
-}
-
 // BuildLinkFifoHandler will return a new firecracker.Handler with the function
 // that will allow linking of the fifos making them visible to Firecracker.
 func (j *runcJailer) BuildLinkFifoHandler() firecracker.Handler {
-	return firecracker.Handler{
-		Name: jailerFifoHandlerName,
-		Fn: func(_ context.Context, m *firecracker.Machine) error {
-			logFifo, err := j.makeLinkInJail(m.Cfg.LogPath, internal.FirecrackerLogFifoName)
-			if err != nil {
-				return err
-			}
-			m.Cfg.LogFifo = logFifo
-
-			metricsFifo, err := j.makeLinkInJail(m.Cfg.MetricsPath, internal.FirecrackerMetricsFifoName)
-			if err != nil {
-				return err
-			}
-			m.Cfg.MetricsFifo = metricsFifo
-
-			return nil
-		},
-	}
+	return linkFifoHandler(j.RootPath(), j.Config.UID, j.Config.GID)
 }
 
 // StubDrivesOptions will return a set of options used to create a new stub
 // drive handler.
 func (j runcJailer) StubDrivesOptions() []FileOpt {
-	return []FileOpt{
-		func(file *os.File) error {
-			err := unix.Fchown(int(file.Fd()), int(j.Config.UID), int(j.Config.GID))
-			if err != nil {
-				return fmt.Errorf("failed to chown stub file %q: %w", file.Name(), err)
-			}
-			return nil
-		},
-	}
+	return chownFileOpts(j.Config.UID, j.Config.GID)
 }
 
 // ExposeFileToJail will inspect the given file, srcPath, and based on the
@@ -369,43 +265,31 @@ func (j runcJailer) StubDrivesOptions() []FileOpt {
 // set the correct permissions to ensure visibility in the jail. Regular files
 // will be copied into the jail.
 func (j *runcJailer) ExposeFileToJail(srcPath string) error {
-	uid := j.Config.UID
-	gid := j.Config.GID
+	return exposeFileToJailRoot(j.RootPath(), srcPath, j.Config.UID, j.Config.GID, j.exposeFileToJail)
+}
 
-	stat := syscall.Stat_t{}
-	if err := syscall.Stat(srcPath, &stat); err != nil {
+// RemoveFileFromJail removes the file exposed to the jail for srcPath. Files bind mounted
+// by runc cannot be unmounted from outside of the jail, so they are left in place.
+func (j *runcJailer) RemoveFileFromJail(srcPath string) error {
+	// Never resolve to a file outside of the jail
+	dst, err := fs.RootPath(j.RootPath(), srcPath)
+	if err != nil {
 		return err
 	}
 
-	// Checks file type using S_IFMT which is the bit mask for the file type.
-	switch stat.Mode & syscall.S_IFMT {
-	case syscall.S_IFBLK:
-		parentDir := filepath.Join(j.RootPath(), filepath.Dir(srcPath))
-		if err := mkdirAllWithPermissions(parentDir, 0700, uid, gid); err != nil {
-			return err
-		}
-
-		dst := filepath.Join(parentDir, filepath.Base(srcPath))
-		if err := exposeBlockDeviceToJail(dst, int(stat.Rdev), int(uid), int(gid)); err != nil {
-			return err
-		}
-
-	case syscall.S_IFREG:
-		parentDir := filepath.Join(j.RootPath(), filepath.Dir(srcPath))
-		if err := mkdirAllWithPermissions(parentDir, 0700, uid, gid); err != nil {
-			return err
-		}
-
-		dst := filepath.Join(parentDir, filepath.Base(srcPath))
-		if err := j.exposeFileToJail(srcPath, dst, os.FileMode(stat.Mode)); err != nil {
-			return err
+	stat := syscall.Stat_t{}
+	if err := syscall.Stat(dst, &stat); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
 		}
+		return err
+	}
 
-	default:
-		return fmt.Errorf("unsupported mode: %v", stat.Mode)
+	if stat.Mode&syscall.S_IFMT == syscall.S_IFREG && j.Config.DriveExposePolicy == proto.DriveExposePolicy_BIND {
+		return nil
 	}
 
-	return nil
+	return os.Remove(dst)
 }
 
 // exposeFileToJail will make the file accessible from the jail.
@@ -413,18 +297,12 @@ func (j *runcJailer) exposeFileToJail(src, dst string, mode os.FileMode) error {
 	if j.Config.DriveExposePolicy == proto.DriveExposePolicy_BIND {
 		return j.bindMountFileToJail(src, dst)
 	}
-	return j.copyFileToJail(src, dst, mode)
+	return linkOrCopyFileAndChown(j.logger, j.Config.DriveExposePolicy, src, dst, mode, j.Config.UID, j.Config.GID)
 }
 
 // copyFileToJail copies a file from src to dst, and chown the new file to the jail user.
 func (j *runcJailer) copyFileToJail(src, dst string, mode os.FileMode) error {
-	if err := copyFile(src, dst, mode); err != nil {
-		return err
-	}
-	if err := os.Chown(dst, int(j.Config.UID), int(j.Config.GID)); err != nil {
-		return err
-	}
-	return nil
+	return copyFileAndChown(src, dst, mode, j.Config.UID, j.Config.GID)
 }
 
 // bindMountFileToJail mounts a file from src to dst, and chown the new file to
@@ -528,6 +406,7 @@ func (j *runcJailer) overwriteConfig(machineConfig *firecracker.Config, socketPa
 	spec.Root.Readonly = false
 	spec.Process.User.UID = j.Config.UID
 	spec.Process.User.GID = j.Config.GID
+	j.Config.Hardening.applyToSpec(&spec)
 
 	if machineConfig.NetNS != "" {
 		for i, ns := range spec.Linux.Namespaces {
@@ -549,6 +428,7 @@ func (j *runcJailer) overwriteConfig(machineConfig *firecracker.Config, socketPa
 
 	spec.Linux.Resources.CPU.Cpus = j.Config.CPUs
 	spec.Linux.Resources.CPU.Mems = j.Config.Mems
+	j.Config.Limits.applyToSpec(spec.Linux.Resources)
 
 	configBytes, err := json.Marshal(&spec)
 	if err != nil {
@@ -674,57 +554,3 @@ func (j runcJailer) Stop(force bool) error {
 	}
 	return j.runcClient.Kill(j.ctx, j.vmID, int(signal), &runc.KillOpts{All: true})
 }
-
-// setupCacheTopology will copy indexed contents from the cacheTopologyPath to
-// the jailer. This is needed for arm architecture as arm does not
-// automatically setup any cache topology
-func (j runcJailer) setupCacheTopology(path string) error {
-	j.logger.WithField("path", path).Debug("Creating cache topology")
-	const mode = os.FileMode(0700)
-
-	// builds the cache topology path from the root directory
-	for _, p := range cacheTopologyPaths {
-		path = filepath.Join(path, p)
-		if err := mkdirAndChown(path, mode, j.Config.UID, j.Config.GID); err != nil {
-			return err
-		}
-	}
-
-	err := filepath.Walk(cacheTopologyPath, func(cachePath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() {
-			return nil
-		}
-
-		folder := filepath.Base(cachePath)
-		if !strings.HasPrefix(folder, cacheFolderPrefix) {
-			return nil
-		}
-
-		indexPath := filepath.Join(path, folder)
-		if err := mkdirAndChown(indexPath, info.Mode(), j.Config.UID, j.Config.GID); err != nil {
-			return err
-		}
-
-		j.logger.WithField("src path", cachePath).WithField("dst path", indexPath).Debug("copying cache folder")
-		for _, file := range cacheTopologyFiles {
-			cacheFilePath := filepath.Join(cachePath, file)
-			info, err := os.Stat(cacheFilePath)
-			if err != nil {
-				return err
-			}
-
-			// This is suppose to be a hard copy as intructed by the Firecracker team.
-			// Bind mounting here may cause some issues on the host's machine
-			if err := j.copyFileToJail(cacheFilePath, filepath.Join(indexPath, file), info.Mode()); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	})
-
-	return err
-}
@@ -167,6 +167,33 @@ func TestBindMountToJail(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRemoveFileFromJail(t *testing.T) {
+	dir := t.TempDir()
+
+	j := &runcJailer{Config: runcJailerConfig{OCIBundlePath: dir}}
+	jailedPath := filepath.Join(j.RootPath(), "drives", "data.img")
+	require.NoError(t, os.MkdirAll(filepath.Dir(jailedPath), 0700))
+	require.NoError(t, os.WriteFile(jailedPath, []byte("data"), 0600))
+
+	// files bind mounted by runc are kept
+	j.Config.DriveExposePolicy = proto.DriveExposePolicy_BIND
+	require.NoError(t, j.RemoveFileFromJail("/drives/data.img"))
+	assert.FileExists(t, jailedPath)
+
+	j.Config.DriveExposePolicy = proto.DriveExposePolicy_COPY
+	require.NoError(t, j.RemoveFileFromJail("/drives/data.img"))
+	assert.NoFileExists(t, jailedPath)
+
+	// removing a file which is not in the jail is not an error
+	require.NoError(t, j.RemoveFileFromJail("/drives/data.img"))
+
+	// paths cannot escape the jail
+	outside := filepath.Join(dir, "outside.img")
+	require.NoError(t, os.WriteFile(outside, []byte("data"), 0600))
+	require.NoError(t, j.RemoveFileFromJail("/../../"+outside))
+	assert.FileExists(t, outside)
+}
+
 func TestFifoHandler(t *testing.T) {
 	// Because of chown(2).
 	internal.RequiresRoot(t)
@@ -21,6 +21,7 @@ import (
 	"math"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 	"strconv"
 	"strings"
@@ -37,6 +38,7 @@ import (
 	"github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/events/exchange"
+	"github.com/containerd/containerd/identifiers"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/pkg/ttrpcutil"
 	"github.com/containerd/containerd/protobuf"
@@ -51,18 +53,26 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
 	"github.com/firecracker-microvm/firecracker-containerd/eventbridge"
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/bundle"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
 	fccontrolTtrpc "github.com/firecracker-microvm/firecracker-containerd/proto/service/fccontrol/ttrpc"
+	guestnetwork "github.com/firecracker-microvm/firecracker-containerd/proto/service/guestnetwork/ttrpc"
+	gueststats "github.com/firecracker-microvm/firecracker-containerd/proto/service/gueststats/ttrpc"
+	health "github.com/firecracker-microvm/firecracker-containerd/proto/service/health/ttrpc"
 	ioproxy "github.com/firecracker-microvm/firecracker-containerd/proto/service/ioproxy/ttrpc"
 )
 
@@ -83,12 +93,39 @@ const (
 	defaultShutdownTimeout     = 5 * time.Second
 	defaultVSockConnectTimeout = 5 * time.Second
 
+	// tracingShutdownTimeout bounds how long the shim waits for pending spans to be exported on exit.
+	tracingShutdownTimeout = 5 * time.Second
+
 	// StartEventName is the topic published to when a VM starts
 	StartEventName = "/firecracker-vm/start"
 
 	// StopEventName is the topic published to when a VM stops
 	StopEventName = "/firecracker-vm/stop"
 
+	// PausedEventName is the topic published to when a VM is paused
+	PausedEventName = "/firecracker-vm/paused"
+
+	// ResumedEventName is the topic published to when a VM is resumed
+	ResumedEventName = "/firecracker-vm/resumed"
+
+	// CreateFailedEventName is the topic published to when a VM fails to be created
+	CreateFailedEventName = "/firecracker-vm/create-failed"
+
+	// ExitEventName is the topic published to when a VM exits, along with why it exited
+	ExitEventName = "/firecracker-vm/exit"
+
+	// BalloonUpdatedEventName is the topic published to when the balloon of a VM is updated
+	BalloonUpdatedEventName = "/firecracker-vm/balloon-updated"
+
+	// DriveAttachedEventName is the topic published to when a drive is attached to a VM
+	DriveAttachedEventName = "/firecracker-vm/drive-attached"
+
+	// UnhealthyEventName is the topic published to when the agent of a VM fails consecutive health checks
+	UnhealthyEventName = "/firecracker-vm/unhealthy"
+
+	// HealthyEventName is the topic published to when the agent of an unhealthy VM passes a health check
+	HealthyEventName = "/firecracker-vm/healthy"
+
 	// taskExecID is a special exec ID that is pointing its task itself.
 	// While the constant is defined here, the convention is coming from containerd.
 	taskExecID = ""
@@ -106,6 +143,10 @@ type service struct {
 	eventExchange *exchange.Exchange
 	namespace     string
 
+	// logger, vmID, exitAfterAllTasksDeleted and the VMID, ExitAfterAllTasksDeleted and Metadata
+	// fields of createVMRequest change when a warm pool VM is bound to its final VMID, so once the
+	// VM is ready they are guarded by bindMu. Use log() and currentVMID() to read logger and vmID.
+	bindMu sync.RWMutex
 	logger *logrus.Entry
 
 	// Normally, it's ill-advised to store a context object in a struct. However,
@@ -132,6 +173,9 @@ type service struct {
 
 	config *config.Config
 
+	// shutdownTracing flushes the spans recorded by the shim. It is only set by shims managing a VM.
+	shutdownTracing func(context.Context) error
+
 	// vmReady is closed once CreateVM has been successfully called
 	vmReady                  chan struct{}
 	vmStartOnce              sync.Once
@@ -139,21 +183,63 @@ type service struct {
 	eventBridgeClient        eventbridge.Getter
 	driveMountClient         drivemount.DriveMounterService
 	ioProxyClient            ioproxy.IOProxyService
+	guestStatsClient         gueststats.GuestStatsService
+	guestNetworkClient       guestnetwork.GuestNetworkService
+	healthClient             health.HealthService
 	jailer                   jailer
 	containerStubHandler     *StubDriveHandler
 	driveMountStubs          []MountableStubDrive
 	exitAfterAllTasksDeleted bool // exit the VM and shim when all tasks are deleted
 
-	blockDeviceTasks map[string]struct{}
+	// blockDeviceTasks maps the IDs of the tasks whose rootfs is on stub drives to the IDs
+	// the drives are reserved with
+	blockDeviceTasks map[string][]string
+
+	// createVMRequest is the configuration the VM was created with, recorded in snapshot manifests
+	createVMRequest *proto.CreateVMRequest
+
+	// warmPool is set when the shim boots an idle VM of a warm pool, which is bound to its
+	// final VMID by a second CreateVM call.
+	warmPool         bool
+	warmPoolBindOnce sync.Once
+
+	// healthStatus is the health of the VM agent, updated by monitorAgentHealth
+	healthStatus healthStatus
 
 	cleanupErr  error
 	cleanupOnce sync.Once
 
+	// vmStartedAt is when the VM finished booting and pausedAt when it was last paused.
+	// exitReason describes why the VM exited and exitForced whether it was forcefully
+	// terminated. They are all published in VM events and guarded by eventMu.
+	vmStartedAt time.Time
+	pausedAt    time.Time
+	exitReason  string
+	exitForced  bool
+	eventMu     sync.Mutex
+
+	// taskIDs are the IDs of the tasks recorded in the shim's state file and taskExits the
+	// exit statuses of those whose init process exited
+	taskIDs      map[string]struct{}
+	taskExits    map[string]vm.TaskExit
+	stateRemoved bool
+	stateMu      sync.Mutex
+
+	// taskID is the ID of the task a "shim delete" call cleans up after
+	taskID string
+
 	machine          *firecracker.Machine
 	machineConfig    *firecracker.Config
 	vsockIOPortCount uint32
 	vsockPortMu      sync.Mutex
 
+	// firecrackerClient makes the Firecracker API requests the SDK's Machine has no method for
+	firecrackerClient *firecracker.Client
+
+	// callerMetricsFifo is set when the metrics FIFO was provided by the caller of CreateVM,
+	// which is then left for the caller to read
+	callerMetricsFifo bool
+
 	// fifos have stdio FIFOs containerd passed to the shim. The key is [taskID][execID].
 	fifos   map[string]map[string]cio.Config
 	fifosMu sync.Mutex
@@ -169,7 +255,7 @@ func shimOpts(shimCtx context.Context) (*shim.Opts, error) {
 }
 
 // NewService creates new runtime shim.
-func NewService(shimCtx context.Context, _ string, remotePublisher shim.Publisher, shimCancel func()) (shim.Shim, error) {
+func NewService(shimCtx context.Context, id string, remotePublisher shim.Publisher, shimCancel func()) (shim.Shim, error) {
 	cfg, err := config.LoadConfig("")
 	if err != nil {
 		return nil, err
@@ -219,28 +305,53 @@ func NewService(shimCtx context.Context, _ string, remotePublisher shim.Publishe
 
 		config: cfg,
 
+		warmPool: os.Getenv(internal.WarmPoolEnvVarKey) != "",
+
 		vmReady:          make(chan struct{}),
 		jailer:           newNoopJailer(shimCtx, logger, shimDir),
-		blockDeviceTasks: make(map[string]struct{}),
+		blockDeviceTasks: make(map[string][]string),
+		taskIDs:          make(map[string]struct{}),
+		taskExits:        make(map[string]vm.TaskExit),
+		taskID:           id,
 		fifos:            make(map[string]map[string]cio.Config),
 	}
 
 	s.startEventForwarders(remotePublisher)
+	if s.shimDir != "" {
+		go s.recordTaskExits()
+	}
 
 	err = s.serveFCControl()
 	if err != nil {
 		err = fmt.Errorf("failed to start fccontrol server: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
+	// Like the fccontrol API, metrics are only served by shims managing a VM.
+	if s.shimDir != "" && os.Getenv(internal.FCSocketFDEnvKey) != "" {
+		err = serveMetrics(s.shimCtx, s.log(), s.shimDir.MetricsSockPath())
+		if err != nil {
+			err = fmt.Errorf("failed to start metrics server: %w", err)
+			s.log().WithError(err).Error()
+			return nil, err
+		}
+
+		s.shutdownTracing, err = tracing.Init(s.shimCtx, "firecracker-containerd-shim", cfg.Tracing)
+		if err != nil {
+			err = fmt.Errorf("failed to set up tracing: %w", err)
+			s.log().WithError(err).Error()
+			return nil, err
+		}
+	}
+
 	return s, nil
 }
 
 func (s *service) startEventForwarders(remotePublisher shim.Publisher) {
 	ns, ok := namespaces.Namespace(s.shimCtx)
 	if !ok {
-		s.logger.Error("failed to fetch the namespace from the context")
+		s.log().Error("failed to fetch the namespace from the context")
 	}
 	ctx := namespaces.WithNamespace(context.Background(), ns)
 
@@ -258,12 +369,12 @@ func (s *service) startEventForwarders(remotePublisher shim.Publisher) {
 
 		err := <-attachCh
 		if err != nil && err != context.Canceled {
-			s.logger.WithError(err).Error("error while forwarding events from VM agent")
+			s.log().WithError(err).Error("error while forwarding events from VM agent")
 		}
 
 		err = <-republishCh
 		if err != nil && err != context.Canceled {
-			s.logger.WithError(err).Error("error while republishing events")
+			s.log().WithError(err).Error("error while republishing events")
 		}
 
 		remotePublisher.Close()
@@ -282,7 +393,10 @@ func (s *service) serveFCControl() error {
 		return nil
 	}
 
-	fcServer, err := ttrpc.NewServer(ttrpc.WithServerHandshaker(ttrpc.UnixSocketRequireSameUser()))
+	fcServer, err := ttrpc.NewServer(
+		ttrpc.WithServerHandshaker(ttrpc.UnixSocketRequireSameUser()),
+		ttrpc.WithUnaryServerInterceptor(tracing.UnaryServerInterceptor),
+	)
 	if err != nil {
 		return err
 	}
@@ -290,7 +404,7 @@ func (s *service) serveFCControl() error {
 	socketFD, err := strconv.Atoi(fcSocketFDEnvVal)
 	if err != nil {
 		err = fmt.Errorf("failed to parse fccontrol socket FD value: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return err
 	}
 
@@ -304,7 +418,7 @@ func (s *service) serveFCControl() error {
 		defer fcListener.Close()
 		err := fcServer.Serve(s.shimCtx, fcListener)
 		if err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
-			s.logger.WithError(err).Error("fccontrol ttrpc server error")
+			s.log().WithError(err).Error("fccontrol ttrpc server error")
 		}
 	}()
 
@@ -454,10 +568,12 @@ func (s *service) waitVMReady() error {
 }
 
 // CreateVM will attempt to create the VM as specified in the provided request, but only on the first request
-// received. Any subsequent requests will be ignored and get an AlreadyExists error response.
+// received. Any subsequent requests will be ignored and get an AlreadyExists error response, except for the
+// second request received by the shim of a warm pool VM, which binds the VM to the request's VMID.
 func (s *service) CreateVM(requestCtx context.Context, request *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
+	requestedAt := time.Now()
 	timeout := defaultCreateVMTimeout
 	if request.TimeoutSeconds > 0 {
 		timeout = time.Duration(request.TimeoutSeconds) * time.Second
@@ -468,7 +584,6 @@ func (s *service) CreateVM(requestCtx context.Context, request *proto.CreateVMRe
 	var (
 		err       error
 		createRan bool
-		resp      proto.CreateVMResponse
 	)
 
 	s.vmStartOnce.Do(func() {
@@ -476,49 +591,206 @@ func (s *service) CreateVM(requestCtx context.Context, request *proto.CreateVMRe
 		createRan = true
 	})
 	if !createRan {
+		if s.warmPool {
+			return s.bindWarmVM(requestCtx, request, requestedAt)
+		}
 		return nil, status.Error(codes.AlreadyExists, "shim cannot create VM more than once")
 	}
 
 	// If we failed to create the VM, we have no point in existing anymore, so shutdown
 	if err != nil {
+		createVMTime.WithValues("failure").UpdateSince(requestedAt)
+		if publishErr := s.publishVMCreateFailed(err, time.Since(requestedAt)); publishErr != nil {
+			s.log().WithError(publishErr).Error("failed to publish create VM failure event")
+		}
 		s.shimCancel()
-		s.logger.WithError(err).Error("failed to create VM")
+		s.log().WithError(err).Error("failed to create VM")
 		if errors.Is(err, context.DeadlineExceeded) {
 			return nil, status.Errorf(codes.DeadlineExceeded, "VM %q didn't start within %s: %s", request.VMID, timeout, err)
 		}
 		return nil, fmt.Errorf("failed to create VM: %w", err)
 	}
 
-	// creating the VM succeeded, setup monitors and publish events to celebrate
-	err = s.publishVMStart()
-	if err != nil {
-		s.logger.WithError(err).Error("failed to publish start VM event")
+	createVMTime.WithValues("success").UpdateSince(requestedAt)
+
+	s.eventMu.Lock()
+	s.vmStartedAt = time.Now()
+	s.eventMu.Unlock()
+
+	// creating the VM succeeded, setup monitors and publish events to celebrate. Warm pool VMs
+	// are only announced once they have been bound to their VMID.
+	if !s.warmPool {
+		err = s.publishVMStart(time.Since(requestedAt))
+		if err != nil {
+			s.log().WithError(err).Error("failed to publish start VM event")
+		}
 	}
 
+	s.saveState()
+
 	go s.monitorVMExit()
+	go s.monitorAgentHealth()
 	// let all the other methods know that the VM is ready for tasks
 	close(s.vmReady)
 
-	resp.VMID = s.vmID
-	resp.MetricsFifoPath = s.machineConfig.MetricsFifo
-	resp.LogFifoPath = s.machineConfig.LogFifo
-	resp.SocketPath = s.shimDir.FirecrackerSockPath()
+	s.startBalloonController(s.createVMRequest.GetBalloonDevice())
+
+	return s.createVMResponse(), nil
+}
+
+// bindWarmVM binds the idle VM booted by this warm pool shim to the VMID of the provided request.
+// Only the settings which are not part of the pool's profile are taken from the request.
+func (s *service) bindWarmVM(
+	requestCtx context.Context,
+	request *proto.CreateVMRequest,
+	requestedAt time.Time,
+) (*proto.CreateVMResponse, error) {
+	var bindRan bool
+	s.warmPoolBindOnce.Do(func() {
+		bindRan = true
+	})
+	if !bindRan {
+		return nil, status.Error(codes.AlreadyExists, "shim cannot create VM more than once")
+	}
+
+	if err := s.waitVMReady(); err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if err := identifiers.Validate(request.VMID); err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if request.Metadata != "" {
+		if err := s.machine.SetMetadata(requestCtx, json.RawMessage(request.Metadata)); err != nil {
+			err = fmt.Errorf("failed to set VM metadata: %w", err)
+			s.log().WithError(err).Error()
+			return nil, err
+		}
+	}
+
+	s.bindMu.Lock()
+	logger := s.logger.WithField("boundVMID", request.VMID)
+	s.vmID = request.VMID
+	s.logger = s.logger.WithField("vmID", request.VMID)
+	s.exitAfterAllTasksDeleted = request.ExitAfterAllTasksDeleted
+	s.createVMRequest.VMID = request.VMID
+	s.createVMRequest.ExitAfterAllTasksDeleted = request.ExitAfterAllTasksDeleted
+	s.createVMRequest.Metadata = request.Metadata
+	s.bindMu.Unlock()
+
+	logger.Info("bound warm pool VM")
+	if err := s.publishVMStart(time.Since(requestedAt)); err != nil {
+		s.log().WithError(err).Error("failed to publish start VM event")
+	}
+
+	return s.createVMResponse(), nil
+}
+
+// log returns the logger of the shim, which includes the VMID.
+func (s *service) log() *logrus.Entry {
+	s.bindMu.RLock()
+	defer s.bindMu.RUnlock()
+	return s.logger
+}
+
+// currentVMID returns the VMID of the VM, which changes once when a warm pool VM is bound.
+func (s *service) currentVMID() string {
+	s.bindMu.RLock()
+	defer s.bindMu.RUnlock()
+	return s.vmID
+}
+
+func (s *service) createVMResponse() *proto.CreateVMResponse {
+	resp := &proto.CreateVMResponse{
+		VMID:            s.currentVMID(),
+		MetricsFifoPath: s.machineConfig.MetricsFifo,
+		LogFifoPath:     s.machineConfig.LogFifo,
+		SocketPath:      s.shimDir.FirecrackerSockPath(),
+	}
 	if c, ok := s.jailer.(cgroupPather); ok {
 		resp.CgroupPath = c.CgroupPath()
 	}
 
-	return &resp, nil
+	return resp
 }
 
-func (s *service) publishVMStart() error {
-	return s.eventExchange.Publish(s.shimCtx, StartEventName, &proto.VMStart{VMID: s.vmID})
+func (s *service) publishVMStart(bootDuration time.Duration) error {
+	s.eventMu.Lock()
+	startedAt := s.vmStartedAt
+	s.eventMu.Unlock()
+
+	return s.eventExchange.Publish(s.shimCtx, StartEventName, &proto.VMStart{
+		VMID:         s.currentVMID(),
+		Namespace:    s.namespace,
+		StartedAt:    protobuf.ToTimestamp(startedAt),
+		BootDuration: durationpb.New(bootDuration),
+	})
 }
 
 func (s *service) publishVMStop() error {
-	return s.eventExchange.Publish(s.shimCtx, StopEventName, &proto.VMStop{VMID: s.vmID})
+	return s.eventExchange.Publish(s.shimCtx, StopEventName, &proto.VMStop{
+		VMID:      s.currentVMID(),
+		Namespace: s.namespace,
+		StoppedAt: protobuf.ToTimestamp(time.Now()),
+		Uptime:    durationpb.New(s.uptime()),
+	})
+}
+
+func (s *service) publishVMCreateFailed(createErr error, duration time.Duration) error {
+	return s.eventExchange.Publish(s.shimCtx, CreateFailedEventName, &proto.VMCreateFailed{
+		VMID:      s.currentVMID(),
+		Namespace: s.namespace,
+		Error:     createErr.Error(),
+		FailedAt:  protobuf.ToTimestamp(time.Now()),
+		Duration:  durationpb.New(duration),
+	})
+}
+
+func (s *service) publishVMExited() error {
+	s.eventMu.Lock()
+	reason, forced := s.exitReason, s.exitForced
+	s.eventMu.Unlock()
+
+	return s.eventExchange.Publish(s.shimCtx, ExitEventName, &proto.VMExited{
+		VMID:       s.currentVMID(),
+		Namespace:  s.namespace,
+		ExitedAt:   protobuf.ToTimestamp(time.Now()),
+		Uptime:     durationpb.New(s.uptime()),
+		ExitReason: reason,
+		Forced:     forced,
+	})
+}
+
+// uptime returns how long the VM has been running for, or zero if it never finished booting.
+func (s *service) uptime() time.Duration {
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+
+	if s.vmStartedAt.IsZero() {
+		return 0
+	}
+	return time.Since(s.vmStartedAt)
+}
+
+// recordExitReason records why the VM is exiting unless a reason was already recorded. The
+// reason of a forced termination replaces the one of the graceful shutdown attempt it follows.
+func (s *service) recordExitReason(reason string, forced bool) {
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+
+	if s.exitReason == "" || (forced && !s.exitForced) {
+		s.exitReason = reason
+		s.exitForced = forced
+	}
 }
 
 func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRequest) (err error) {
+	requestCtx, span := tracing.StartSpan(requestCtx, "createVM", attribute.String("vm_id", s.currentVMID()))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var vsockFd *os.File
 	defer func() {
 		if vsockFd != nil {
@@ -531,13 +803,31 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		namespace = namespaces.Default
 	}
 
-	dir, err := vm.ShimDir(s.config.ShimBaseDir, namespace, s.vmID)
+	dir, err := vm.ShimDir(s.config.ShimBaseDir, namespace, s.currentVMID())
 	if err != nil {
 		return err
 	}
 
-	s.logger.Info("creating new VM")
-	s.jailer, err = newJailer(s.shimCtx, s.logger, dir.RootPath(), s, request)
+	snapshotSource := request.Snapshot
+	var snapshotManifest *proto.SnapshotManifest
+	if snapshotSource != nil {
+		snapshotManifest, err = readSnapshotManifest(snapshotSource.ManifestPath)
+		if err != nil {
+			return err
+		}
+		request = restoreRequest(request, snapshotManifest)
+		s.log().Info("restoring VM from snapshot")
+	} else {
+		s.log().Info("creating new VM")
+	}
+
+	if err := validateBalloonPolicy(request.BalloonDevice); err != nil {
+		return err
+	}
+
+	_, jailerSpan := tracing.StartSpan(requestCtx, "newJailer")
+	s.jailer, err = newJailer(s.shimCtx, s.log(), dir.RootPath(), s, request)
+	tracing.EndSpan(jailerSpan, err)
 	if err != nil {
 		return fmt.Errorf("failed to create jailer: %w", err)
 	}
@@ -546,7 +836,7 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		// in the event of an error, we should stop the VM
 		if err != nil {
 			if e := s.jailer.Stop(true); e != nil {
-				s.logger.WithError(e).Debug("failed to stop firecracker")
+				s.log().WithError(e).Debug("failed to stop firecracker")
 			}
 		}
 	}()
@@ -556,6 +846,13 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		return fmt.Errorf("failed to build VM configuration: %w", err)
 	}
 
+	// Firecracker's writes to a FIFO would be split between its readers, so the shim only
+	// reads the metrics FIFO it created.
+	s.callerMetricsFifo = request.MetricsFifoPath != ""
+	if !s.callerMetricsFifo {
+		go readFirecrackerMetrics(s.shimCtx, s.log(), s.machineConfig.MetricsPath, vmMetrics)
+	}
+
 	opts := []firecracker.Opt{}
 
 	if v, ok := s.config.DebugHelper.GetFirecrackerSDKLogLevel(); ok {
@@ -568,13 +865,22 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		return fmt.Errorf("failed to get relative path to firecracker vsock: %w", err)
 	}
 
-	jailedOpts, err := s.jailer.BuildJailedMachine(s.config, s.machineConfig, s.vmID)
+	_, jailerSpan = tracing.StartSpan(requestCtx, "BuildJailedMachine")
+	jailedOpts, err := s.jailer.BuildJailedMachine(s.config, s.machineConfig, s.currentVMID())
+	tracing.EndSpan(jailerSpan, err)
 	if err != nil {
 		return fmt.Errorf("failed to build jailed machine options: %w", err)
 	}
 
-	if request.BalloonDevice == nil {
-		s.logger.Debug("No balloon device is setup")
+	if snapshotSource != nil {
+		// All of the devices of a restored VM, including its balloon device, are loaded from the snapshot.
+		snapshotOpts, err := s.buildSnapshotOpts(snapshotSource, snapshotManifest)
+		if err != nil {
+			return fmt.Errorf("failed to build snapshot options: %w", err)
+		}
+		opts = append(opts, snapshotOpts...)
+	} else if request.BalloonDevice == nil {
+		s.log().Debug("No balloon device is setup")
 	} else {
 		// Creates a new balloon device if one does not already exist, otherwise updates it, before machine startup.
 		balloon, err := s.createBalloon(requestCtx, request)
@@ -598,30 +904,108 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 	if err != nil {
 		return fmt.Errorf("failed to create new machine instance: %w", err)
 	}
+	s.firecrackerClient = firecracker.NewClient(s.machine.Cfg.SocketPath, s.log(), false)
 
 	if err = s.machine.Start(s.shimCtx); err != nil {
 		return fmt.Errorf("failed to start the VM: %w", err)
 	}
 
-	s.logger.Info("calling agent")
-	conn, err := vsock.DialContext(requestCtx, relVSockPath, defaultVsockPort, vsock.WithLogger(s.logger))
+	if request.Metadata != "" {
+		if err = s.machine.SetMetadata(requestCtx, json.RawMessage(request.Metadata)); err != nil {
+			return fmt.Errorf("failed to set VM metadata: %w", err)
+		}
+	}
+
+	s.log().Info("calling agent")
+	conn, err := vsock.DialContext(requestCtx, relVSockPath, defaultVsockPort,
+		vsock.WithLogger(vm.DialRetryLogger(s.log(), countVSockDialRetry)))
 	if err != nil {
 		return fmt.Errorf("failed to dial the VM over vsock: %w", err)
 	}
 
-	rpcClient := ttrpc.NewClient(conn, ttrpc.WithOnClose(func() { _ = conn.Close() }))
+	rpcClient := ttrpc.NewClient(conn,
+		ttrpc.WithOnClose(func() { _ = conn.Close() }),
+		ttrpc.WithChainUnaryClientInterceptor(agentRPCInterceptor, tracing.UnaryClientInterceptor))
 	s.agentClient = taskAPI.NewTaskClient(rpcClient)
 	s.eventBridgeClient = eventbridge.NewGetterClient(rpcClient)
 	s.driveMountClient = drivemount.NewDriveMounterClient(rpcClient)
 	s.ioProxyClient = ioproxy.NewIOProxyClient(rpcClient)
+	s.guestStatsClient = gueststats.NewGuestStatsClient(rpcClient)
+	s.guestNetworkClient = guestnetwork.NewGuestNetworkClient(rpcClient)
+	s.healthClient = health.NewHealthClient(rpcClient)
 	s.exitAfterAllTasksDeleted = request.ExitAfterAllTasksDeleted
+	s.createVMRequest = request
+
+	// The network and drives of a restored VM were already configured by the agent before the VM was snapshotted.
+	if snapshotSource == nil {
+		err = s.configureGuestNetwork(requestCtx)
+		if err != nil {
+			return err
+		}
+
+		err = s.mountDrives(requestCtx)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.log().Info("successfully started the VM")
+	return nil
+}
+
+// buildSnapshotOpts returns the options needed to load the provided snapshot into the VM instead of booting it.
+// The snapshot is resumed as soon as it has been loaded.
+func (s *service) buildSnapshotOpts(source *proto.SnapshotSource, manifest *proto.SnapshotManifest) ([]firecracker.Opt, error) {
+	if err := verifySnapshotStubDrives(manifest.ContainerStubs, s.containerStubHandler.freeStubDrives()); err != nil {
+		return nil, fmt.Errorf("container stub drives do not match snapshot: %w", err)
+	}
 
-	err = s.mountDrives(requestCtx)
+	driveMountStubs, err := mountableStubDrives(s.driveMountStubs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	s.logger.Info("successfully started the VM")
+	if err := verifySnapshotStubDrives(manifest.DriveMountStubs, driveMountStubs); err != nil {
+		return nil, fmt.Errorf("drive mount stub drives do not match snapshot: %w", err)
+	}
+
+	// The drive mounts were patched into the VM before it was snapshotted, so their contents
+	// must be available in the jail before the snapshot is loaded.
+	for _, drive := range driveMountStubs {
+		if err := s.jailer.ExposeFileToJail(drive.driveMount.HostPath); err != nil {
+			return nil, fmt.Errorf("failed to expose drive mount contents to jail: %w", err)
+		}
+	}
+
+	for _, path := range []string{source.MemFilePath, source.SnapshotPath} {
+		if err := s.jailer.ExposeFileToJail(path); err != nil {
+			return nil, fmt.Errorf("failed to expose snapshot file %q to jail: %w", path, err)
+		}
+	}
+
+	return []firecracker.Opt{
+		firecracker.WithSnapshot(source.MemFilePath, source.SnapshotPath, func(cfg *firecracker.SnapshotConfig) {
+			cfg.ResumeVM = true
+		}),
+		func(m *firecracker.Machine) {
+			// The vsock device is restored from the snapshot and cannot be added once the snapshot is loaded.
+			m.Handlers.FcInit = m.Handlers.FcInit.Remove(firecracker.AddVsocksHandlerName)
+		},
+	}, nil
+}
+
+// configureGuestNetwork has the agent apply the parts of the static IP configuration of the
+// network interfaces the kernel cannot configure at boot.
+func (s *service) configureGuestNetwork(requestCtx context.Context) error {
+	req := guestNetworkRequest(s.createVMRequest.NetworkInterfaces)
+	if req == nil {
+		return nil
+	}
+
+	_, err := s.guestNetworkClient.ConfigureNetwork(requestCtx, req)
+	if err != nil {
+		return fmt.Errorf("failed to configure the VM network: %w", err)
+	}
 	return nil
 }
 
@@ -640,8 +1024,8 @@ func (s *service) mountDrives(requestCtx context.Context) error {
 // but the shim will continue to shutdown. Similarly if we detect that the VM is in pause state, then
 // we are unable to communicate to the in-VM agent. In this case, we do a forceful shutdown.
 func (s *service) StopVM(requestCtx context.Context, request *proto.StopVMRequest) (_ *types.Empty, err error) {
-	defer logPanicAndDie(s.logger)
-	s.logger.WithFields(logrus.Fields{"timeout_seconds": request.TimeoutSeconds}).Debug("StopVM")
+	defer logPanicAndDie(s.log())
+	s.log().WithFields(logrus.Fields{"timeout_seconds": request.TimeoutSeconds}).Debug("StopVM")
 
 	timeout := defaultStopVMTimeout
 	if request.TimeoutSeconds > 0 {
@@ -659,34 +1043,170 @@ func (s *service) StopVM(requestCtx context.Context, request *proto.StopVMReques
 
 // ResumeVM resumes a VM
 func (s *service) ResumeVM(ctx context.Context, _ *proto.ResumeVMRequest) (*types.Empty, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
+	resumeStartedAt := time.Now()
 	if err := s.machine.ResumeVM(ctx); err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
+	resumedAt := time.Now()
+
+	s.eventMu.Lock()
+	var pausedDuration time.Duration
+	if !s.pausedAt.IsZero() {
+		pausedDuration = resumedAt.Sub(s.pausedAt)
+		s.pausedAt = time.Time{}
+	}
+	s.eventMu.Unlock()
+
+	err = s.eventExchange.Publish(s.shimCtx, ResumedEventName, &proto.VMResumed{
+		VMID:           s.currentVMID(),
+		Namespace:      s.namespace,
+		ResumedAt:      protobuf.ToTimestamp(resumedAt),
+		Duration:       durationpb.New(resumedAt.Sub(resumeStartedAt)),
+		PausedDuration: durationpb.New(pausedDuration),
+	})
+	if err != nil {
+		s.log().WithError(err).Error("failed to publish resume VM event")
+	}
 
 	return &types.Empty{}, nil
 }
 
 // PauseVM pauses a VM
 func (s *service) PauseVM(ctx context.Context, _ *proto.PauseVMRequest) (*types.Empty, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
+	pauseStartedAt := time.Now()
 	if err := s.machine.PauseVM(ctx); err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+	pausedAt := time.Now()
+
+	s.eventMu.Lock()
+	s.pausedAt = pausedAt
+	s.eventMu.Unlock()
+
+	err = s.eventExchange.Publish(s.shimCtx, PausedEventName, &proto.VMPaused{
+		VMID:      s.currentVMID(),
+		Namespace: s.namespace,
+		PausedAt:  protobuf.ToTimestamp(pausedAt),
+		Duration:  durationpb.New(pausedAt.Sub(pauseStartedAt)),
+	})
+	if err != nil {
+		s.log().WithError(err).Error("failed to publish pause VM event")
+	}
+
+	return &types.Empty{}, nil
+}
+
+// CreateSnapshot pauses the VM and writes a snapshot of it along with a manifest of the VM's configuration,
+// which CreateVM needs to restore the VM from the snapshot. If the VM was running, it is resumed once the snapshot
+// has been written. Snapshots cannot be created while the VM is running containers.
+func (s *service) CreateSnapshot(requestCtx context.Context, request *proto.CreateSnapshotRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	for _, path := range []string{request.MemFilePath, request.SnapshotPath, request.ManifestPath} {
+		if !filepath.IsAbs(path) {
+			return nil, status.Errorf(codes.InvalidArgument, "snapshot path %q must be absolute", path)
+		}
+	}
+
+	containerStubs, err := s.containerStubHandler.prepareSnapshot(requestCtx, s.machine)
+	if errors.Is(err, ErrDrivesInUse) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot snapshot VM %q: %s", s.currentVMID(), err)
+	} else if err != nil {
+		err = fmt.Errorf("failed to prepare container stub drives for snapshot: %w", err)
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	driveMountStubs, err := mountableStubDrives(s.driveMountStubs)
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	// Stub drives provisioned since the VM booted must be restored as stub drives too
+	stubCount, driveCount := s.containerStubHandler.driveCounts()
+	s.bindMu.RLock()
+	vmRequest := protov2.Clone(s.createVMRequest).(*proto.CreateVMRequest)
+	s.bindMu.RUnlock()
+	vmRequest.ContainerCount = 0
+	vmRequest.MinContainerCount = int32(stubCount)
+	vmRequest.MaxContainerCount = int32(driveCount)
+
+	manifest := &proto.SnapshotManifest{Request: vmRequest}
+	for _, drive := range containerStubs {
+		manifest.ContainerStubs = append(manifest.ContainerStubs, snapshotStubDrive(drive))
+	}
+	for _, drive := range driveMountStubs {
+		manifest.DriveMountStubs = append(manifest.DriveMountStubs, snapshotStubDrive(drive))
+	}
+
+	info, err := s.machine.DescribeInstanceInfo(requestCtx)
+	if err != nil {
+		err = fmt.Errorf("failed to describe instance info: %w", err)
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if vmStateFromInstanceInfo(info) == proto.VMState_VM_STATE_RUNNING {
+		if err := s.machine.PauseVM(requestCtx); err != nil {
+			s.log().WithError(err).Error()
+			return nil, err
+		}
+
+		defer func() {
+			if err := s.machine.ResumeVM(requestCtx); err != nil {
+				s.log().WithError(err).Error("failed to resume VM after creating snapshot")
+			}
+		}()
+	}
+
+	s.log().Info("creating VM snapshot")
+	if err := s.machine.CreateSnapshot(requestCtx, snapshotMemFileName, snapshotStateFileName); err != nil {
+		err = fmt.Errorf("failed to create snapshot: %w", err)
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	// Firecracker writes the snapshot relative to its working directory, which is the root of the jail.
+	jailRoot := s.jailer.JailPath().RootPath()
+	if err := moveFile(filepath.Join(jailRoot, snapshotMemFileName), request.MemFilePath); err != nil {
+		err = fmt.Errorf("failed to move snapshot memory file: %w", err)
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if err := moveFile(filepath.Join(jailRoot, snapshotStateFileName), request.SnapshotPath); err != nil {
+		err = fmt.Errorf("failed to move snapshot state file: %w", err)
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	if err := writeSnapshotManifest(request.ManifestPath, manifest); err != nil {
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
@@ -695,46 +1215,207 @@ func (s *service) PauseVM(ctx context.Context, _ *proto.PauseVMRequest) (*types.
 
 // GetVMInfo returns metadata for the VM being managed by this shim. If the VM has not been created yet, this
 // method will wait for up to a hardcoded timeout for it to exist, returning an error if the timeout is reached.
-func (s *service) GetVMInfo(_ context.Context, _ *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
-	defer logPanicAndDie(s.logger)
+func (s *service) GetVMInfo(requestCtx context.Context, _ *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
+	return s.vmInfo(requestCtx), nil
+}
+
+// vmInfo returns metadata for the VM being managed by this shim, which must have been created.
+func (s *service) vmInfo(requestCtx context.Context) *proto.GetVMInfoResponse {
 	cgroupPath := ""
 	if c, ok := s.jailer.(cgroupPather); ok {
 		cgroupPath = c.CgroupPath()
 	}
 
+	state := proto.VMState_VM_STATE_UNKNOWN
+	info, err := s.machine.DescribeInstanceInfo(requestCtx)
+	if err != nil {
+		s.log().WithError(err).Warn("failed to describe instance info")
+	} else {
+		state = vmStateFromInstanceInfo(info)
+	}
+
+	vmHealth, healthErr := s.healthStatus.get()
+
 	return &proto.GetVMInfoResponse{
-		VMID:            s.vmID,
+		VMID:            s.currentVMID(),
 		SocketPath:      s.shimDir.FirecrackerSockPath(),
 		LogFifoPath:     s.machineConfig.LogPath,
 		MetricsFifoPath: s.machineConfig.MetricsPath,
 		CgroupPath:      cgroupPath,
 		VSockPath:       s.shimDir.FirecrackerVSockPath(),
+		State:           state,
+		Health:          vmHealth,
+		HealthError:     healthErr,
+	}
+}
+
+// ListVMs returns the single VM being managed by this shim. Unlike GetVMInfo, it doesn't wait for the
+// VM to be created: the state of a VM which is still being created is unknown.
+func (s *service) ListVMs(requestCtx context.Context, _ *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	defer logPanicAndDie(s.log())
+
+	select {
+	case <-s.vmReady:
+	default:
+		return &proto.ListVMsResponse{
+			VMs: []*proto.VMInfo{{
+				VMID:       s.currentVMID(),
+				ShimPID:    uint32(os.Getpid()),
+				SocketPath: s.shimDir.FirecrackerSockPath(),
+				VSockPath:  s.shimDir.FirecrackerVSockPath(),
+				State:      proto.VMState_VM_STATE_UNKNOWN,
+			}},
+		}, nil
+	}
+
+	info := s.vmInfo(requestCtx)
+	return &proto.ListVMsResponse{
+		VMs: []*proto.VMInfo{{
+			VMID:            info.VMID,
+			ShimPID:         uint32(os.Getpid()),
+			SocketPath:      info.SocketPath,
+			VSockPath:       info.VSockPath,
+			LogFifoPath:     info.LogFifoPath,
+			MetricsFifoPath: info.MetricsFifoPath,
+			CgroupPath:      info.CgroupPath,
+			State:           info.State,
+		}},
 	}, nil
 }
 
+// attachedDriveReservationID returns the ID a drive attached with AttachDrive is reserved with in
+// the container stub drive handler. Container IDs cannot contain "/", so it never collides with
+// the reservation of a container's rootfs drive.
+func attachedDriveReservationID(driveID string) string {
+	return "attached-drive/" + driveID
+}
+
+// AttachDrive attaches a drive to the running VM by patching one of its spare container stub
+// drives to the requested host path, then mounts it inside the VM. Container stub drives are
+// writable Firecracker drives and the drive of a running VM cannot be made read-only, so only
+// writable drives can be attached: a read-only mount inside the VM would not stop the guest
+// from remounting it read-write and writing to the host file.
+func (s *service) AttachDrive(requestCtx context.Context, request *proto.AttachDriveRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	driveMount := request.DriveMount
+	if request.DriveID == "" || driveMount == nil || driveMount.HostPath == "" || driveMount.VMPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "DriveID, DriveMount.HostPath and DriveMount.VMPath must be set")
+	}
+	if driveMount.RateLimiter != nil || driveMount.CacheType != "" {
+		return nil, status.Error(codes.InvalidArgument, "the rate limiter and cache type of an attached drive cannot be set")
+	}
+	if !driveMount.IsWritable {
+		return nil, status.Error(codes.InvalidArgument,
+			"only writable drives can be attached, as the spare drives of a running VM cannot be made read-only")
+	}
+
+	reservationID := attachedDriveReservationID(request.DriveID)
+	if _, ok := s.containerStubHandler.reservedDrive(reservationID); ok {
+		return nil, status.Errorf(codes.AlreadyExists, "drive %q is already attached", request.DriveID)
+	}
+
+	logger := s.log().WithField("drive_id", request.DriveID)
+	logger.Info("attaching drive")
+
+	attachStartedAt := time.Now()
+	err = s.containerStubHandler.Reserve(requestCtx, reservationID,
+		driveMount.HostPath, driveMount.VMPath, driveMount.FilesystemType, driveMount.Options,
+		s.driveMountClient, s.machine)
+	if errors.Is(err, ErrDrivesExhausted) {
+		return nil, status.Errorf(codes.ResourceExhausted, "no spare drive to attach drive %q", request.DriveID)
+	} else if errors.Is(err, ErrDriveReserved) {
+		return nil, status.Errorf(codes.AlreadyExists, "drive %q is already attached", request.DriveID)
+	} else if err != nil {
+		err = fmt.Errorf("failed to attach drive %q: %w", request.DriveID, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+	attachedAt := time.Now()
+
+	err = s.eventExchange.Publish(s.shimCtx, DriveAttachedEventName, &proto.DriveAttached{
+		VMID:       s.currentVMID(),
+		Namespace:  s.namespace,
+		DriveID:    request.DriveID,
+		HostPath:   driveMount.HostPath,
+		VMPath:     driveMount.VMPath,
+		AttachedAt: protobuf.ToTimestamp(attachedAt),
+		Duration:   durationpb.New(attachedAt.Sub(attachStartedAt)),
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to publish drive attach event")
+	}
+
+	return &types.Empty{}, nil
+}
+
+// DetachDrive unmounts a drive attached with AttachDrive inside the VM and patches its stub
+// drive back, so it can be reused by containers or other drives.
+func (s *service) DetachDrive(requestCtx context.Context, request *proto.DetachDriveRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	reservationID := attachedDriveReservationID(request.DriveID)
+	driveMount, ok := s.containerStubHandler.reservedDrive(reservationID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "drive %q is not attached", request.DriveID)
+	}
+
+	logger := s.log().WithField("drive_id", request.DriveID)
+	logger.Info("detaching drive")
+
+	err = s.containerStubHandler.Release(requestCtx, reservationID, s.driveMountClient, s.machine)
+	if err != nil {
+		err = fmt.Errorf("failed to detach drive %q: %w", request.DriveID, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	err = s.jailer.RemoveFileFromJail(driveMount.HostPath)
+	if err != nil {
+		err = fmt.Errorf("failed to remove drive %q from jail: %w", request.DriveID, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return &types.Empty{}, nil
+}
+
 // SetVMMetadata will update the VM being managed by this shim with the provided metadata. If the VM has not been created yet, this
 // method will wait for up to a hardcoded timeout for it to exist, returning an error if the timeout is reached.
 func (s *service) SetVMMetadata(requestCtx context.Context, request *proto.SetVMMetadataRequest) (*types.Empty, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Info("setting VM metadata")
+	s.log().Info("setting VM metadata")
 	jayson := json.RawMessage(request.Metadata)
 	if err := s.machine.SetMetadata(requestCtx, jayson); err != nil {
 		err = fmt.Errorf("failed to set VM metadata: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
@@ -746,19 +1427,19 @@ func (s *service) SetVMMetadata(requestCtx context.Context, request *proto.SetVM
 // to exist, returning an error if the timeout is reached.
 func (s *service) UpdateVMMetadata(requestCtx context.Context, request *proto.UpdateVMMetadataRequest) (*types.Empty, error) {
 
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Info("updating VM metadata")
+	s.log().Info("updating VM metadata")
 	jayson := json.RawMessage(request.Metadata)
 	if err := s.machine.UpdateMetadata(requestCtx, jayson); err != nil {
 		err = fmt.Errorf("failed to update VM metadata: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
@@ -769,19 +1450,19 @@ func (s *service) UpdateVMMetadata(requestCtx context.Context, request *proto.Up
 // If the vm has not been created yet, this method will wait for up to the hardcoded timeout for it
 // to exist, returning an error if the timeout is reached.
 func (s *service) GetVMMetadata(requestCtx context.Context, _ *proto.GetVMMetadataRequest) (*proto.GetVMMetadataResponse, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Info("Get VM metadata")
+	s.log().Info("Get VM metadata")
 	var metadata json.RawMessage
 	if err := s.machine.GetMetadata(requestCtx, &metadata); err != nil {
 		err = fmt.Errorf("failed to get VM metadata: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
@@ -798,7 +1479,7 @@ func (s *service) createBalloon(_ context.Context, request *proto.CreateVMReques
 	if balloon.AmountMib == nil || balloon.DeflateOnOom == nil {
 		return balloon, fmt.Errorf("One of balloon properties is nil, please check %+v: ", balloon)
 	}
-	s.logger.Infof("Creating a balloon device: AmountMib=%d, DeflateOnOom=%t and statsPollingIntervals=%d ", *balloon.AmountMib, *balloon.DeflateOnOom, balloon.StatsPollingIntervals)
+	s.log().Infof("Creating a balloon device: AmountMib=%d, DeflateOnOom=%t and statsPollingIntervals=%d ", *balloon.AmountMib, *balloon.DeflateOnOom, balloon.StatsPollingIntervals)
 	return balloon, nil
 }
 
@@ -814,15 +1495,15 @@ func (s *service) buildBalloonDeviceOpt(balloon models.Balloon) ([]firecracker.O
 
 // GetBalloonConfig will get configuration for an existing balloon device, before or after machine startup
 func (s *service) GetBalloonConfig(requestCtx context.Context, _ *proto.GetBalloonConfigRequest) (*proto.GetBalloonConfigResponse, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Info("Getting configuration for the balloon device")
+	s.log().Info("Getting configuration for the balloon device")
 	balloon, err := s.machine.GetBalloonConfig(requestCtx)
 	if err != nil {
 		return nil, errors.New("Failed to get balloon configuration. Please check if you have successfully created a balloon device")
@@ -837,42 +1518,74 @@ func (s *service) GetBalloonConfig(requestCtx context.Context, _ *proto.GetBallo
 
 // UpdateBalloon will update an existing balloon device, before or after machine startup
 func (s *service) UpdateBalloon(requestCtx context.Context, req *proto.UpdateBalloonRequest) (*types.Empty, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Infof("Updating balloon memory size, the new amount memory is %d MiB", req.AmountMib)
-	if err := s.machine.UpdateBalloon(requestCtx, req.AmountMib); err != nil {
-		err = fmt.Errorf("failed to update memory balloon: %w", err)
-		s.logger.WithError(err).Error()
+	s.log().Infof("Updating balloon memory size, the new amount memory is %d MiB", req.AmountMib)
+	if err := s.updateBalloon(requestCtx, req.AmountMib); err != nil {
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
 	return &types.Empty{}, nil
 }
 
+// updateBalloon sets the target size of the balloon device and publishes a BalloonUpdated event.
+func (s *service) updateBalloon(ctx context.Context, amountMib int64) error {
+	updateStartedAt := time.Now()
+	if err := s.machine.UpdateBalloon(ctx, amountMib); err != nil {
+		return fmt.Errorf("failed to update memory balloon: %w", err)
+	}
+	updatedAt := time.Now()
+
+	err := s.eventExchange.Publish(s.shimCtx, BalloonUpdatedEventName, &proto.BalloonUpdated{
+		VMID:      s.currentVMID(),
+		Namespace: s.namespace,
+		AmountMib: amountMib,
+		UpdatedAt: protobuf.ToTimestamp(updatedAt),
+		Duration:  durationpb.New(updatedAt.Sub(updateStartedAt)),
+	})
+	if err != nil {
+		s.log().WithError(err).Error("failed to publish balloon update event")
+	}
+
+	return nil
+}
+
 // GetBalloonStats will return the latest balloon device statistics, only if enabled pre-boot.
 func (s *service) GetBalloonStats(requestCtx context.Context, _ *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Info("Getting statistics for the balloon device")
-	balloonStats, err := s.machine.GetBalloonStats(requestCtx)
+	s.log().Info("Getting statistics for the balloon device")
+	resp, err := s.getBalloonStats(requestCtx)
 	if err != nil {
-		err = fmt.Errorf("failed to get balloon statistics: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
+	s.log().Info("GetBalloonStatsResponse: ", resp)
+
+	return resp, nil
+}
+
+// getBalloonStats returns the latest statistics of the balloon device.
+func (s *service) getBalloonStats(ctx context.Context) (*proto.GetBalloonStatsResponse, error) {
+	balloonStats, err := s.machine.GetBalloonStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balloon statistics: %w", err)
+	}
+
 	if balloonStats.ActualMib == nil ||
 		balloonStats.ActualPages == nil ||
 		balloonStats.TargetMib == nil ||
@@ -897,25 +1610,23 @@ func (s *service) GetBalloonStats(requestCtx context.Context, _ *proto.GetBalloo
 		TotalMemory:        balloonStats.TotalMemory,
 	}
 
-	s.logger.Info("GetBalloonStatsResponse: ", resp)
-
 	return resp, nil
 }
 
 // UpdateBalloonStats will update an existing balloon device statistics interval, before or after machine startup.
 func (s *service) UpdateBalloonStats(requestCtx context.Context, req *proto.UpdateBalloonStatsRequest) (*types.Empty, error) {
-	defer logPanicAndDie(s.logger)
+	defer logPanicAndDie(s.log())
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
-	s.logger.Info("updating balloon device statistics interval")
+	s.log().Info("updating balloon device statistics interval")
 	if err := s.machine.UpdateBalloonStats(requestCtx, req.StatsPollingIntervals); err != nil {
 		err = fmt.Errorf("failed to update balloon device statistics interval: %w", err)
-		s.logger.WithError(err).Error()
+		s.log().WithError(err).Error()
 		return nil, err
 	}
 
@@ -949,7 +1660,7 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 		}},
 		MachineCfg: machineConfigurationFromProto(s.config, req.MachineCfg),
 		LogLevel:   s.config.DebugHelper.GetFirecrackerLogLevel(),
-		VMID:       s.vmID,
+		VMID:       s.currentVMID(),
 	}
 
 	flag, err := internal.SupportCPUTemplate()
@@ -987,7 +1698,7 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 		cfg.NetNS = req.JailerConfig.NetNS
 	}
 
-	s.logger.Debugf("using socket path: %s", cfg.SocketPath)
+	s.log().Debugf("using socket path: %s", cfg.SocketPath)
 
 	// Kernel configuration
 
@@ -1006,22 +1717,19 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 	cfg.Drives = s.buildRootDrive(req)
 
 	// Drives configuration
-	containerCount := int(req.ContainerCount)
-	if containerCount < 1 {
-		// containerCount should always be positive so that at least one container
-		// can run inside the VM. This makes the assumption that a task is going
-		// to be run, and to do that at least one container is needed.
-		containerCount = 1
+	minContainerCount, maxContainerCount, err := containerCounts(req)
+	if err != nil {
+		return nil, err
 	}
 
 	s.containerStubHandler, err = CreateContainerStubs(
-		&cfg, s.jailer, containerCount, s.logger)
+		&cfg, s.jailer, minContainerCount, maxContainerCount, s.log())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container stub drives: %w", err)
 	}
 
 	s.driveMountStubs, err = CreateDriveMountStubs(
-		&cfg, s.jailer, req.DriveMounts, s.logger)
+		&cfg, s.jailer, req.DriveMounts, s.log())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create drive mount stub drives: %w", err)
 	}
@@ -1036,7 +1744,7 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 	}
 
 	for _, ni := range req.NetworkInterfaces {
-		netCfg, err := networkConfigFromProto(ni, s.vmID)
+		netCfg, err := networkConfigFromProto(ni, s.currentVMID())
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert network config %+v: %w", ni, err)
 		}
@@ -1078,14 +1786,14 @@ func (s *service) newIOProxy(logger *logrus.Entry, stdin, stdout, stderr string,
 		if stdin != "" {
 			stdinConnectorPair = &vm.IOConnectorPair{
 				ReadConnector:  vm.ReadFIFOConnector(stdin),
-				WriteConnector: vm.VSockDialConnector(defaultVSockConnectTimeout, relVSockPath, extraData.StdinPort),
+				WriteConnector: countIO(vm.VSockDialConnector(defaultVSockConnectTimeout, relVSockPath, extraData.StdinPort, countVSockDialRetry), "stdin"),
 			}
 		}
 
 		var stdoutConnectorPair *vm.IOConnectorPair
 		if stdout != "" {
 			stdoutConnectorPair = &vm.IOConnectorPair{
-				ReadConnector:  vm.VSockDialConnector(defaultVSockConnectTimeout, relVSockPath, extraData.StdoutPort),
+				ReadConnector:  countIO(vm.VSockDialConnector(defaultVSockConnectTimeout, relVSockPath, extraData.StdoutPort, countVSockDialRetry), "stdout"),
 				WriteConnector: vm.WriteFIFOConnector(stdout),
 			}
 		}
@@ -1093,7 +1801,7 @@ func (s *service) newIOProxy(logger *logrus.Entry, stdin, stdout, stderr string,
 		var stderrConnectorPair *vm.IOConnectorPair
 		if stderr != "" {
 			stderrConnectorPair = &vm.IOConnectorPair{
-				ReadConnector:  vm.VSockDialConnector(defaultVSockConnectTimeout, relVSockPath, extraData.StderrPort),
+				ReadConnector:  countIO(vm.VSockDialConnector(defaultVSockConnectTimeout, relVSockPath, extraData.StderrPort, countVSockDialRetry), "stderr"),
 				WriteConnector: vm.WriteFIFOConnector(stderr),
 			}
 		}
@@ -1137,7 +1845,7 @@ func (s *service) deleteFIFOs(taskID, execID string) error {
 }
 
 func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTaskRequest) (*taskAPI.CreateTaskResponse, error) {
-	logger := s.logger.WithField("task_id", request.ID)
+	logger := s.log().WithField("task_id", request.ID)
 	defer logPanicAndDie(logger)
 
 	err := s.waitVMReady()
@@ -1171,7 +1879,7 @@ func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTask
 	}
 
 	// We don't support a rootfs with multiple mounts, only one mount can be exposed to the
-	// vm per-container
+	// vm per-container. The layers of an overlay mount are exposed as separate drives though.
 	if len(request.Rootfs) != 1 {
 		return nil, fmt.Errorf("can only support rootfs with exactly one mount: %+v", request.Rootfs)
 	}
@@ -1181,7 +1889,25 @@ func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTask
 
 	// Only mount the container's rootfs as a block device if the mount doesn't
 	// signal that it is only accessible from inside the VM.
-	if !isVMLocalRootfs {
+	var vmOverlay *vm.OverlayMount
+	if isVMLocalRootfs {
+		// nothing to expose to the VM
+	} else if vm.IsOverlayMount(rootfsMnt) {
+		overlay, err := vm.ParseOverlayMount(rootfsMnt)
+		if err != nil {
+			logger.WithError(err).Error()
+			return nil, err
+		}
+
+		var driveIDs []string
+		driveIDs, vmOverlay, err = s.reserveOverlayDrives(requestCtx, request.ID, vmBundleDir, overlay)
+		if err != nil {
+			err = fmt.Errorf("failed to get stub drives for task %q: %w", request.ID, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
+		s.blockDeviceTasks[request.ID] = driveIDs
+	} else {
 		err = s.containerStubHandler.Reserve(requestCtx, request.ID,
 			rootfsMnt.Source, vmBundleDir.RootfsPath(), "ext4", nil, s.driveMountClient, s.machine)
 		if err != nil {
@@ -1189,7 +1915,17 @@ func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTask
 			logger.WithError(err).Error()
 			return nil, err
 		}
-		s.blockDeviceTasks[request.ID] = struct{}{}
+		s.blockDeviceTasks[request.ID] = []string{request.ID}
+	}
+
+	// A checkpoint on the host is exposed to the VM as a drive the task is restored from
+	if request.Checkpoint != "" {
+		request.Checkpoint, err = s.reserveRestoreDrive(requestCtx, request.ID, request.Checkpoint)
+		if err != nil {
+			err = fmt.Errorf("failed to expose checkpoint of task %q: %w", request.ID, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
 	}
 
 	ociConfigBytes, err := hostBundleDir.OCIConfig().Bytes()
@@ -1219,7 +1955,11 @@ func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTask
 	// override the request with the bundle dir that should be used inside the VM
 	request.Bundle = vmBundleDir.RootPath()
 
-	if !isVMLocalRootfs {
+	if vmOverlay != nil {
+		// The layers of an overlay rootfs are mounted via MountDrive calls, and the agent
+		// assembles them back into an overlay mounted at the bundle's rootfs.
+		request.Rootfs[0] = vmOverlay.Mount()
+	} else if !isVMLocalRootfs {
 		// If the rootfs is not inside the VM, it is mounted via a MountDrive call,
 		// so unset Rootfs in the request.
 		// We unfortunately can't rely on just having the runc shim inside the VM do
@@ -1248,9 +1988,75 @@ func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTask
 		return nil, err
 	}
 
+	s.addTaskState(request.ID)
+
 	return resp, nil
 }
 
+// reserveOverlayDrives exposes each layer of an overlay rootfs to the VM as its own stub drive
+// mounted in the VM's bundle dir: a read-only drive per lower layer, backed by a private copy of
+// the layer as the guest could otherwise write to layers shared with other containers, and a
+// writable drive for the upper layer. The layers must be block devices or filesystem images of
+// the configured overlay filesystem type: overlays of directories, such as the ones of the
+// overlayfs snapshotter, cannot be exposed to the VM and are rejected.
+// The upper layer's drive holds both the upper directory and the work directory of the overlay
+// inside the VM, so the work directory on the host is ignored. It returns the IDs the drives are
+// reserved with and the overlay mount of the layers inside the VM.
+func (s *service) reserveOverlayDrives(
+	requestCtx context.Context,
+	taskID string,
+	vmBundleDir bundle.Dir,
+	overlay *vm.OverlayMount,
+) (_ []string, _ *vm.OverlayMount, err error) {
+	var driveIDs []string
+	defer func() {
+		if err != nil {
+			for _, driveID := range driveIDs {
+				if releaseErr := s.containerStubHandler.Release(requestCtx, driveID, s.driveMountClient, s.machine); releaseErr != nil {
+					s.log().WithError(releaseErr).Errorf("failed to release stub drive %q", driveID)
+				}
+			}
+		}
+	}()
+
+	layers := overlay.LowerDirs
+	if overlay.UpperDir != "" {
+		layers = append(layers[:len(layers):len(layers)], overlay.UpperDir)
+	}
+	for _, layer := range layers {
+		if info, statErr := os.Stat(layer); statErr == nil && info.IsDir() {
+			return nil, nil, fmt.Errorf("overlay layer %q is a directory: only layers backed by block devices or filesystem images can be exposed to the VM", layer)
+		}
+	}
+
+	filesystemType := s.config.OverlayFilesystemType
+	vmOverlay := &vm.OverlayMount{Options: overlay.Options}
+	for i, lowerDir := range overlay.LowerDirs {
+		driveID := fmt.Sprintf("%s#layer%d", taskID, i)
+		err = s.containerStubHandler.reservePrivateCopy(requestCtx, driveID, lowerDir, fmt.Sprintf("%s-layer%d.img", taskID, i),
+			vmBundleDir.LowerLayerPath(i), filesystemType, s.driveMountClient, s.machine)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mount layer %q: %w", lowerDir, err)
+		}
+		driveIDs = append(driveIDs, driveID)
+		vmOverlay.LowerDirs = append(vmOverlay.LowerDirs, vmBundleDir.LowerLayerPath(i))
+	}
+
+	if overlay.UpperDir != "" {
+		driveID := taskID + "#upper"
+		err = s.containerStubHandler.Reserve(requestCtx, driveID,
+			overlay.UpperDir, vmBundleDir.UpperLayerPath(), filesystemType, nil, s.driveMountClient, s.machine)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mount upper layer %q: %w", overlay.UpperDir, err)
+		}
+		driveIDs = append(driveIDs, driveID)
+		vmOverlay.UpperDir = filepath.Join(vmBundleDir.UpperLayerPath(), "upper")
+		vmOverlay.WorkDir = filepath.Join(vmBundleDir.UpperLayerPath(), "work")
+	}
+
+	return driveIDs, vmOverlay, nil
+}
+
 func (s *service) Start(requestCtx context.Context, req *taskAPI.StartRequest) (*taskAPI.StartResponse, error) {
 	defer logPanicAndDie(log.G(requestCtx))
 
@@ -1264,6 +2070,12 @@ func (s *service) Start(requestCtx context.Context, req *taskAPI.StartRequest) (
 		return nil, err
 	}
 
+	if req.ExecID == "" {
+		if err := s.releaseRestoreDrive(requestCtx, req.ID); err != nil {
+			log.G(requestCtx).WithError(err).WithField("task_id", req.ID).Error("failed to release checkpoint drive")
+		}
+	}
+
 	return resp, nil
 }
 
@@ -1295,12 +2107,17 @@ func (s *service) Delete(requestCtx context.Context, req *taskAPI.DeleteRequest)
 
 	var result *multierror.Error
 
-	if _, contains := s.blockDeviceTasks[req.ID]; contains {
-		// Trying to release stub drive for further reuse
-		if err := s.containerStubHandler.Release(requestCtx, req.ID, s.driveMountClient, s.machine); err != nil {
-			result = multierror.Append(fmt.Errorf("failed to release stub drive for container: %s: %w", req.ID, err))
+	// Trying to release stub drives for further reuse
+	for _, driveID := range s.blockDeviceTasks[req.ID] {
+		if err := s.containerStubHandler.Release(requestCtx, driveID, s.driveMountClient, s.machine); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to release stub drive for container: %s: %w", req.ID, err))
 		}
 	}
+	if err := s.releaseRestoreDrive(requestCtx, req.ID); err != nil {
+		result = multierror.Append(result, fmt.Errorf("failed to release checkpoint drive for container: %s: %w", req.ID, err))
+	}
+
+	s.removeTaskState(req.ID)
 
 	// Otherwise, delete the container
 	dir, err := s.shimDir.BundleLink(req.ID)
@@ -1323,7 +2140,7 @@ func (s *service) Delete(requestCtx context.Context, req *taskAPI.DeleteRequest)
 // Exec an additional process inside the container
 func (s *service) Exec(requestCtx context.Context, req *taskAPI.ExecProcessRequest) (*types.Empty, error) {
 	defer logPanicAndDie(log.G(requestCtx))
-	logger := s.logger.WithField("task_id", req.ID).WithField("exec_id", req.ExecID)
+	logger := s.log().WithField("task_id", req.ID).WithField("exec_id", req.ExecID)
 	logger.Debug("exec")
 
 	agent, err := s.agent()
@@ -1553,21 +2370,22 @@ func (s *service) CloseIO(requestCtx context.Context, req *taskAPI.CloseIOReques
 	return resp, nil
 }
 
-// Checkpoint the container
+// Checkpoint the container. The agent checkpoints the container to a scratch drive, which is
+// then copied to req.Path on the host.
 func (s *service) Checkpoint(requestCtx context.Context, req *taskAPI.CheckpointTaskRequest) (*types.Empty, error) {
 	defer logPanicAndDie(log.G(requestCtx))
 
-	log.G(requestCtx).WithFields(logrus.Fields{"task_id": req.ID, "path": req.Path}).Info("checkpoint")
-	agent, err := s.agent()
-	if err != nil {
-		return nil, err
-	}
-	resp, err := agent.Checkpoint(requestCtx, req)
+	logger := log.G(requestCtx).WithFields(logrus.Fields{"task_id": req.ID, "path": req.Path})
+	logger.Info("checkpoint")
+
+	err := s.checkpoint(requestCtx, req)
 	if err != nil {
+		err = fmt.Errorf("failed to checkpoint task %q: %w", req.ID, err)
+		logger.WithError(err).Error()
 		return nil, err
 	}
 
-	return resp, nil
+	return &types.Empty{}, nil
 }
 
 // Connect returns shim information such as the shim's pid
@@ -1598,9 +2416,13 @@ func (s *service) Connect(requestCtx context.Context, req *taskAPI.ConnectReques
 // * After any task Create call returns an error
 func (s *service) Shutdown(requestCtx context.Context, req *taskAPI.ShutdownRequest) (*types.Empty, error) {
 	defer logPanicAndDie(log.G(requestCtx))
-	s.logger.WithFields(logrus.Fields{"task_id": req.ID, "now": req.Now}).Debug("Shutdown")
+	s.log().WithFields(logrus.Fields{"task_id": req.ID, "now": req.Now}).Debug("Shutdown")
+
+	s.bindMu.RLock()
+	exitAfterAllTasksDeleted := s.exitAfterAllTasksDeleted
+	s.bindMu.RUnlock()
 
-	shouldShutdown := req.Now || s.exitAfterAllTasksDeleted && s.taskManager.ShutdownIfEmpty()
+	shouldShutdown := req.Now || exitAfterAllTasksDeleted && s.taskManager.ShutdownIfEmpty()
 	if !shouldShutdown {
 		return &types.Empty{}, nil
 	}
@@ -1624,19 +2446,20 @@ func (s *service) isPaused(ctx context.Context) (bool, error) {
 }
 
 func (s *service) forceTerminate(_ context.Context) error {
-	s.logger.Errorf("forcefully terminate VM %s", s.vmID)
+	s.log().Errorf("forcefully terminate VM %s", s.currentVMID())
+	s.recordExitReason("forcefully terminated after failing to shut down gracefully", true)
 
 	err := s.jailer.Stop(true)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to stop")
+		s.log().WithError(err).Error("failed to stop")
 	}
 
 	err = s.cleanup()
 	if err != nil {
-		s.logger.WithError(err).Error("failed to cleanup")
+		s.log().WithError(err).Error("failed to cleanup")
 	}
 
-	return status.Errorf(codes.Internal, "forcefully terminated VM %s", s.vmID)
+	return status.Errorf(codes.Internal, "forcefully terminated VM %s", s.currentVMID())
 }
 
 func (s *service) terminate(ctx context.Context) (retErr error) {
@@ -1649,35 +2472,36 @@ func (s *service) terminate(ctx context.Context) (retErr error) {
 
 	err := s.waitVMReady()
 	if err != nil {
-		s.logger.WithError(err).Error("failed to wait VM")
+		s.log().WithError(err).Error("failed to wait VM")
 		return
 	}
 
 	paused, err := s.isPaused(ctx)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to check VM")
+		s.log().WithError(err).Error("failed to check VM")
 		return
 	}
 
 	if paused {
-		s.logger.Error("VM is paused and cannot take requests")
+		s.log().Error("VM is paused and cannot take requests")
 		return
 	}
 
-	s.logger.Info("gracefully shutdown VM")
+	s.log().Info("gracefully shutdown VM")
+	s.recordExitReason("shut down", false)
 	agent, err := s.agent()
 	if err != nil {
 		return err
 	}
-	_, err = agent.Shutdown(ctx, &taskAPI.ShutdownRequest{ID: s.vmID, Now: true})
+	_, err = agent.Shutdown(ctx, &taskAPI.ShutdownRequest{ID: s.currentVMID(), Now: true})
 	if err != nil {
-		s.logger.WithError(err).Error("failed to call in-VM agent")
+		s.log().WithError(err).Error("failed to call in-VM agent")
 		return
 	}
 
 	err = s.machine.Wait(ctx)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to wait VM")
+		s.log().WithError(err).Error("failed to wait VM")
 		return
 	}
 
@@ -1735,16 +2559,86 @@ func (s *service) Wait(requestCtx context.Context, req *taskAPI.WaitRequest) (*t
 	return resp, nil
 }
 
+// Cleanup is called through "shim delete" once the shim of a task is gone without the task
+// having been deleted, usually because the shim crashed. The VM of the task is found from the
+// shim address link in the task's bundle. Unless the VM's shim is still running, the VM is torn
+// down along with its jail, CNI networks and FIFOs, and its shim directory is removed. The task's
+// exit status is the one recorded by the shim, or SIGKILL if its init process hadn't exited.
 func (s *service) Cleanup(requestCtx context.Context) (*taskAPI.DeleteResponse, error) {
 	defer logPanicAndDie(log.G(requestCtx))
 
-	log.G(requestCtx).Debug("cleanup")
-	// Destroy VM/etc here?
-	// copied from runcs impl, nothing to cleanup atm
-	return &taskAPI.DeleteResponse{
+	logger := log.G(requestCtx).WithField("task_id", s.taskID)
+	logger.Debug("cleanup")
+
+	resp := &taskAPI.DeleteResponse{
 		ExitedAt:   protobuf.ToTimestamp(time.Now()),
 		ExitStatus: 128 + uint32(unix.SIGKILL),
-	}, nil
+	}
+
+	opts, err := shimOpts(s.shimCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	shimDir, err := shimDirFromBundle(bundle.Dir(opts.BundlePath))
+	if os.IsNotExist(err) {
+		logger.Debug("task has no VM to clean up")
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Shims remove their state file once they have cleaned up after their VM.
+	state, err := shimDir.ReadState()
+	if os.IsNotExist(err) {
+		logger.Debug("VM has already been cleaned up")
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("vmID", state.VMID)
+
+	if exit, ok := state.TaskExits[s.taskID]; ok {
+		resp.ExitStatus = exit.ExitStatus
+		resp.ExitedAt = protobuf.ToTimestamp(exit.ExitedAt)
+	}
+
+	shimRunning, err := fcShim.ShimRunning(requestCtx, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if shim process %d exists: %w", state.ShimPID, err)
+	}
+	if shimRunning {
+		logger.Debug("VM is still managed by its shim")
+		return resp, nil
+	}
+
+	logger.Info("tearing down VM of dead shim")
+	if err := fcShim.TeardownVM(requestCtx, shimDir, state); err != nil {
+		err = fmt.Errorf("failed to tear down VM %q: %w", state.VMID, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if err := os.RemoveAll(shimDir.RootPath()); err != nil {
+		return nil, fmt.Errorf("failed to remove shim dir %q: %w", shimDir.RootPath(), err)
+	}
+
+	return resp, nil
+}
+
+// shimDirFromBundle returns the shim dir of the VM a task runs in, as linked from the task's
+// bundle by the shim address link.
+func shimDirFromBundle(bundleDir bundle.Dir) (vm.Dir, error) {
+	addrPath, err := os.Readlink(bundleDir.AddrFilePath())
+	if err != nil {
+		return "", err
+	}
+
+	if !filepath.IsAbs(addrPath) {
+		addrPath = filepath.Join(bundleDir.RootPath(), addrPath)
+	}
+
+	return vm.Dir(filepath.Dir(addrPath)), nil
 }
 
 // cleanup resources
@@ -1755,12 +2649,30 @@ func (s *service) cleanup() error {
 		// process was killed via SIGKILL
 		if err := s.jailer.Close(); err != nil {
 			result = multierror.Append(result, err)
-			s.logger.WithError(err).Error("failed to close jailer")
+			s.log().WithError(err).Error("failed to close jailer")
+		}
+
+		if err := s.removeState(); err != nil {
+			result = multierror.Append(result, err)
+			s.log().WithError(err).Error("failed to remove shim state")
+		}
+
+		if err := s.publishVMExited(); err != nil {
+			result = multierror.Append(result, err)
+			s.log().WithError(err).Error("failed to publish exit VM event")
 		}
 
 		if err := s.publishVMStop(); err != nil {
 			result = multierror.Append(result, err)
-			s.logger.WithError(err).Error("failed to publish stop VM event")
+			s.log().WithError(err).Error("failed to publish stop VM event")
+		}
+
+		if s.shutdownTracing != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+			if err := s.shutdownTracing(ctx); err != nil {
+				s.log().WithError(err).Warn("failed to flush spans")
+			}
+			cancel()
 		}
 
 		// once the VM shuts down, the shim should too
@@ -1775,11 +2687,13 @@ func (s *service) cleanup() error {
 func (s *service) monitorVMExit() {
 	// Block until the VM exits
 	if err := s.machine.Wait(s.shimCtx); err != nil && err != context.Canceled {
-		s.logger.WithError(err).Error("error returned from VM wait")
+		s.log().WithError(err).Error("error returned from VM wait")
+		s.recordExitReason(fmt.Sprintf("exited with error: %v", err), false)
 	}
+	s.recordExitReason("exited", false)
 
 	if err := s.cleanup(); err != nil {
-		s.logger.WithError(err).Error("failed to clean up the VM")
+		s.log().WithError(err).Error("failed to clean up the VM")
 	}
 }
 
@@ -1787,7 +2701,7 @@ func (s *service) monitorVMExit() {
 func (s *service) agent() (taskAPI.TaskService, error) {
 	pid, _ := s.machine.PID()
 	if pid == 0 {
-		return nil, status.Errorf(codes.NotFound, "failed to find VM %q", s.vmID)
+		return nil, status.Errorf(codes.NotFound, "failed to find VM %q", s.currentVMID())
 	}
 	return s.agentClient, nil
 }
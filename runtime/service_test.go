@@ -15,23 +15,33 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/containerd/containerd/api/runtime/task/v2"
+	"github.com/containerd/containerd/events/exchange"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/containerd/typeurl/v2"
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/bundle"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/debug"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
@@ -368,3 +378,194 @@ func TestTaskCreateDoesNotLogStdoutOrStderrFields(t *testing.T) {
 		}
 	}
 }
+
+func TestVMExitedEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(namespaces.WithNamespace(context.Background(), defaultNamespace))
+	defer cancel()
+
+	uut := service{
+		logger:        logrus.NewEntry(logrus.New()),
+		eventExchange: exchange.NewExchange(),
+		shimCtx:       ctx,
+		vmID:          "vm",
+		namespace:     defaultNamespace,
+		vmStartedAt:   time.Now().Add(-time.Minute),
+	}
+
+	eventCh, errCh := uut.eventExchange.Subscribe(ctx, fmt.Sprintf(`topic=="%s"`, ExitEventName))
+
+	// the graceful shutdown attempt is superseded by the forced termination, and the VM
+	// exiting afterwards doesn't change why it exited
+	uut.recordExitReason("shut down", false)
+	uut.recordExitReason("forcefully terminated", true)
+	uut.recordExitReason("exited", false)
+	require.NoError(t, uut.publishVMExited())
+
+	select {
+	case envelope := <-eventCh:
+		event, err := typeurl.UnmarshalAny(envelope.Event)
+		require.NoError(t, err)
+		exited, ok := event.(*proto.VMExited)
+		require.True(t, ok, "unexpected event %T", event)
+		assert.Equal(t, "vm", exited.VMID)
+		assert.Equal(t, defaultNamespace, exited.Namespace)
+		assert.Equal(t, "forcefully terminated", exited.ExitReason)
+		assert.True(t, exited.Forced)
+		assert.GreaterOrEqual(t, exited.Uptime.AsDuration(), time.Minute)
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for exit event")
+	}
+}
+
+func TestListVMsDoesNotWaitForVM(t *testing.T) {
+	uut := service{
+		logger:  logrus.NewEntry(logrus.New()),
+		vmID:    "vm",
+		shimDir: vm.Dir(t.TempDir()),
+		vmReady: make(chan struct{}),
+	}
+
+	resp, err := uut.ListVMs(context.Background(), &proto.ListVMsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.VMs, 1)
+	assert.Equal(t, "vm", resp.VMs[0].VMID)
+	assert.Equal(t, uint32(os.Getpid()), resp.VMs[0].ShimPID)
+	assert.Equal(t, proto.VMState_VM_STATE_UNKNOWN, resp.VMs[0].State)
+}
+
+func TestBindWarmVM(t *testing.T) {
+	vmReady := make(chan struct{})
+	close(vmReady)
+
+	var metadata interface{}
+	machine, err := firecracker.NewMachine(context.Background(), firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PutMmdsFn: func(params *ops.PutMmdsParams) (*ops.PutMmdsNoContent, error) {
+				metadata = params.Body
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err)
+
+	uut := service{
+		logger:          logrus.NewEntry(logrus.New()),
+		vmID:            "warm-pool-pool-vm",
+		shimCtx:         namespaces.WithNamespace(context.Background(), defaultNamespace),
+		shimDir:         vm.Dir(t.TempDir()),
+		machine:         machine,
+		machineConfig:   &firecracker.Config{},
+		eventExchange:   exchange.NewExchange(),
+		createVMRequest: &proto.CreateVMRequest{VMID: "warm-pool-pool-vm"},
+		warmPool:        true,
+		vmReady:         vmReady,
+	}
+
+	// The shim keeps serving requests and monitoring its VM while it is bound
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				uut.log().WithField("current", uut.currentVMID()).Debug("reading VMID")
+			}
+		}
+	}()
+
+	resp, err := uut.bindWarmVM(context.Background(), &proto.CreateVMRequest{
+		VMID:                     "vm",
+		ExitAfterAllTasksDeleted: true,
+		Metadata:                 `{"foo":"bar"}`,
+	}, time.Now())
+	close(done)
+	<-readerDone
+	require.NoError(t, err)
+
+	assert.Equal(t, "vm", resp.VMID)
+	assert.Equal(t, "vm", uut.currentVMID())
+	assert.Equal(t, "vm", uut.log().Data["vmID"])
+	assert.True(t, uut.exitAfterAllTasksDeleted)
+	assert.Equal(t, "vm", uut.createVMRequest.VMID)
+	assert.Equal(t, json.RawMessage(`{"foo":"bar"}`), metadata, "the metadata should be rebound")
+	assert.Equal(t, `{"foo":"bar"}`, uut.createVMRequest.Metadata)
+
+	_, err = uut.bindWarmVM(context.Background(), &proto.CreateVMRequest{VMID: "other"}, time.Now())
+	assert.Error(t, err, "a warm pool VM must only be bound once")
+	assert.Equal(t, "vm", uut.currentVMID())
+}
+
+func TestCleanupAfterDeadShim(t *testing.T) {
+	bundleDir := bundle.Dir(t.TempDir())
+	shimDir := vm.Dir(t.TempDir())
+	require.NoError(t, os.Symlink(shimDir.AddrFilePath(), bundleDir.AddrFilePath()))
+
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+
+	exitedAt := time.Now().Add(-time.Minute).UTC()
+	require.NoError(t, shimDir.WriteState(&vm.ShimState{
+		Namespace: defaultNamespace,
+		VMID:      "vm",
+		ShimPID:   exited.Process.Pid,
+		TaskIDs:   []string{"task"},
+		TaskExits: map[string]vm.TaskExit{
+			"task": {ExitStatus: 3, ExitedAt: exitedAt},
+		},
+	}))
+
+	shimCtx := context.WithValue(context.Background(), shim.OptsKey{}, shim.Opts{BundlePath: bundleDir.RootPath()})
+	uut := service{
+		shimCtx: shimCtx,
+		taskID:  "task",
+	}
+
+	resp, err := uut.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), resp.ExitStatus)
+	assert.True(t, exitedAt.Equal(resp.ExitedAt.AsTime()))
+	assert.NoDirExists(t, shimDir.RootPath())
+
+	// tasks without a VM left to clean up are reported as killed
+	resp, err = uut.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 128+uint32(unix.SIGKILL), resp.ExitStatus)
+}
+
+func TestCNIState(t *testing.T) {
+	cniConf := func(network string) *firecracker.CNIConfiguration {
+		return &firecracker.CNIConfiguration{
+			NetworkName: network,
+			IfName:      "veth0",
+			BinPath:     []string{"/opt/cni/bin"},
+			ConfDir:     "/etc/cni/conf.d",
+			CacheDir:    "/var/lib/cni",
+		}
+	}
+
+	networks := cniState(firecracker.Config{
+		VMID:  "vm",
+		NetNS: "/var/run/netns/vm",
+		NetworkInterfaces: firecracker.NetworkInterfaces{
+			{CNIConfiguration: cniConf("first")},
+			{StaticConfiguration: &firecracker.StaticNetworkConfiguration{HostDevName: hostDevName}},
+			{CNIConfiguration: cniConf("second")},
+		},
+	}, true)
+
+	// every CNI network is recorded so all of them can be torn down
+	require.Len(t, networks, 2)
+	assert.Equal(t, "first", networks[0].NetworkName)
+	assert.Equal(t, "second", networks[1].NetworkName)
+	for _, network := range networks {
+		assert.Equal(t, "vm", network.ContainerID)
+		assert.Equal(t, "/var/run/netns/vm", network.NetNS)
+		assert.True(t, network.OwnsNetNS)
+	}
+
+	assert.Empty(t, cniState(firecracker.Config{}, true))
+}
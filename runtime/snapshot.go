@@ -0,0 +1,146 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	// snapshotMemFileName and snapshotStateFileName are the names of the files Firecracker
+	// writes a snapshot to. They are relative to the working directory of Firecracker, which
+	// is the jail's root for every jailer.
+	snapshotMemFileName   = "snapshot.mem"
+	snapshotStateFileName = "snapshot.state"
+)
+
+// readSnapshotManifest reads the manifest written by CreateSnapshot from the provided path.
+func readSnapshotManifest(path string) (*proto.SnapshotManifest, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	manifest := &proto.SnapshotManifest{}
+	if err := protojson.Unmarshal(contents, manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot manifest %q: %w", path, err)
+	}
+
+	if manifest.Request == nil {
+		return nil, fmt.Errorf("snapshot manifest %q does not contain a CreateVMRequest", path)
+	}
+
+	return manifest, nil
+}
+
+// writeSnapshotManifest writes the provided manifest to path.
+func writeSnapshotManifest(path string, manifest *proto.SnapshotManifest) error {
+	contents, err := protojson.MarshalOptions{Multiline: true}.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+
+	return os.WriteFile(path, contents, 0600)
+}
+
+// restoreRequest returns the CreateVMRequest used to set up a VM restored from the snapshot
+// described by manifest. The VM configuration is taken from the manifest, while the settings
+// scoped to the new shim are taken from request. The manifest's request is modified in place.
+func restoreRequest(request *proto.CreateVMRequest, manifest *proto.SnapshotManifest) *proto.CreateVMRequest {
+	restored := manifest.Request
+
+	restored.VMID = request.VMID
+	restored.ExitAfterAllTasksDeleted = request.ExitAfterAllTasksDeleted
+	restored.JailerConfig = request.JailerConfig
+	restored.TimeoutSeconds = request.TimeoutSeconds
+	restored.LogFifoPath = request.LogFifoPath
+	restored.MetricsFifoPath = request.MetricsFifoPath
+	restored.Snapshot = request.Snapshot
+
+	return restored
+}
+
+// snapshotStubDrive returns the manifest entry of the provided stub drive.
+func snapshotStubDrive(drive stubDrive) *proto.SnapshotStubDrive {
+	return &proto.SnapshotStubDrive{
+		DriveID:      drive.driveID,
+		StubFileName: filepath.Base(drive.stubPath),
+		DriveMount:   drive.driveMount,
+	}
+}
+
+// verifySnapshotStubDrives checks that the stub drives created for a restored VM match the
+// ones recorded in the snapshot's manifest. Firecracker restores drives by their IDs, so
+// any difference means the snapshot cannot be loaded into the VM as configured.
+func verifySnapshotStubDrives(expected []*proto.SnapshotStubDrive, actual []stubDrive) error {
+	if len(expected) != len(actual) {
+		return fmt.Errorf("snapshot has %d stub drives, but %d were created", len(expected), len(actual))
+	}
+
+	stubFileNames := make(map[string]string, len(expected))
+	for _, entry := range expected {
+		stubFileNames[entry.DriveID] = entry.StubFileName
+	}
+
+	for _, drive := range actual {
+		entry := snapshotStubDrive(drive)
+		stubFileName, ok := stubFileNames[entry.DriveID]
+		if !ok || stubFileName != entry.StubFileName {
+			return fmt.Errorf("stub drive %q (%s) was not found in snapshot", entry.DriveID, entry.StubFileName)
+		}
+	}
+
+	return nil
+}
+
+// mountableStubDrives converts drive mount stubs back to their stub drives.
+func mountableStubDrives(drives []MountableStubDrive) ([]stubDrive, error) {
+	stubDrives := make([]stubDrive, 0, len(drives))
+	for _, drive := range drives {
+		sd, ok := drive.(stubDrive)
+		if !ok {
+			return nil, fmt.Errorf("unexpected drive mount stub type %T", drive)
+		}
+		stubDrives = append(stubDrives, sd)
+	}
+	return stubDrives, nil
+}
+
+// moveFile moves src to dst, falling back to copying the file when they are on different
+// filesystems.
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if err := copyFile(src, dst, info.Mode()); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
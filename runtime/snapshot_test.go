@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/log"
+	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestSnapshotManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	manifest := &proto.SnapshotManifest{
+		Request: &proto.CreateVMRequest{
+			VMID:            "snapshotted",
+			KernelArgs:      "console=ttyS0",
+			ContainerCount:  2,
+			TimeoutSeconds:  10,
+			LogFifoPath:     "/old/log",
+			MetricsFifoPath: "/old/metrics",
+			DriveMounts: []*proto.FirecrackerDriveMount{
+				{HostPath: "/foo/1", VMPath: "/bar/1", FilesystemType: "ext4"},
+			},
+		},
+		ContainerStubs: []*proto.SnapshotStubDrive{
+			{DriveID: "id0", StubFileName: "ctrstub0"},
+			{DriveID: "id1", StubFileName: "ctrstub1"},
+		},
+	}
+	require.NoError(t, writeSnapshotManifest(path, manifest))
+
+	read, err := readSnapshotManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, "snapshotted", read.Request.VMID)
+	assert.Equal(t, int32(2), read.Request.ContainerCount)
+	assert.Len(t, read.ContainerStubs, 2)
+
+	source := &proto.SnapshotSource{ManifestPath: path}
+	restored := restoreRequest(&proto.CreateVMRequest{
+		VMID:                     "restored",
+		ExitAfterAllTasksDeleted: true,
+		Snapshot:                 source,
+	}, read)
+	assert.Equal(t, "restored", restored.VMID)
+	assert.True(t, restored.ExitAfterAllTasksDeleted)
+	assert.Equal(t, "console=ttyS0", restored.KernelArgs)
+	assert.Equal(t, int32(2), restored.ContainerCount)
+	assert.Zero(t, restored.TimeoutSeconds)
+	assert.Empty(t, restored.LogFifoPath)
+	assert.Empty(t, restored.MetricsFifoPath)
+	assert.Equal(t, "/foo/1", restored.DriveMounts[0].HostPath)
+	assert.Equal(t, source, restored.Snapshot)
+
+	_, err = readSnapshotManifest(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPrepareSnapshot(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	stubDir := t.TempDir()
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(stubDir),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	containerCount := 3
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, noopJailer, containerCount, containerCount, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	patchedPaths := make(map[string]string)
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				patchedPaths[params.DriveID] = params.Body.PathOnHost
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	drives, err := stubDriveHandler.prepareSnapshot(ctx, mockMachine)
+	require.NoError(t, err)
+	require.Len(t, drives, containerCount)
+
+	var entries []*proto.SnapshotStubDrive
+	for _, drive := range drives {
+		// stub drives must be patched to paths relative to the jail
+		assert.Equal(t, filepath.Base(drive.stubPath), patchedPaths[drive.driveID])
+		entries = append(entries, snapshotStubDrive(drive))
+	}
+
+	assert.NoError(t, verifySnapshotStubDrives(entries, stubDriveHandler.freeStubDrives()))
+	assert.Error(t, verifySnapshotStubDrives(entries[1:], stubDriveHandler.freeStubDrives()))
+
+	entries[0] = &proto.SnapshotStubDrive{DriveID: entries[0].DriveID, StubFileName: "other"}
+	assert.Error(t, verifySnapshotStubDrives(entries, stubDriveHandler.freeStubDrives()))
+
+	stubDriveHandler.usedDrives["container"] = &drives[0]
+	_, err = stubDriveHandler.prepareSnapshot(ctx, mockMachine)
+	assert.ErrorIs(t, err, ErrDrivesInUse)
+}
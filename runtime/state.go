@@ -0,0 +1,212 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/protobuf"
+	"github.com/containerd/containerd/runtime"
+	"github.com/containerd/typeurl/v2"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+)
+
+// addTaskState records that the task is running in the VM in the shim's state file.
+func (s *service) addTaskState(taskID string) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	s.taskIDs[taskID] = struct{}{}
+	s.writeState()
+}
+
+// removeTaskState records that the task is no longer running in the VM in the shim's state file.
+func (s *service) removeTaskState(taskID string) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	delete(s.taskIDs, taskID)
+	delete(s.taskExits, taskID)
+	s.writeState()
+}
+
+// recordTaskExits records the exit status of the init processes of the VM's tasks in the shim's
+// state file until the tasks are deleted, so Cleanup can report it if the shim dies before then.
+func (s *service) recordTaskExits() {
+	eventCh, errCh := s.eventExchange.Subscribe(s.shimCtx, fmt.Sprintf(`topic==%q`, runtime.TaskExitEventTopic))
+	for {
+		select {
+		case envelope := <-eventCh:
+			event, err := typeurl.UnmarshalAny(envelope.Event)
+			if err != nil {
+				s.log().WithError(err).Error("failed to unmarshal task exit event")
+				continue
+			}
+
+			exit, ok := event.(*events.TaskExit)
+			if !ok || exit.ID != exit.ContainerID {
+				continue
+			}
+
+			s.stateMu.Lock()
+			s.taskExits[exit.ContainerID] = vm.TaskExit{
+				ExitStatus: exit.ExitStatus,
+				ExitedAt:   protobuf.FromTimestamp(exit.ExitedAt),
+			}
+			s.writeState()
+			s.stateMu.Unlock()
+		case err := <-errCh:
+			if err != nil && err != context.Canceled {
+				s.log().WithError(err).Error("error while recording task exits")
+			}
+			return
+		}
+	}
+}
+
+// removeState removes the shim's state file once the shim has cleaned up after its VM.
+func (s *service) removeState() error {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	if s.shimDir == "" {
+		return nil
+	}
+
+	s.stateRemoved = true
+	err := os.Remove(s.shimDir.StateFilePath())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove shim state file: %w", err)
+	}
+
+	return nil
+}
+
+// saveState writes the shim's state file, which lets the firecracker-control plugin reattach to
+// the shim or clean up after it once containerd restarts.
+func (s *service) saveState() {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	s.writeState()
+}
+
+func (s *service) writeState() {
+	// shims started by containerd rather than the firecracker-control plugin have no shim dir
+	if s.shimDir == "" || s.stateRemoved {
+		return
+	}
+
+	state := &vm.ShimState{
+		Namespace: s.namespace,
+		VMID:      s.currentVMID(),
+		ShimPID:   os.Getpid(),
+		Jailer:    jailerState(s.jailer),
+	}
+
+	if startTime, err := fcShim.ProcessStartTime(s.shimCtx, int32(state.ShimPID)); err == nil {
+		state.ShimStartTime = startTime
+	} else {
+		s.log().WithError(err).Warn("failed to get start time of shim process")
+	}
+
+	if s.machineConfig != nil {
+		for _, path := range []string{s.machineConfig.LogPath, s.machineConfig.MetricsPath} {
+			if path != "" {
+				state.FIFOs = append(state.FIFOs, path)
+			}
+		}
+	}
+
+	if s.machine != nil {
+		if pid, err := s.machine.PID(); err == nil {
+			state.FirecrackerPID = pid
+		}
+
+		// The network namespace is created by the SDK unless one was configured.
+		state.CNI = cniState(s.machine.Cfg, s.machineConfig.NetNS == "")
+	}
+
+	for taskID := range s.taskIDs {
+		state.TaskIDs = append(state.TaskIDs, taskID)
+	}
+	sort.Strings(state.TaskIDs)
+
+	if len(s.taskExits) > 0 {
+		state.TaskExits = make(map[string]vm.TaskExit, len(s.taskExits))
+		for taskID, exit := range s.taskExits {
+			state.TaskExits[taskID] = exit
+		}
+	}
+
+	if err := s.shimDir.WriteState(state); err != nil {
+		s.log().WithError(err).Error("failed to write shim state file")
+	}
+}
+
+// cniState describes the CNI networks of the network interfaces of the VM with the provided
+// configuration.
+func cniState(cfg firecracker.Config, ownsNetNS bool) []vm.CNIState {
+	var networks []vm.CNIState
+	for _, iface := range cfg.NetworkInterfaces {
+		cniConf := iface.CNIConfiguration
+		if cniConf == nil {
+			continue
+		}
+
+		networks = append(networks, vm.CNIState{
+			NetworkName: cniConf.NetworkName,
+			IfName:      cniConf.IfName,
+			BinPath:     cniConf.BinPath,
+			ConfDir:     cniConf.ConfDir,
+			CacheDir:    cniConf.CacheDir,
+			Args:        cniConf.Args,
+			ContainerID: cfg.VMID,
+			NetNS:       cfg.NetNS,
+			OwnsNetNS:   ownsNetNS,
+		})
+	}
+
+	return networks
+}
+
+// jailerState describes the jail of the provided jailer, or returns nil if it doesn't jail
+// the VM.
+func jailerState(j jailer) *vm.JailerState {
+	switch j := j.(type) {
+	case *runcJailer:
+		return &vm.JailerState{
+			RuncBinaryPath: j.Config.RuncBinPath,
+			ContainerID:    j.vmID,
+			BundlePath:     j.OCIBundlePath(),
+		}
+	case *firecrackerJailer:
+		return &vm.JailerState{
+			Backend:     config.FirecrackerJailerBackend,
+			ContainerID: j.vmID,
+			BundlePath:  j.Config.ChrootBaseDir,
+			BindMounts:  j.mountedFiles(),
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/ttrpc"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/tracing"
+)
+
+func init() {
+	// containerd's shim runtime registers the task service on a ttrpc server which only
+	// accepts interceptors from plugins. This plugin traces the task requests of containerd
+	// as children of the spans propagated by containerd.
+	plugin.Register(&plugin.Registration{
+		Type: plugin.TTRPCPlugin,
+		ID:   "tracing",
+		InitFn: func(*plugin.InitContext) (interface{}, error) {
+			return tracingPlugin{}, nil
+		},
+	})
+}
+
+type tracingPlugin struct{}
+
+// RegisterTTRPC registers no service, the plugin only provides an interceptor.
+func (tracingPlugin) RegisterTTRPC(*ttrpc.Server) error {
+	return nil
+}
+
+// UnaryInterceptor returns the interceptor tracing the requests to the task service.
+func (tracingPlugin) UnaryInterceptor() ttrpc.UnaryServerInterceptor {
+	return tracing.UnaryServerInterceptor
+}
@@ -0,0 +1,267 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	gueststats "github.com/firecracker-microvm/firecracker-containerd/proto/service/gueststats/ttrpc"
+)
+
+const (
+	// cgroupRoot is where the cgroup hierarchies of the host are mounted.
+	cgroupRoot = "/sys/fs/cgroup"
+
+	// flushMetricsTimeout is how long GetVMStats waits for Firecracker to write its metrics.
+	flushMetricsTimeout = time.Second
+)
+
+// GetVMStats returns the resource usage of the VM as a whole, combining the metrics written by
+// Firecracker, the usage of the cgroup the VM is jailed in and the memory usage and load of the guest.
+func (s *service) GetVMStats(requestCtx context.Context, _ *proto.GetVMStatsRequest) (*proto.GetVMStatsResponse, error) {
+	defer logPanicAndDie(s.log())
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+
+	resp := &proto.GetVMStatsResponse{
+		VMID: s.currentVMID(),
+	}
+	if !s.callerMetricsFifo {
+		resp.VMM = s.flushedVMMStats(requestCtx)
+	}
+
+	if c, ok := s.jailer.(cgroupPather); ok && c.CgroupPath() != "" {
+		resp.Cgroup, err = readCgroupStats(cgroupRoot, c.CgroupPath())
+		if err != nil {
+			err = fmt.Errorf("failed to read stats of cgroup %q: %w", c.CgroupPath(), err)
+			s.log().WithError(err).Error()
+			return nil, err
+		}
+	}
+
+	guest, err := s.guestStatsClient.GetGuestStats(requestCtx, &gueststats.GetGuestStatsRequest{})
+	if err != nil {
+		err = fmt.Errorf("failed to get guest stats from the agent: %w", err)
+		s.log().WithError(err).Error()
+		return nil, err
+	}
+	resp.Guest = &proto.GuestKernelStats{
+		MemTotalBytes:     guest.MemTotalBytes,
+		MemFreeBytes:      guest.MemFreeBytes,
+		MemAvailableBytes: guest.MemAvailableBytes,
+		BuffersBytes:      guest.BuffersBytes,
+		CachedBytes:       guest.CachedBytes,
+		SwapTotalBytes:    guest.SwapTotalBytes,
+		SwapFreeBytes:     guest.SwapFreeBytes,
+		Load1:             guest.Load1,
+		Load5:             guest.Load5,
+		Load15:            guest.Load15,
+	}
+
+	return resp, nil
+}
+
+// flushedVMMStats returns the totals of the metrics written by Firecracker, once it wrote its
+// current metrics. Firecracker otherwise only writes them every minute, so the last ones written
+// are returned if it could not be made to.
+func (s *service) flushedVMMStats(ctx context.Context) *proto.FirecrackerVMMetrics {
+	flushed := vmMetrics.nextFlush()
+	_, err := s.firecrackerClient.CreateSyncAction(ctx, &models.InstanceActionInfo{
+		ActionType: firecracker.String(models.InstanceActionInfoActionTypeFlushMetrics),
+	})
+	if err != nil {
+		s.log().WithError(err).Warn("failed to flush firecracker metrics, using the ones last written")
+		return vmMetrics.vmStats()
+	}
+
+	timer := time.NewTimer(flushMetricsTimeout)
+	defer timer.Stop()
+	select {
+	case <-flushed:
+	case <-timer.C:
+		s.log().Warn("timed out waiting for firecracker to write its metrics, using the ones last written")
+	case <-ctx.Done():
+	}
+
+	return vmMetrics.vmStats()
+}
+
+// readCgroupStats reads the usage of the cgroup at cgroupPath from the cgroup hierarchies mounted
+// at root, which are either a unified cgroup v2 hierarchy or one cgroup v1 hierarchy per controller.
+func readCgroupStats(root, cgroupPath string) (*proto.CgroupStats, error) {
+	if isCgroupV2(root) {
+		return readCgroupV2Stats(filepath.Join(root, cgroupPath), cgroupPath)
+	}
+	return readCgroupV1Stats(root, cgroupPath)
+}
+
+func readCgroupV2Stats(dir, cgroupPath string) (*proto.CgroupStats, error) {
+	stats := &proto.CgroupStats{CgroupPath: cgroupPath}
+
+	cpu, err := readKeyedValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return nil, err
+	}
+	stats.CPUUsageNanoseconds = cpu["usage_usec"] * 1000
+
+	stats.MemoryUsageBytes, err = readUint(filepath.Join(dir, "memory.current"))
+	if err != nil {
+		return nil, err
+	}
+
+	// memory.peak is only available from Linux 5.19
+	stats.MemoryMaxUsageBytes, err = readUint(filepath.Join(dir, "memory.peak"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	// io.stat has a line per device formatted as "8:0 rbytes=1 wbytes=2 rios=3 wios=4 ..."
+	lines, err := readLines(filepath.Join(dir, "io.stat"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, line := range lines {
+		for _, field := range strings.Fields(line)[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid io.stat value %q: %w", field, err)
+			}
+			switch key {
+			case "rbytes":
+				stats.IOReadBytes += n
+			case "wbytes":
+				stats.IOWriteBytes += n
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+func readCgroupV1Stats(root, cgroupPath string) (*proto.CgroupStats, error) {
+	stats := &proto.CgroupStats{CgroupPath: cgroupPath}
+
+	var err error
+	stats.CPUUsageNanoseconds, err = readUint(filepath.Join(root, "cpuacct", cgroupPath, "cpuacct.usage"))
+	if err != nil {
+		return nil, err
+	}
+
+	memoryDir := filepath.Join(root, "memory", cgroupPath)
+	stats.MemoryUsageBytes, err = readUint(filepath.Join(memoryDir, "memory.usage_in_bytes"))
+	if err != nil {
+		return nil, err
+	}
+	stats.MemoryMaxUsageBytes, err = readUint(filepath.Join(memoryDir, "memory.max_usage_in_bytes"))
+	if err != nil {
+		return nil, err
+	}
+
+	// blkio.throttle.io_service_bytes has a line per device and operation formatted as
+	// "8:0 Read 4096", followed by a "Total 8192" line.
+	lines, err := readLines(filepath.Join(root, "blkio", cgroupPath, "blkio.throttle.io_service_bytes"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blkio value %q: %w", line, err)
+		}
+		switch fields[1] {
+		case "Read":
+			stats.IOReadBytes += n
+		case "Write":
+			stats.IOWriteBytes += n
+		}
+	}
+
+	return stats, nil
+}
+
+// readUint reads a file holding a single unsigned integer.
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %q: %w", path, err)
+	}
+	return n, nil
+}
+
+// readKeyedValues reads a file of "key value" lines, such as cpu.stat.
+func readKeyedValues(path string) (map[string]uint64, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]uint64, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s in %q: %w", fields[0], path, err)
+		}
+		values[fields[0]] = n
+	}
+	return values, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
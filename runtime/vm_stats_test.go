@@ -0,0 +1,157 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	gueststats "github.com/firecracker-microvm/firecracker-containerd/proto/service/gueststats/ttrpc"
+)
+
+type mockGuestStats struct {
+	resp *gueststats.GetGuestStatsResponse
+}
+
+func (m *mockGuestStats) GetGuestStats(_ context.Context, _ *gueststats.GetGuestStatsRequest) (*gueststats.GetGuestStatsResponse, error) {
+	return m.resp, nil
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestFirecrackerVMStats(t *testing.T) {
+	uut := newFirecrackerMetrics()
+	flush := map[string]interface{}{
+		"vcpu":         map[string]interface{}{"exit_io_in": 3.0, "exit_mmio_write": 1.0},
+		"block":        map[string]interface{}{"read_bytes": 4096.0, "write_count": 2.0},
+		"block_rootfs": map[string]interface{}{"read_bytes": 4096.0},
+		"net":          map[string]interface{}{"rx_bytes_count": 100.0, "tx_packets_count": 1.0},
+	}
+	uut.update(flush)
+	uut.update(flush)
+
+	assert.Equal(t, &proto.FirecrackerVMMetrics{
+		Flushes:           2,
+		VcpuExitIoIn:      6,
+		VcpuExitMmioWrite: 2,
+		BlockReadBytes:    8192,
+		BlockWriteCount:   4,
+		NetRxBytes:        200,
+		NetTxPackets:      2,
+	}, uut.vmStats())
+}
+
+func TestReadCgroupV2Stats(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"cgroup.controllers":     "cpu io memory pids\n",
+		"fc/vm-1/cpu.stat":       "usage_usec 1500\nuser_usec 1000\nsystem_usec 500\n",
+		"fc/vm-1/memory.current": "134217728\n",
+		"fc/vm-1/io.stat":        "8:0 rbytes=4096 wbytes=512 rios=1 wios=1 dbytes=0 dios=0\n8:16 rbytes=1024 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n",
+	})
+
+	stats, err := readCgroupStats(root, "/fc/vm-1")
+	require.NoError(t, err)
+	assert.Equal(t, &proto.CgroupStats{
+		CgroupPath:          "/fc/vm-1",
+		CPUUsageNanoseconds: 1500000,
+		MemoryUsageBytes:    134217728,
+		IOReadBytes:         5120,
+		IOWriteBytes:        512,
+	}, stats)
+}
+
+func TestReadCgroupV1Stats(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"cpuacct/fc/vm-1/cpuacct.usage":                 "2000000\n",
+		"memory/fc/vm-1/memory.usage_in_bytes":          "1048576\n",
+		"memory/fc/vm-1/memory.max_usage_in_bytes":      "2097152\n",
+		"blkio/fc/vm-1/blkio.throttle.io_service_bytes": "8:0 Read 4096\n8:0 Write 512\n8:0 Sync 4608\n8:0 Total 4608\nTotal 4608\n",
+	})
+
+	stats, err := readCgroupStats(root, "/fc/vm-1")
+	require.NoError(t, err)
+	assert.Equal(t, &proto.CgroupStats{
+		CgroupPath:          "/fc/vm-1",
+		CPUUsageNanoseconds: 2000000,
+		MemoryUsageBytes:    1048576,
+		MemoryMaxUsageBytes: 2097152,
+		IOReadBytes:         4096,
+		IOWriteBytes:        512,
+	}, stats)
+
+	_, err = readCgroupStats(root, "/fc/missing")
+	assert.Error(t, err)
+}
+
+func TestGetVMStats(t *testing.T) {
+	// Firecracker writes its current metrics when asked to flush them
+	var flushes int
+	client := firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+		CreateSyncActionFn: func(params *ops.CreateSyncActionParams) (*ops.CreateSyncActionNoContent, error) {
+			if *params.Info.ActionType == "FlushMetrics" {
+				flushes++
+				go vmMetrics.update(map[string]interface{}{"block": map[string]interface{}{"read_count": float64(1)}})
+			}
+			return nil, nil
+		},
+	}))
+
+	before := vmMetrics.vmStats()
+	s := &service{
+		vmID:              "vm-1",
+		logger:            logrus.NewEntry(logrus.New()),
+		vmReady:           make(chan struct{}),
+		firecrackerClient: client,
+		guestStatsClient: &mockGuestStats{resp: &gueststats.GetGuestStatsResponse{
+			MemTotalBytes: 1 << 30,
+			MemFreeBytes:  1 << 29,
+			Load1:         0.5,
+		}},
+	}
+	close(s.vmReady)
+
+	resp, err := s.GetVMStats(context.Background(), &proto.GetVMStatsRequest{VMID: "vm-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "vm-1", resp.VMID)
+	assert.Equal(t, 1, flushes)
+	assert.Equal(t, before.Flushes+1, resp.VMM.Flushes, "metrics should be read once flushed")
+	assert.Equal(t, before.BlockReadCount+1, resp.VMM.BlockReadCount)
+	assert.Nil(t, resp.Cgroup, "a VM which isn't jailed has no cgroup")
+	assert.Equal(t, &proto.GuestKernelStats{MemTotalBytes: 1 << 30, MemFreeBytes: 1 << 29, Load1: 0.5}, resp.Guest)
+
+	// the metrics FIFO given by the caller of CreateVM isn't read by the shim
+	s.callerMetricsFifo = true
+	resp, err = s.GetVMStats(context.Background(), &proto.GetVMStatsRequest{VMID: "vm-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, flushes)
+	assert.Nil(t, resp.VMM)
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/cache"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/metrics"
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/metrics/aggregate"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/metrics/discovery"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/proxy"
 	proxyaddress "github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/proxy/address"
@@ -58,9 +59,12 @@ func Run(config config.Config) error {
 	var (
 		monitor          *metrics.Monitor
 		serviceDiscovery *discovery.ServiceDiscovery
+		aggregator       *aggregate.Aggregator
 	)
 
-	if config.Snapshotter.Metrics.Enable {
+	metricsConfig := config.Snapshotter.Metrics
+	// Aggregated metrics are all served by a single server, they don't use ports from a range.
+	if metricsConfig.Enable && !metricsConfig.Aggregate {
 		var err error
 		monitor, err = initMetricsProxyMonitor(config.Snapshotter.Metrics.PortRange)
 		if err != nil {
@@ -76,9 +80,14 @@ func Run(config config.Config) error {
 		return fmt.Errorf("failed initializing cache: %w", err)
 	}
 
-	if config.Snapshotter.Metrics.Enable {
-		sdHost := config.Snapshotter.Metrics.Host
-		sdPort := config.Snapshotter.Metrics.ServiceDiscoveryPort
+	if metricsConfig.Enable && metricsConfig.Aggregate {
+		aggregator = aggregate.NewAggregator(metricsConfig.Host, metricsConfig.AggregatePort, cache)
+		group.Go(func() error {
+			return aggregator.Serve()
+		})
+	} else if metricsConfig.Enable {
+		sdHost := metricsConfig.Host
+		sdPort := metricsConfig.ServiceDiscoveryPort
 		serviceDiscovery = discovery.NewServiceDiscovery(sdHost, sdPort, cache)
 		group.Go(func() error {
 			return serviceDiscovery.Serve()
@@ -87,7 +96,7 @@ func Run(config config.Config) error {
 
 	snapshotter := demux.NewSnapshotter(cache)
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(metrics.UnaryServerInterceptor))
 	service := snapshotservice.FromSnapshotter(snapshotter)
 	snapshotsapi.RegisterSnapshotsServer(grpcServer, service)
 
@@ -115,7 +124,11 @@ func Run(config config.Config) error {
 			select {
 			case <-stop:
 				// cancelling context will cause shutdown to fail; shutdown before cancel
-				if config.Snapshotter.Metrics.Enable {
+				if aggregator != nil {
+					if err := aggregator.Shutdown(ctx); err != nil {
+						log.G(ctx).WithError(err).Error("failed to shutdown aggregated metrics server")
+					}
+				} else if metricsConfig.Enable {
 					if err := serviceDiscovery.Shutdown(ctx); err != nil {
 						log.G(ctx).WithError(err).Error("failed to shutdown service discovery server")
 					}
@@ -141,12 +154,32 @@ func Run(config config.Config) error {
 
 func initResolver(config config.Config) (proxyaddress.Resolver, error) {
 	resolverConfig := config.Snapshotter.Proxy.Address.Resolver
+
+	var resolver proxyaddress.Resolver
 	switch resolverConfig.Type {
 	case "http":
-		return proxyaddress.NewHTTPResolver(resolverConfig.Address), nil
+		resolver = proxyaddress.NewHTTPResolver(resolverConfig.Address)
+	case "file":
+		fileResolver, err := proxyaddress.NewFileResolver(resolverConfig.Address)
+		if err != nil {
+			return nil, err
+		}
+		resolver = fileResolver
+	case "fccontrol":
+		resolver = proxyaddress.NewFCControlResolver(resolverConfig.Address, resolverConfig.SnapshotterPort, resolverConfig.MetricsPort)
 	default:
 		return nil, fmt.Errorf("invalid resolver type: %s", resolverConfig.Type)
 	}
+
+	if resolverConfig.CacheTTL != "" {
+		ttl, err := time.ParseDuration(resolverConfig.CacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resolver cache TTL: %w", err)
+		}
+		resolver = proxyaddress.NewCachedResolver(resolver, ttl)
+	}
+
+	return resolver, nil
 }
 
 const base10 = 10
@@ -220,14 +253,40 @@ func initCache(config config.Config, monitor *metrics.Monitor) (*cache.RemoteSna
 
 	opts := make([]cache.SnapshotterCacheOption, 0)
 
-	if config.Snapshotter.Cache.EvictOnConnectionFailure {
-		cachePollFrequency, err := time.ParseDuration(config.Snapshotter.Cache.PollConnectionFrequency)
+	cacheConfig := config.Snapshotter.Cache
+	if cacheConfig.EvictOnHealthCheckFailure {
+		cachePollFrequency, err := time.ParseDuration(cacheConfig.PollConnectionFrequency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache evict poll connection frequency: %w", err)
+		}
+		healthCheckTimeout, err := time.ParseDuration(cacheConfig.HealthCheckTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache health check timeout: %w", err)
+		}
+		var idleTTL time.Duration
+		if cacheConfig.IdleTTL != "" {
+			idleTTL, err = time.ParseDuration(cacheConfig.IdleTTL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cache idle TTL: %w", err)
+			}
+		}
+		opts = append(opts, cache.EvictOnHealthCheckFailure(cachePollFrequency, healthCheckTimeout, idleTTL))
+	} else if cacheConfig.EvictOnConnectionFailure {
+		cachePollFrequency, err := time.ParseDuration(cacheConfig.PollConnectionFrequency)
 		if err != nil {
 			return nil, fmt.Errorf("invalid cache evict poll connection frequency: %w", err)
 		}
 		opts = append(opts, cache.EvictOnConnectionFailure(dialer, cachePollFrequency))
 	}
 
+	if cacheConfig.CircuitBreakerThreshold > 0 {
+		cooldown, err := time.ParseDuration(cacheConfig.CircuitBreakerCooldown)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache circuit breaker cooldown: %w", err)
+		}
+		opts = append(opts, cache.CircuitBreaker(cacheConfig.CircuitBreakerThreshold, cooldown))
+	}
+
 	return cache.NewRemoteSnapshotterCache(fetch, opts...), nil
 }
 
@@ -259,6 +318,10 @@ func initMetricsProxy(config config.Config, monitor *metrics.Monitor, host, port
 		return vsock.DialContext(ctx, host, uint32(metricsPort), vsock.WithLogger(log.G(ctx)))
 	}
 
+	if config.Snapshotter.Metrics.Aggregate {
+		return metrics.NewAggregatedProxy(labels, metricsDialer), nil
+	}
+
 	metricsHost := config.Snapshotter.Metrics.Host
 
 	return metrics.NewProxy(metricsHost, monitor, labels, metricsDialer)
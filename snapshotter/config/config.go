@@ -59,13 +59,32 @@ type address struct {
 }
 
 type resolver struct {
-	Type    string `toml:"type"`
+	// Type is one of "http", "file" or "fccontrol".
+	Type string `toml:"type"`
+	// Address is the URL of the HTTP resolver, the path to the file of the file resolver
+	// or the containerd TTRPC address of the fccontrol resolver.
 	Address string `toml:"address"`
+	// CacheTTL is how long resolved addresses are cached for. Addresses are not cached if empty.
+	CacheTTL string `toml:"cache_ttl"`
+	// SnapshotterPort and MetricsPort are the vsock ports returned by the fccontrol resolver.
+	SnapshotterPort string `toml:"snapshotter_port" default:"10000"`
+	MetricsPort     string `toml:"metrics_port" default:"10002"`
 }
 
 type cache struct {
 	EvictOnConnectionFailure bool   `toml:"evict_on_connection_failure" default:"true"`
 	PollConnectionFrequency  string `toml:"poll_connection_frequency" default:"60s"`
+	// EvictOnHealthCheckFailure supersedes EvictOnConnectionFailure, probing remote snapshotters
+	// with a snapshotter request every PollConnectionFrequency instead of only dialing them.
+	EvictOnHealthCheckFailure bool   `toml:"evict_on_health_check_failure" default:"false"`
+	HealthCheckTimeout        string `toml:"health_check_timeout" default:"5s"`
+	// IdleTTL is how long a remote snapshotter stays cached without being used when health checks
+	// are enabled. Idle remote snapshotters are never evicted if empty.
+	IdleTTL string `toml:"idle_ttl"`
+	// CircuitBreakerThreshold is the number of consecutive connection failures after which requests
+	// to a remote snapshotter fail fast for CircuitBreakerCooldown. Disabled if 0.
+	CircuitBreakerThreshold int    `toml:"circuit_breaker_threshold" default:"0"`
+	CircuitBreakerCooldown  string `toml:"circuit_breaker_cooldown" default:"30s"`
 }
 
 type debug struct {
@@ -77,6 +96,10 @@ type metrics struct {
 	Host                 string `toml:"host"`
 	PortRange            string `toml:"port_range"`
 	ServiceDiscoveryPort int    `toml:"service_discovery_port"`
+	// Aggregate serves the metrics of every remote snapshotter on AggregatePort instead of
+	// serving each on a port from PortRange advertised by service discovery.
+	Aggregate     bool `toml:"aggregate" default:"false"`
+	AggregatePort int  `toml:"aggregate_port"`
 }
 
 // Load parses application configuration from a specified file path.
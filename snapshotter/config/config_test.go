@@ -56,12 +56,25 @@ func errorOnParse() error {
 }
 
 func defaultConfig() error {
-	expected := Config{
+	return parseConfig([]byte(``), expectedDefaultConfig())
+}
+
+// expectedDefaultConfig returns the configuration loaded from an empty file.
+func expectedDefaultConfig() Config {
+	return Config{
 		Snapshotter: snapshotter{
 			Listener: listener{
 				Network: "unix",
 				Address: "/var/lib/demux-snapshotter/snapshotter.sock",
 			},
+			Proxy: proxy{
+				Address: address{
+					Resolver: resolver{
+						SnapshotterPort: "10000",
+						MetricsPort:     "10002",
+					},
+				},
+			},
 			Dialer: dialer{
 				Timeout:       "5s",
 				RetryInterval: "100ms",
@@ -69,6 +82,8 @@ func defaultConfig() error {
 			Cache: cache{
 				EvictOnConnectionFailure: true,
 				PollConnectionFrequency:  "60s",
+				HealthCheckTimeout:       "5s",
+				CircuitBreakerCooldown:   "30s",
 			},
 			Metrics: metrics{
 				Enable: false,
@@ -78,7 +93,6 @@ func defaultConfig() error {
 			LogLevel: "info",
 		},
 	}
-	return parseConfig([]byte(``), expected)
 }
 
 func parseExampleConfig() error {
@@ -96,11 +110,18 @@ func parseExampleConfig() error {
       [snapshotter.cache]
         evict_on_connection_failure = false
         poll_connection_frequency = "120s"
+        evict_on_health_check_failure = true
+        health_check_timeout = "2s"
+        idle_ttl = "1h"
+        circuit_breaker_threshold = 3
+        circuit_breaker_cooldown = "10s"
       [snapshotter.metrics]
         enable = true
         port_range = "9000-9999"
         host = "0.0.0.0"
         service_discovery_port = 8080
+        aggregate = true
+        aggregate_port = 9100
     [debug]
       logLevel = "debug"
     `)
@@ -113,8 +134,10 @@ func parseExampleConfig() error {
 			Proxy: proxy{
 				Address: address{
 					Resolver: resolver{
-						Type:    "http",
-						Address: "localhost:10001",
+						Type:            "http",
+						Address:         "localhost:10001",
+						SnapshotterPort: "10000",
+						MetricsPort:     "10002",
 					},
 				},
 			},
@@ -123,14 +146,21 @@ func parseExampleConfig() error {
 				RetryInterval: "100ms",
 			},
 			Cache: cache{
-				EvictOnConnectionFailure: false,
-				PollConnectionFrequency:  "120s",
+				EvictOnConnectionFailure:  false,
+				PollConnectionFrequency:   "120s",
+				EvictOnHealthCheckFailure: true,
+				HealthCheckTimeout:        "2s",
+				IdleTTL:                   "1h",
+				CircuitBreakerThreshold:   3,
+				CircuitBreakerCooldown:    "10s",
 			},
 			Metrics: metrics{
 				Enable:               true,
 				PortRange:            "9000-9999",
 				Host:                 "0.0.0.0",
 				ServiceDiscoveryPort: 8080,
+				Aggregate:            true,
+				AggregatePort:        9100,
 			},
 		},
 		Debug: debug{
@@ -140,6 +170,44 @@ func parseExampleConfig() error {
 	return parseConfig(fileContents, expected)
 }
 
+func parseFileResolverConfig() error {
+	fileContents := []byte(`
+    [snapshotter.proxy.address.resolver]
+      type = "file"
+      address = "/etc/demux-snapshotter/addresses.toml"
+      cache_ttl = "1m"
+    `)
+	expected := expectedDefaultConfig()
+	expected.Snapshotter.Proxy.Address.Resolver = resolver{
+		Type:            "file",
+		Address:         "/etc/demux-snapshotter/addresses.toml",
+		CacheTTL:        "1m",
+		SnapshotterPort: "10000",
+		MetricsPort:     "10002",
+	}
+	return parseConfig(fileContents, expected)
+}
+
+func parseFCControlResolverConfig() error {
+	fileContents := []byte(`
+    [snapshotter.proxy.address.resolver]
+      type = "fccontrol"
+      address = "/run/firecracker-containerd/containerd.sock.ttrpc"
+      cache_ttl = "30s"
+      snapshotter_port = "10010"
+      metrics_port = "10012"
+    `)
+	expected := expectedDefaultConfig()
+	expected.Snapshotter.Proxy.Address.Resolver = resolver{
+		Type:            "fccontrol",
+		Address:         "/run/firecracker-containerd/containerd.sock.ttrpc",
+		CacheTTL:        "30s",
+		SnapshotterPort: "10010",
+		MetricsPort:     "10012",
+	}
+	return parseConfig(fileContents, expected)
+}
+
 func parseConfig(input []byte, expected Config) error {
 	reader := fakeReader{
 		buffer:    input,
@@ -167,6 +235,8 @@ func TestLoad(t *testing.T) {
 		{"ParseError", errorOnParse},
 		{"DefaultConfig", defaultConfig},
 		{"ParseFullConfig", parseExampleConfig},
+		{"ParseFileResolverConfig", parseFileResolverConfig},
+		{"ParseFCControlResolverConfig", parseFCControlResolverConfig},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,219 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/snapshots"
+)
+
+// circuitBreaker fails requests to a remote snapshotter fast after a number of
+// consecutive failures, until a cooldown period passes.
+//
+// Once the cooldown passed, the circuit is half-open: a single request is let
+// through to probe the remote snapshotter while the others keep failing fast.
+// The probe failing opens the circuit again while it succeeding closes it.
+type circuitBreaker struct {
+	key       string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// probing is set while the probe let through by a half-open circuit is in flight.
+	probing bool
+}
+
+func newCircuitBreaker(key string, threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{key: key, threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns an error wrapping errdefs.ErrUnavailable if the circuit is open, or if it is
+// half-open and its probe is already in flight. Otherwise probe is true if the request is
+// the probe of a half-open circuit, in which case its result must be recorded, or the probe
+// released if no request was made after all.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return false, nil
+	}
+
+	if remaining := b.openUntil.Sub(b.now()); remaining > 0 {
+		return false, fmt.Errorf("remote snapshotter for namespace %q failed %d consecutive times, retrying in %s: %w",
+			b.key, b.failures, remaining.Round(time.Millisecond), errdefs.ErrUnavailable)
+	}
+
+	if b.probing {
+		return false, fmt.Errorf("remote snapshotter for namespace %q failed %d consecutive times, probing it: %w",
+			b.key, b.failures, errdefs.ErrUnavailable)
+	}
+
+	b.probing = true
+	return true, nil
+}
+
+// release lets another request probe the half-open circuit, when the probe let through by allow
+// made no request to the remote snapshotter.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// record updates the state of the circuit with the result of a request.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		circuitBreakerOpened.Inc()
+	}
+}
+
+// isConnectionFailure returns whether err means the remote snapshotter could not be reached,
+// as opposed to the remote snapshotter failing the request.
+func isConnectionFailure(err error) bool {
+	return errdefs.IsUnavailable(err) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errdefs.IsDeadlineExceeded(err)
+}
+
+// circuitBreakingSnapshotter records the connection failures of the requests made to a
+// remote snapshotter in a circuit breaker, and fails requests fast while the circuit is open.
+type circuitBreakingSnapshotter struct {
+	snapshots.Snapshotter
+	breaker *circuitBreaker
+}
+
+func (s *circuitBreakingSnapshotter) call(fn func() error) error {
+	if _, err := s.breaker.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	if isConnectionFailure(err) {
+		s.breaker.record(err)
+	} else {
+		// the remote snapshotter was reached, even if it failed the request
+		s.breaker.record(nil)
+	}
+	return err
+}
+
+// Stat records the result of the remote snapshotter stat request.
+func (s *circuitBreakingSnapshotter) Stat(ctx context.Context, key string) (info snapshots.Info, err error) {
+	err = s.call(func() error {
+		info, err = s.Snapshotter.Stat(ctx, key)
+		return err
+	})
+	return info, err
+}
+
+// Update records the result of the remote snapshotter update request.
+func (s *circuitBreakingSnapshotter) Update(ctx context.Context, info snapshots.Info, fieldpaths ...string) (updated snapshots.Info, err error) {
+	err = s.call(func() error {
+		updated, err = s.Snapshotter.Update(ctx, info, fieldpaths...)
+		return err
+	})
+	return updated, err
+}
+
+// Usage records the result of the remote snapshotter usage request.
+func (s *circuitBreakingSnapshotter) Usage(ctx context.Context, key string) (usage snapshots.Usage, err error) {
+	err = s.call(func() error {
+		usage, err = s.Snapshotter.Usage(ctx, key)
+		return err
+	})
+	return usage, err
+}
+
+// Mounts records the result of the remote snapshotter mounts request.
+func (s *circuitBreakingSnapshotter) Mounts(ctx context.Context, key string) (mounts []mount.Mount, err error) {
+	err = s.call(func() error {
+		mounts, err = s.Snapshotter.Mounts(ctx, key)
+		return err
+	})
+	return mounts, err
+}
+
+// Prepare records the result of the remote snapshotter prepare request.
+func (s *circuitBreakingSnapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshots.Opt) (mounts []mount.Mount, err error) {
+	err = s.call(func() error {
+		mounts, err = s.Snapshotter.Prepare(ctx, key, parent, opts...)
+		return err
+	})
+	return mounts, err
+}
+
+// View records the result of the remote snapshotter view request.
+func (s *circuitBreakingSnapshotter) View(ctx context.Context, key, parent string, opts ...snapshots.Opt) (mounts []mount.Mount, err error) {
+	err = s.call(func() error {
+		mounts, err = s.Snapshotter.View(ctx, key, parent, opts...)
+		return err
+	})
+	return mounts, err
+}
+
+// Commit records the result of the remote snapshotter commit request.
+func (s *circuitBreakingSnapshotter) Commit(ctx context.Context, name, key string, opts ...snapshots.Opt) error {
+	return s.call(func() error {
+		return s.Snapshotter.Commit(ctx, name, key, opts...)
+	})
+}
+
+// Remove records the result of the remote snapshotter remove request.
+func (s *circuitBreakingSnapshotter) Remove(ctx context.Context, key string) error {
+	return s.call(func() error {
+		return s.Snapshotter.Remove(ctx, key)
+	})
+}
+
+// Walk records the result of the remote snapshotter walk request.
+func (s *circuitBreakingSnapshotter) Walk(ctx context.Context, fn snapshots.WalkFunc, filters ...string) error {
+	return s.call(func() error {
+		return s.Snapshotter.Walk(ctx, fn, filters...)
+	})
+}
+
+// Cleanup records the result of the remote snapshotter cleanup request.
+func (s *circuitBreakingSnapshotter) Cleanup(ctx context.Context) error {
+	return s.call(func() error {
+		cleaner, ok := s.Snapshotter.(snapshots.Cleaner)
+		if !ok {
+			return fmt.Errorf("remote snapshotter does not support cleanup: %w", errdefs.ErrNotImplemented)
+		}
+		return cleaner.Cleanup(ctx)
+	})
+}
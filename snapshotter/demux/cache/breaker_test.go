@@ -0,0 +1,177 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/proxy"
+)
+
+// unreachableSnapshotter mocks a remote snapshotter whose requests fail with err.
+type unreachableSnapshotter struct {
+	internal.SuccessfulSnapshotter
+	calls int
+	err   error
+}
+
+func (s *unreachableSnapshotter) Stat(_ context.Context, _ string) (snapshots.Info, error) {
+	s.calls++
+	return snapshots.Info{}, s.err
+}
+
+func (s *unreachableSnapshotter) Prepare(_ context.Context, _, _ string, _ ...snapshots.Opt) ([]mount.Mount, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	uut := newCircuitBreaker("test", 2, time.Minute)
+	uut.now = func() time.Time { return now }
+
+	unavailable := fmt.Errorf("mock dial error: %w", errdefs.ErrUnavailable)
+
+	probe, err := uut.allow()
+	require.NoError(t, err)
+	assert.False(t, probe, "requests through a closed circuit are not probes")
+	uut.record(unavailable)
+	_, err = uut.allow()
+	require.NoError(t, err, "circuit should open after the threshold only")
+	uut.record(unavailable)
+
+	_, err = uut.allow()
+	assert.True(t, errdefs.IsUnavailable(err), "open circuit should fail with ErrUnavailable, got %v", err)
+
+	now = now.Add(time.Minute)
+	probe, err = uut.allow()
+	require.NoError(t, err, "circuit should let a probe through after the cooldown")
+	assert.True(t, probe)
+	uut.record(unavailable)
+	_, err = uut.allow()
+	assert.Error(t, err, "a failed probe should open the circuit again")
+
+	now = now.Add(time.Minute)
+	probe, err = uut.allow()
+	require.NoError(t, err)
+	assert.True(t, probe)
+	uut.record(nil)
+	uut.record(unavailable)
+	_, err = uut.allow()
+	assert.NoError(t, err, "a successful probe should close the circuit")
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	now := time.Now()
+	uut := newCircuitBreaker("test", 1, time.Minute)
+	uut.now = func() time.Time { return now }
+
+	uut.record(fmt.Errorf("mock dial error: %w", errdefs.ErrUnavailable))
+	now = now.Add(time.Minute)
+
+	probe, err := uut.allow()
+	require.NoError(t, err)
+	require.True(t, probe)
+
+	for i := 0; i < 3; i++ {
+		_, err = uut.allow()
+		assert.True(t, errdefs.IsUnavailable(err), "requests should fail fast while the probe is in flight, got %v", err)
+	}
+
+	// a released probe lets another request probe the circuit
+	uut.release()
+	probe, err = uut.allow()
+	require.NoError(t, err)
+	assert.True(t, probe)
+	_, err = uut.allow()
+	assert.Error(t, err)
+
+	uut.record(nil)
+	for i := 0; i < 3; i++ {
+		probe, err = uut.allow()
+		assert.NoError(t, err, "requests should be let through once the probe succeeded")
+		assert.False(t, probe)
+	}
+}
+
+func TestCircuitBreakingSnapshotter(t *testing.T) {
+	remote := &unreachableSnapshotter{err: fmt.Errorf("mock dial error: %w", errdefs.ErrUnavailable)}
+	fetch := func(_ context.Context, _ string) (*proxy.RemoteSnapshotter, error) {
+		return &proxy.RemoteSnapshotter{Snapshotter: remote}, nil
+	}
+	cache := NewRemoteSnapshotterCache(fetch, CircuitBreaker(2, time.Minute))
+	defer cache.Close()
+
+	snapshotter, err := cache.Get(context.Background(), "test")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = snapshotter.Prepare(context.Background(), "key", "")
+		require.Error(t, err)
+	}
+	assert.Equal(t, 2, remote.calls)
+
+	_, err = snapshotter.Prepare(context.Background(), "key", "")
+	assert.True(t, errdefs.IsUnavailable(err), "expected ErrUnavailable, got %v", err)
+	assert.Equal(t, 2, remote.calls, "requests should fail fast while the circuit is open")
+
+	_, err = cache.Get(context.Background(), "test")
+	assert.True(t, errdefs.IsUnavailable(err), "expected ErrUnavailable, got %v", err)
+}
+
+func TestCircuitBreakerIgnoresRequestFailures(t *testing.T) {
+	remote := &unreachableSnapshotter{err: fmt.Errorf("mock missing snapshot: %w", errdefs.ErrNotFound)}
+	fetch := func(_ context.Context, _ string) (*proxy.RemoteSnapshotter, error) {
+		return &proxy.RemoteSnapshotter{Snapshotter: remote}, nil
+	}
+	cache := NewRemoteSnapshotterCache(fetch, CircuitBreaker(1, time.Minute))
+	defer cache.Close()
+
+	snapshotter, err := cache.Get(context.Background(), "test")
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = snapshotter.Stat(context.Background(), "key")
+		assert.True(t, errdefs.IsNotFound(err), "expected ErrNotFound, got %v", err)
+	}
+	assert.Equal(t, 3, remote.calls)
+}
+
+func TestCircuitBreakerOnFetchFailure(t *testing.T) {
+	calls := 0
+	fetch := func(_ context.Context, _ string) (*proxy.RemoteSnapshotter, error) {
+		calls++
+		return nil, fmt.Errorf("mock resolver error")
+	}
+	cache := NewRemoteSnapshotterCache(fetch, CircuitBreaker(1, time.Minute))
+	defer cache.Close()
+
+	_, err := cache.Get(context.Background(), "test")
+	require.Error(t, err)
+	assert.False(t, errdefs.IsUnavailable(err))
+
+	_, err = cache.Get(context.Background(), "test")
+	assert.True(t, errdefs.IsUnavailable(err), "expected ErrUnavailable, got %v", err)
+	assert.Equal(t, 1, calls, "snapshotter should not be fetched while the circuit is open")
+}
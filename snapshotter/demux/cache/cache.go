@@ -19,8 +19,11 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/proxy"
 	"github.com/hashicorp/go-multierror"
@@ -34,6 +37,14 @@ type SnapshotterProvider = func(context.Context, string) (*proxy.RemoteSnapshott
 type RemoteSnapshotterCache struct {
 	mutex        *sync.RWMutex
 	snapshotters map[string]*proxy.RemoteSnapshotter
+	usage        map[string]*entryUsage
+	generation   uint64
+
+	// breakers are kept across evictions so a remote snapshotter which keeps failing
+	// isn't fetched again until its circuit closes.
+	breakers         map[string]*circuitBreaker
+	breakerThreshold int
+	breakerCooldown  time.Duration
 
 	fetch SnapshotterProvider
 
@@ -52,6 +63,8 @@ func NewRemoteSnapshotterCache(fetch SnapshotterProvider, opts ...SnapshotterCac
 	c := &RemoteSnapshotterCache{
 		mutex:        &sync.RWMutex{},
 		snapshotters: make(map[string]*proxy.RemoteSnapshotter),
+		usage:        make(map[string]*entryUsage),
+		breakers:     make(map[string]*circuitBreaker),
 		fetch:        fetch,
 		reaper:       &sync.Once{},
 		stop:         make(chan struct{}),
@@ -73,6 +86,82 @@ func EvictOnConnectionFailure(dialer proxy.Dialer, frequency time.Duration) func
 	}
 }
 
+// EvictOnHealthCheckFailure is a caching option for evicting entries from the cache after a failed
+// health probe, issued as a snapshotter RPC on the specified frequency, or after being idle for idleTTL.
+// Entries are never evicted for being idle if idleTTL is 0.
+func EvictOnHealthCheckFailure(frequency, timeout, idleTTL time.Duration) SnapshotterCacheOption {
+	return func(c *RemoteSnapshotterCache) {
+		c.evict = make(chan string)
+		c.lease = NewHealthCheckEvictionPolicy(c.evict, c.probe, c.entryUsage, frequency, timeout, idleTTL, c.stop)
+		c.startBackgroundReaper()
+	}
+}
+
+// CircuitBreaker is a caching option for failing requests to a remote snapshotter fast with
+// errdefs.ErrUnavailable, for cooldown, after it could not be reached threshold consecutive times.
+func CircuitBreaker(threshold int, cooldown time.Duration) SnapshotterCacheOption {
+	return func(c *RemoteSnapshotterCache) {
+		c.breakerThreshold = threshold
+		c.breakerCooldown = cooldown
+	}
+}
+
+type entryUsage struct {
+	generation uint64
+	lastUsed   atomic.Int64
+}
+
+func (u *entryUsage) touch() {
+	u.lastUsed.Store(time.Now().UnixNano())
+}
+
+// entryUsage returns the usage of the entry cached for a given key.
+func (c *RemoteSnapshotterCache) entryUsage(key string) (EntryUsage, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	u, ok := c.usage[key]
+	if !ok {
+		return EntryUsage{}, false
+	}
+	return EntryUsage{Generation: u.generation, LastUsed: time.Unix(0, u.lastUsed.Load())}, true
+}
+
+// probe checks the cached snapshotter for a given key serves requests by stat'ing a snapshot
+// which doesn't exist.
+func (c *RemoteSnapshotterCache) probe(ctx context.Context, key string) error {
+	c.mutex.RLock()
+	snapshotter, ok := c.snapshotters[key]
+	c.mutex.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("snapshotter %s not found in cache", key)
+	}
+
+	_, err := snapshotter.Stat(namespaces.WithNamespace(ctx, key), healthProbeKey)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("health probe of snapshotter %s failed: %w", key, err)
+	}
+	return nil
+}
+
+// breaker returns the circuit breaker for a given key, or nil if circuit breaking is disabled.
+func (c *RemoteSnapshotterCache) breaker(key string) *circuitBreaker {
+	if c.breakerThreshold <= 0 {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	b, ok := c.breakers[key]
+	if !ok {
+		b = newCircuitBreaker(key, c.breakerThreshold, c.breakerCooldown)
+		c.breakers[key] = b
+	}
+	return b
+}
+
 func (c *RemoteSnapshotterCache) startBackgroundReaper() {
 	reap := func() {
 		for {
@@ -104,9 +193,29 @@ func (c *RemoteSnapshotterCache) List() []string {
 }
 
 // Get fetches and caches the snapshotter for a given key.
+//
+// If circuit breaking is enabled, Get fails fast with errdefs.ErrUnavailable while the
+// circuit of the snapshotter is open.
 func (c *RemoteSnapshotterCache) Get(ctx context.Context, key string) (*proxy.RemoteSnapshotter, error) {
+	breaker := c.breaker(key)
+	var probe bool
+	if breaker != nil {
+		var err error
+		if probe, err = breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+	// Only fetching the snapshotter makes a request, so the probe of a half-open circuit is
+	// otherwise left to the first request to the snapshotter.
+	if probe {
+		defer breaker.release()
+	}
+
 	c.mutex.RLock()
 	snapshotter, ok := c.snapshotters[key]
+	if ok {
+		c.usage[key].touch()
+	}
 	c.mutex.RUnlock()
 
 	if !ok {
@@ -117,11 +226,22 @@ func (c *RemoteSnapshotterCache) Get(ctx context.Context, key string) (*proxy.Re
 		if !ok {
 			newSnapshotter, err := c.fetch(ctx, key)
 			if err != nil {
+				if breaker != nil {
+					breaker.record(err)
+				}
 				return nil, err
 			}
+			if breaker != nil {
+				newSnapshotter.Snapshotter = &circuitBreakingSnapshotter{Snapshotter: newSnapshotter.Snapshotter, breaker: breaker}
+			}
 
 			c.mutex.Lock()
+			c.generation++
+			usage := &entryUsage{generation: c.generation}
+			usage.touch()
 			c.snapshotters[key] = newSnapshotter
+			c.usage[key] = usage
+			cachedSnapshotters.Set(float64(len(c.snapshotters)))
 			c.mutex.Unlock()
 
 			if c.lease != nil {
@@ -133,6 +253,16 @@ func (c *RemoteSnapshotterCache) Get(ctx context.Context, key string) (*proxy.Re
 	return snapshotter, nil
 }
 
+// Peek returns the cached snapshotter for a given key, without fetching it if it isn't cached
+// nor counting it as used.
+func (c *RemoteSnapshotterCache) Peek(key string) (*proxy.RemoteSnapshotter, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	snapshotter, ok := c.snapshotters[key]
+	return snapshotter, ok
+}
+
 // WalkAll applies the provided function to all cached snapshotters.
 func (c *RemoteSnapshotterCache) WalkAll(ctx context.Context, fn snapshots.WalkFunc, filters ...string) error {
 	c.mutex.RLock()
@@ -163,6 +293,8 @@ func (c *RemoteSnapshotterCache) Evict(key string) error {
 
 	err := s.Close()
 	delete(c.snapshotters, key)
+	delete(c.usage, key)
+	cachedSnapshotters.Set(float64(len(c.snapshotters)))
 	return err
 }
 
@@ -183,6 +315,8 @@ func (c *RemoteSnapshotterCache) Close() error {
 			allErr = multierror.Append(allErr, err)
 		}
 		delete(c.snapshotters, k)
+		delete(c.usage, k)
 	}
+	cachedSnapshotters.Set(0)
 	return allErr
 }
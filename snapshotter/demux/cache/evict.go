@@ -62,6 +62,7 @@ func (p EvictOnConnectionFailurePolicy) Enforce(key string) {
 				ctx, cancel := context.WithTimeout(context.Background(), p.dialer.Timeout)
 				conn, err := p.dialer.Dial(ctx, key)
 				if err != nil {
+					cacheEvictions.WithValues(evictReasonConnectionFailure).Inc()
 					p.evict <- key
 					cancel()
 					return
@@ -74,3 +75,98 @@ func (p EvictOnConnectionFailurePolicy) Enforce(key string) {
 		}
 	}()
 }
+
+// healthProbeKey is the snapshot key stat'ed to probe remote snapshotters. It is not
+// expected to exist, a not found error proves the remote snapshotter is serving requests.
+const healthProbeKey = "demux-snapshotter-health-probe"
+
+// EntryUsage describes the usage of a cached entry.
+type EntryUsage struct {
+	// Generation identifies the cached entry among the entries cached under the same key over time.
+	Generation uint64
+
+	// LastUsed is the last time the entry was fetched from cache.
+	LastUsed time.Time
+}
+
+// HealthCheckEvictionPolicy defines an eviction policy where entries are evicted from cache
+// after failing a health probe issued as a snapshotter RPC, or after not being used for an idle TTL.
+type HealthCheckEvictionPolicy struct {
+	evictionPolicy
+
+	probe func(context.Context, string) error
+	usage func(string) (EntryUsage, bool)
+
+	frequency time.Duration
+	timeout   time.Duration
+	idleTTL   time.Duration
+}
+
+// NewHealthCheckEvictionPolicy creates a new policy which probes remote snapshotters on a specified
+// frequency duration, giving up on a probe after timeout. Entries unused for idleTTL are evicted too,
+// unless idleTTL is 0.
+func NewHealthCheckEvictionPolicy(
+	evictChan chan string,
+	probe func(context.Context, string) error,
+	usage func(string) (EntryUsage, bool),
+	frequency, timeout, idleTTL time.Duration,
+	stopCondition chan struct{},
+) EvictionPolicy {
+	return &HealthCheckEvictionPolicy{
+		evictionPolicy: evictionPolicy{evict: evictChan, stop: stopCondition},
+		probe:          probe,
+		usage:          usage,
+		frequency:      frequency,
+		timeout:        timeout,
+		idleTTL:        idleTTL,
+	}
+}
+
+// Enforce launches a go routine which periodically probes the cached entry.
+//
+// The entry will be evicted from cache when the probe fails or when it has been idle for too long.
+func (p HealthCheckEvictionPolicy) Enforce(key string) {
+	go func() {
+		ticker := time.NewTicker(p.frequency)
+		defer ticker.Stop()
+
+		enforced, ok := p.usage(key)
+		if !ok {
+			return
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				usage, ok := p.usage(key)
+				if !ok || usage.Generation != enforced.Generation {
+					// the entry was evicted by other means, another go routine enforces the policy
+					// on the entry which replaced it, if any
+					return
+				}
+				if p.idleTTL > 0 && time.Since(usage.LastUsed) >= p.idleTTL {
+					p.evictKey(key, evictReasonIdle)
+					return
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+				err := p.probe(ctx, key)
+				cancel()
+				if err != nil {
+					p.evictKey(key, evictReasonHealthCheckFailure)
+					return
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (p evictionPolicy) evictKey(key, reason string) {
+	cacheEvictions.WithValues(reason).Inc()
+	select {
+	case p.evict <- key:
+	case <-p.stop:
+	}
+}
@@ -16,10 +16,12 @@ package cache
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"testing"
 	"time"
 
+	"github.com/containerd/containerd/errdefs"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/proxy"
 	"github.com/stretchr/testify/require"
@@ -163,3 +165,70 @@ func TestLogErrorOnEvictionFailure(t *testing.T) {
 		}
 	}
 }
+
+func waitForLength(t *testing.T, cache *RemoteSnapshotterCache, expected int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if cache.length() == expected {
+				return
+			}
+		case <-ctx.Done():
+			require.Equal(t, expected, cache.length(), "unexpected number of cache entries")
+			return
+		}
+	}
+}
+
+func TestCacheEvictionOnHealthCheckFailure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	remote := &unreachableSnapshotter{err: fmt.Errorf("mock dial error: %w", errdefs.ErrUnavailable)}
+	fetch := func(_ context.Context, _ string) (*proxy.RemoteSnapshotter, error) {
+		return &proxy.RemoteSnapshotter{Snapshotter: remote}, nil
+	}
+	cache := NewRemoteSnapshotterCache(fetch, EvictOnHealthCheckFailure(time.Millisecond, time.Second, 0))
+	defer cache.Close()
+
+	_, err := cache.Get(context.Background(), "test")
+	require.NoError(t, err, "Snapshotter not added to cache correctly")
+
+	waitForLength(t, cache, 0)
+}
+
+func TestCacheNotEvictedIfHealthCheckSucceeds(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// the health probe stats a snapshot which doesn't exist
+	remote := &unreachableSnapshotter{err: fmt.Errorf("mock missing snapshot: %w", errdefs.ErrNotFound)}
+	fetch := func(_ context.Context, _ string) (*proxy.RemoteSnapshotter, error) {
+		return &proxy.RemoteSnapshotter{Snapshotter: remote}, nil
+	}
+	cache := NewRemoteSnapshotterCache(fetch, EvictOnHealthCheckFailure(time.Millisecond, time.Second, 0))
+
+	_, err := cache.Get(context.Background(), "test")
+	require.NoError(t, err, "Snapshotter not added to cache correctly")
+
+	time.Sleep(25 * time.Millisecond)
+	require.Equal(t, 1, cache.length(), "Cache entry was incorrectly evicted")
+
+	cache.Close()
+}
+
+func TestCacheEvictionAfterIdleTTL(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cache := NewRemoteSnapshotterCache(getSnapshotter, EvictOnHealthCheckFailure(time.Millisecond, time.Second, 10*time.Millisecond))
+	defer cache.Close()
+
+	_, err := cache.Get(context.Background(), "test")
+	require.NoError(t, err, "Snapshotter not added to cache correctly")
+
+	waitForLength(t, cache, 0)
+}
@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	metrics "github.com/docker/go-metrics"
+)
+
+const (
+	evictReasonConnectionFailure  = "connection_failure"
+	evictReasonHealthCheckFailure = "health_check_failure"
+	evictReasonIdle               = "idle"
+)
+
+var (
+	cacheMetrics         = metrics.NewNamespace("demux_snapshotter", "cache", nil)
+	cachedSnapshotters   = cacheMetrics.NewGauge("snapshotters", "Remote snapshotters in the cache", metrics.Total)
+	cacheEvictions       = cacheMetrics.NewLabeledCounter("evictions", "Remote snapshotters evicted from the cache by an eviction policy", "reason")
+	circuitBreakerOpened = cacheMetrics.NewCounter("circuit_breaker_opened", "Times the circuit of a remote snapshotter was opened")
+)
+
+func init() {
+	metrics.Register(cacheMetrics)
+}
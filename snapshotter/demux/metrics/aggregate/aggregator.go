@@ -0,0 +1,267 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package aggregate serves the metrics of every remote snapshotter in the cache,
+// along with the demux snapshotter's own metrics, on a single HTTP server.
+package aggregate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"golang.org/x/sync/errgroup"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/cache"
+)
+
+const (
+	// NamespaceLabel is the label added to the metrics of a remote snapshotter with its namespace,
+	// unless the labels of the remote snapshotter already include it.
+	NamespaceLabel = "namespace"
+
+	// maxConcurrentScrapes is the number of remote snapshotters whose metrics are fetched at once.
+	maxConcurrentScrapes = 16
+)
+
+// Aggregator serves the metrics of the remote snapshotters in the cache on a single HTTP server:
+//
+//   - /metrics returns the metrics of the demux snapshotter and of all the remote snapshotters,
+//     labeled with the labels of the remote snapshotter they come from.
+//   - /metrics/{namespace} returns the metrics of the remote snapshotter for a namespace, as is.
+type Aggregator struct {
+	// cache is the shared host-level cache of snapshotters also used by the demux snapshotter.
+	cache *cache.RemoteSnapshotterCache
+	// gatherer gathers the metrics of the demux snapshotter itself.
+	gatherer prometheus.Gatherer
+	// server is the HTTP server serving the aggregated metrics.
+	server *http.Server
+}
+
+// NewAggregator returns an Aggregator with configured HTTP server and provided cache.
+func NewAggregator(host string, port int, c *cache.RemoteSnapshotterCache) *Aggregator {
+	return &Aggregator{
+		cache:    c,
+		gatherer: prometheus.DefaultGatherer,
+		server: &http.Server{
+			Addr:              host + ":" + strconv.Itoa(port),
+			ReadHeaderTimeout: 2 * time.Second,
+		},
+	}
+}
+
+// Serve starts the HTTP server for receiving metrics requests.
+func (a *Aggregator) Serve() error {
+	a.server.Handler = a.handler()
+	err := a.server.ListenAndServe()
+	if err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown shuts down the aggregated metrics HTTP server.
+func (a *Aggregator) Shutdown(ctx context.Context) error {
+	return a.server.Shutdown(ctx)
+}
+
+func (a *Aggregator) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", a.aggregatedMetrics)
+	mux.HandleFunc("GET /metrics/{namespace}", a.namespaceMetrics)
+	return mux
+}
+
+// namespaceMetrics is an HTTP handler that returns the metrics of the remote snapshotter for a namespace.
+func (a *Aggregator) namespaceMetrics(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	namespace := req.PathValue("namespace")
+
+	snapshotter, ok := a.cache.Peek(namespace)
+	if !ok || snapshotter.MetricsProxy() == nil {
+		http.Error(w, "no remote snapshotter for namespace "+namespace, http.StatusNotFound)
+		return
+	}
+
+	metrics, err := snapshotter.MetricsProxy().Fetch()
+	if err != nil {
+		log.G(ctx).WithError(err).WithField("namespace", namespace).Error("unable to get remote snapshotter metrics")
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	w.Write(metrics)
+}
+
+// aggregatedMetrics is an HTTP handler that returns the metrics of the demux snapshotter
+// and of every remote snapshotter in the cache.
+func (a *Aggregator) aggregatedMetrics(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	families := newFamilySet()
+
+	own, err := a.gatherer.Gather()
+	if err != nil {
+		log.G(ctx).WithError(err).Error("unable to gather demux snapshotter metrics")
+	}
+	families.add(own)
+
+	var (
+		mu    sync.Mutex
+		group errgroup.Group
+	)
+	group.SetLimit(maxConcurrentScrapes)
+	for _, namespace := range a.cache.List() {
+		namespace := namespace
+		snapshotter, ok := a.cache.Peek(namespace)
+		if !ok || snapshotter.MetricsProxy() == nil {
+			continue
+		}
+		metricsProxy := snapshotter.MetricsProxy()
+
+		group.Go(func() error {
+			metrics, err := metricsProxy.Fetch()
+			if err != nil {
+				log.G(ctx).WithError(err).WithField("namespace", namespace).Warn("unable to get remote snapshotter metrics")
+				return nil
+			}
+
+			remote, err := decode(metrics)
+			if err != nil {
+				log.G(ctx).WithError(err).WithField("namespace", namespace).Warn("unable to parse remote snapshotter metrics")
+				return nil
+			}
+			labelFamilies(remote, remoteLabels(namespace, metricsProxy.Labels))
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, skipped := range families.add(remote) {
+				log.G(ctx).WithField("namespace", namespace).Warnf("skipping metric %s which has a conflicting type", skipped)
+			}
+			return nil
+		})
+	}
+	group.Wait()
+
+	format := expfmt.NewFormat(expfmt.TypeTextPlain)
+	w.Header().Set("Content-Type", string(format))
+	encoder := expfmt.NewEncoder(w, format)
+	for _, family := range families.sorted() {
+		if err := encoder.Encode(family); err != nil {
+			log.G(ctx).WithError(err).Error("unable to encode metrics")
+			return
+		}
+	}
+}
+
+// remoteLabels returns the labels added to the metrics of the remote snapshotter for a namespace.
+func remoteLabels(namespace string, labels map[string]string) map[string]string {
+	result := map[string]string{NamespaceLabel: namespace}
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result
+}
+
+// decode parses metrics in the Prometheus text format.
+func decode(metrics []byte) ([]*dto.MetricFamily, error) {
+	decoder := expfmt.NewDecoder(bytes.NewReader(metrics), expfmt.NewFormat(expfmt.TypeTextPlain))
+
+	var families []*dto.MetricFamily
+	for {
+		family := &dto.MetricFamily{}
+		err := decoder.Decode(family)
+		if errors.Is(err, io.EOF) {
+			return families, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		families = append(families, family)
+	}
+}
+
+// labelFamilies sets the provided labels on every metric of the families, replacing
+// the labels of the same name the metrics already have.
+func labelFamilies(families []*dto.MetricFamily, labels map[string]string) {
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			pairs := make(map[string]string, len(metric.Label)+len(labels))
+			for _, pair := range metric.Label {
+				pairs[pair.GetName()] = pair.GetValue()
+			}
+			for k, v := range labels {
+				pairs[k] = v
+			}
+
+			metric.Label = make([]*dto.LabelPair, 0, len(pairs))
+			for k, v := range pairs {
+				metric.Label = append(metric.Label, &dto.LabelPair{Name: protobuf.String(k), Value: protobuf.String(v)})
+			}
+			sort.Slice(metric.Label, func(i, j int) bool {
+				return metric.Label[i].GetName() < metric.Label[j].GetName()
+			})
+		}
+	}
+}
+
+// familySet merges metric families by name.
+type familySet map[string]*dto.MetricFamily
+
+func newFamilySet() familySet {
+	return make(familySet)
+}
+
+// add merges the metrics of the families into the set. The names of the families
+// which aren't of the same type as the family of the same name in the set are returned.
+func (s familySet) add(families []*dto.MetricFamily) []string {
+	var skipped []string
+	for _, family := range families {
+		existing, ok := s[family.GetName()]
+		if !ok {
+			s[family.GetName()] = family
+			continue
+		}
+		if existing.GetType() != family.GetType() {
+			skipped = append(skipped, family.GetName())
+			continue
+		}
+		existing.Metric = append(existing.Metric, family.Metric...)
+	}
+	return skipped
+}
+
+// sorted returns the families in the set ordered by name.
+func (s familySet) sorted() []*dto.MetricFamily {
+	families := make([]*dto.MetricFamily, 0, len(s))
+	for _, family := range s {
+		families = append(families, family)
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+	return families
+}
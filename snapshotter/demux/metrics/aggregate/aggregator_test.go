@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package aggregate
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/cache"
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/metrics"
+	"github.com/firecracker-microvm/firecracker-containerd/snapshotter/demux/proxy"
+)
+
+const remoteMetrics = `# HELP snapshotter_requests_total Requests served by the snapshotter.
+# TYPE snapshotter_requests_total counter
+snapshotter_requests_total{op="prepare"} 3
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 12
+`
+
+// remoteSnapshotters returns a function fetching remote snapshotters whose metrics are served by server.
+func remoteSnapshotters(server *httptest.Server) cache.SnapshotterProvider {
+	return func(ctx context.Context, namespace string) (*proxy.RemoteSnapshotter, error) {
+		dial := func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", server.Listener.Addr().String())
+		}
+		metricsProxy := metrics.NewAggregatedProxy(map[string]string{"VMID": namespace}, dial)
+		return proxy.NewRemoteSnapshotter(ctx, "unused", func(context.Context, string) (net.Conn, error) {
+			return nil, io.EOF
+		}, metricsProxy)
+	}
+}
+
+func newTestAggregator(t *testing.T) (*Aggregator, *cache.RemoteSnapshotterCache) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, remoteMetrics)
+	}))
+	t.Cleanup(server.Close)
+
+	c := cache.NewRemoteSnapshotterCache(remoteSnapshotters(server))
+	t.Cleanup(func() { c.Close() })
+
+	registry := prometheus.NewRegistry()
+	goroutines := prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_goroutines", Help: "Number of goroutines that currently exist."})
+	goroutines.Set(42)
+	registry.MustRegister(goroutines)
+
+	return &Aggregator{cache: c, gatherer: registry}, c
+}
+
+func TestAggregatedMetrics(t *testing.T) {
+	uut, c := newTestAggregator(t)
+	for _, namespace := range []string{"ns-1", "ns-2"} {
+		_, err := c.Get(context.Background(), namespace)
+		require.NoError(t, err)
+	}
+
+	rr := httptest.NewRecorder()
+	uut.handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := `# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+go_goroutines{VMID="ns-1",namespace="ns-1"} 12
+go_goroutines{VMID="ns-2",namespace="ns-2"} 12
+# HELP snapshotter_requests_total Requests served by the snapshotter.
+# TYPE snapshotter_requests_total counter
+snapshotter_requests_total{VMID="ns-1",namespace="ns-1",op="prepare"} 3
+snapshotter_requests_total{VMID="ns-2",namespace="ns-2",op="prepare"} 3
+`
+	// remote snapshotters are scraped concurrently, so their metrics may come in any order
+	assert.ElementsMatch(t, splitLines(expected), splitLines(rr.Body.String()))
+}
+
+func TestNamespaceMetrics(t *testing.T) {
+	uut, c := newTestAggregator(t)
+	_, err := c.Get(context.Background(), "ns-1")
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	uut.handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics/ns-1", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, remoteMetrics, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	uut.handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics/ns-2", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestFamilySetSkipsConflictingTypes(t *testing.T) {
+	own, err := decode([]byte("# TYPE requests counter\nrequests 1\n"))
+	require.NoError(t, err)
+	remote, err := decode([]byte("# TYPE requests gauge\nrequests 2\n"))
+	require.NoError(t, err)
+
+	families := newFamilySet()
+	assert.Empty(t, families.add(own))
+	assert.Equal(t, []string{"requests"}, families.add(remote))
+	assert.Len(t, families["requests"].Metric, 1)
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
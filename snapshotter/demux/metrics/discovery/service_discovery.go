@@ -81,9 +81,9 @@ func (sd *ServiceDiscovery) serviceDiscoveryHandler(w http.ResponseWriter, req *
 	namespaces := sd.cache.List()
 	services := []metricsTarget{}
 	for _, ns := range namespaces {
-		cachedSnapshotter, err := sd.cache.Get(ctx, ns)
-		if err != nil {
-			log.G(ctx).Error("unable to retrieve from snapshotter cache: ", err)
+		cachedSnapshotter, ok := sd.cache.Peek(ns)
+		if !ok {
+			// the snapshotter was evicted since the cache was listed
 			continue
 		}
 
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	metrics "github.com/docker/go-metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcMetrics  = metrics.NewNamespace("demux_snapshotter", "grpc", nil)
+	grpcRequests = grpcMetrics.NewLabeledTimer("request", "Time taken to serve snapshotter requests", "method")
+	grpcErrors   = grpcMetrics.NewLabeledCounter("errors", "Snapshotter requests which failed", "method", "code")
+)
+
+func init() {
+	metrics.Register(grpcMetrics)
+}
+
+// UnaryServerInterceptor records the latency and errors of the snapshotter requests served
+// by the demux snapshotter.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	grpcRequests.WithValues(method).UpdateSince(start)
+	if err != nil {
+		grpcErrors.WithValues(method, status.Code(err).String()).Inc()
+	}
+	return resp, err
+}
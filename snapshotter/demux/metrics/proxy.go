@@ -33,6 +33,10 @@ const (
 	RequestPath = "http://localhost/metrics"
 	// MaxMetricsResponseSize is the limit in bytes for size of a metrics response from a remote snapshotter.
 	MaxMetricsResponseSize = 32768 // 32 KB
+
+	// aggregatedScrapeTimeout is the time allowed to get metrics from a remote snapshotter
+	// for the aggregated metrics endpoint.
+	aggregatedScrapeTimeout = 5 * time.Second
 )
 
 // Monitor is used for port and lifecycle management of metrics proxies.
@@ -140,8 +144,41 @@ func NewProxy(host string, monitor *Monitor, labels map[string]string, dialer fu
 	}, nil
 }
 
+// NewAggregatedProxy creates a new Proxy whose metrics are served by the aggregated metrics
+// endpoint rather than by a server of its own, so it doesn't use a port.
+func NewAggregatedProxy(labels map[string]string, dialer func(context.Context, string, string) (net.Conn, error)) *Proxy {
+	return &Proxy{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: dialer,
+			},
+			Timeout: aggregatedScrapeTimeout,
+		},
+		Labels: labels,
+	}
+}
+
+// Fetch gets the metrics of the remote snapshotter. The response is truncated to MaxMetricsResponseSize.
+func (mp *Proxy) Fetch() ([]byte, error) {
+	res, err := mp.client.Get(RequestPath)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to GET %s: status=%d", RequestPath, res.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, MaxMetricsResponseSize))
+}
+
 // Serve starts the metrics proxy server for a single in-VM snapshotter.
+// Aggregated proxies don't have a server, Serve returns immediately for them.
 func (mp *Proxy) Serve(ctx context.Context) error {
+	if mp.monitor == nil {
+		return nil
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/metrics", mp.metrics)
 
@@ -164,6 +201,9 @@ func (mp *Proxy) Serve(ctx context.Context) error {
 
 // Shutdown shuts down the remote snapshotter metrics proxy server.
 func (mp *Proxy) Shutdown(ctx context.Context) error {
+	if mp.monitor == nil {
+		return nil
+	}
 	return mp.server.Shutdown(ctx)
 }
 
@@ -19,6 +19,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -213,6 +214,24 @@ func TestMetricsGet(t *testing.T) {
 	}
 }
 
+func TestFetch(t *testing.T) {
+	client := mockClient{getResponse: http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(metricsResponse))}}
+	uut := Proxy{client: &client}
+
+	metrics, err := uut.Fetch()
+	if err != nil {
+		t.Fatalf("expected no error from fetch: %s", err)
+	}
+	if string(metrics) != metricsResponse {
+		t.Fatalf("fetch returned unexpected metrics: got %v want %v", string(metrics), metricsResponse)
+	}
+
+	client.getResponse.StatusCode = http.StatusServiceUnavailable
+	if _, err := uut.Fetch(); err == nil {
+		t.Fatal("expected an error when the remote snapshotter fails to serve metrics")
+	}
+}
+
 type mockClient struct {
 	getResponse http.Response
 	getError    error
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package address
+
+import (
+	"sync"
+	"time"
+)
+
+type cachedResponse struct {
+	response  Response
+	expiresAt time.Time
+}
+
+// CachedResolver caches the successful responses of another resolver for a fixed
+// time to live.
+type CachedResolver struct {
+	resolver Resolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	responses map[string]cachedResponse
+}
+
+// NewCachedResolver creates a new instance of CachedResolver caching the responses
+// of resolver for ttl.
+func NewCachedResolver(resolver Resolver, ttl time.Duration) *CachedResolver {
+	return &CachedResolver{
+		resolver:  resolver,
+		ttl:       ttl,
+		now:       time.Now,
+		responses: make(map[string]cachedResponse),
+	}
+}
+
+// Get returns the cached response for the specified namespace, or queries the
+// underlying resolver if there is none or it expired.
+func (c *CachedResolver) Get(namespace string) (Response, error) {
+	c.mu.Lock()
+	cached, ok := c.responses[namespace]
+	c.mu.Unlock()
+
+	if ok && c.now().Before(cached.expiresAt) {
+		return cached.response, nil
+	}
+
+	response, err := c.resolver.Get(namespace)
+	if err != nil {
+		return Response{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses[namespace] = cachedResponse{response: response, expiresAt: c.now().Add(c.ttl)}
+	// drop the other expired responses so namespaces which are gone don't accumulate
+	for ns, r := range c.responses {
+		if !c.now().Before(r.expiresAt) {
+			delete(c.responses, ns)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package address
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingResolver struct {
+	calls int
+	err   error
+}
+
+func (r *countingResolver) Get(namespace string) (Response, error) {
+	r.calls++
+	if r.err != nil {
+		return Response{}, r.err
+	}
+	return Response{Address: namespace}, nil
+}
+
+func TestCachedResolver(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	resolver := &countingResolver{}
+	uut := NewCachedResolver(resolver, time.Minute)
+	uut.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		actual, err := uut.Get("ns-1")
+		require.NoError(t, err)
+		assert.Equal(t, "ns-1", actual.Address)
+	}
+	assert.Equal(t, 1, resolver.calls, "responses should be cached")
+
+	now = now.Add(time.Minute)
+	_, err := uut.Get("ns-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, resolver.calls, "expired responses should be resolved again")
+
+	resolver.err = errors.New("mock resolver error")
+	_, err = uut.Get("ns-2")
+	assert.Error(t, err)
+	_, err = uut.Get("ns-2")
+	assert.Error(t, err)
+	assert.Equal(t, 4, resolver.calls, "errors should not be cached")
+}
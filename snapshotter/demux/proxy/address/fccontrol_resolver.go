@@ -0,0 +1,83 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package address
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/namespaces"
+
+	"github.com/firecracker-microvm/firecracker-containerd/firecracker-control/client"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const fcControlRequestTimeout = 5 * time.Second
+
+// VMInfoClient defines the interface for the firecracker-control client
+// used by the resolver.
+type VMInfoClient interface {
+	GetVMInfo(context.Context, *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error)
+	Close() error
+}
+
+// FCControlResolver implements a proxy address resolver via firecracker-control.
+//
+// The namespace is treated as the ID of the VM whose snapshotter requests are
+// forwarded to, and the address is the VM's vsock path.
+type FCControlResolver struct {
+	snapshotterPort string
+	metricsPort     string
+	connect         func() (VMInfoClient, error)
+}
+
+// NewFCControlResolver creates a new instance of FCControlResolver connecting to
+// firecracker-control on the specified containerd TTRPC address.
+func NewFCControlResolver(ttrpcAddress, snapshotterPort, metricsPort string) FCControlResolver {
+	return FCControlResolver{
+		snapshotterPort: snapshotterPort,
+		metricsPort:     metricsPort,
+		connect: func() (VMInfoClient, error) {
+			return client.New(ttrpcAddress)
+		},
+	}
+}
+
+// Get queries the vsock path of the VM whose ID is the specified namespace.
+func (r FCControlResolver) Get(namespace string) (Response, error) {
+	fcClient, err := r.connect()
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to create firecracker-control client: %w", err)
+	}
+	defer fcClient.Close()
+
+	ctx, cancel := context.WithTimeout(namespaces.WithNamespace(context.Background(), namespace), fcControlRequestTimeout)
+	defer cancel()
+
+	vmInfo, err := fcClient.GetVMInfo(ctx, &proto.GetVMInfoRequest{VMID: namespace})
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to get VM %q: %w", namespace, err)
+	}
+
+	return Response{
+		Network:         "unix",
+		Address:         vmInfo.VSockPath,
+		SnapshotterPort: r.snapshotterPort,
+		MetricsPort:     r.metricsPort,
+		Labels: map[string]string{
+			"VMID": namespace,
+		},
+	}, nil
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package address
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+type mockVMInfoClient struct {
+	vmInfo    *proto.GetVMInfoResponse
+	namespace string
+}
+
+func (c *mockVMInfoClient) GetVMInfo(ctx context.Context, req *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
+	c.namespace, _ = namespaces.Namespace(ctx)
+	if c.vmInfo == nil || c.vmInfo.VMID != req.VMID {
+		return nil, errors.New("VM not found")
+	}
+	return c.vmInfo, nil
+}
+
+func (c *mockVMInfoClient) Close() error {
+	return nil
+}
+
+func TestFCControlResolver(t *testing.T) {
+	t.Parallel()
+
+	client := &mockVMInfoClient{vmInfo: &proto.GetVMInfoResponse{VMID: "ns-1", VSockPath: "/path/to/ns-1/firecracker.vsock"}}
+	uut := NewFCControlResolver("", "10000", "10002")
+	uut.connect = func() (VMInfoClient, error) { return client, nil }
+
+	actual, err := uut.Get("ns-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ns-1", client.namespace)
+	assert.Equal(t, Response{
+		Network:         "unix",
+		Address:         "/path/to/ns-1/firecracker.vsock",
+		SnapshotterPort: "10000",
+		MetricsPort:     "10002",
+		Labels:          map[string]string{"VMID": "ns-1"},
+	}, actual)
+
+	_, err = uut.Get("ns-2")
+	assert.Error(t, err)
+
+	uut.connect = func() (VMInfoClient, error) { return nil, errors.New("mock connection error") }
+	_, err = uut.Get("ns-1")
+	assert.Error(t, err)
+}
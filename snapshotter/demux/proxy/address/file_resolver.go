@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package address
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml"
+)
+
+// FileResolver implements a proxy address resolver from a static file mapping
+// namespaces to responses.
+//
+// The file is either JSON, when its extension is ".json", or TOML. It is reloaded
+// whenever its modification time or size changes.
+//
+// Example TOML file:
+//
+//	["ns-1"]
+//	  network = "unix"
+//	  address = "/var/lib/firecracker-containerd/shim-base/default#ns-1/firecracker.vsock"
+//	  snapshotter_port = "10000"
+//	  metrics_port = "10002"
+type FileResolver struct {
+	path string
+
+	mu        sync.Mutex
+	modTime   time.Time
+	size      int64
+	responses map[string]Response
+}
+
+// NewFileResolver creates a new instance of FileResolver reading the file at the specified path.
+func NewFileResolver(path string) (*FileResolver, error) {
+	r := &FileResolver{path: path}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Get looks up the proxy network type and address for the specified namespace.
+func (r *FileResolver) Get(namespace string) (Response, error) {
+	if err := r.reload(); err != nil {
+		return Response{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	response, ok := r.responses[namespace]
+	if !ok {
+		return Response{}, fmt.Errorf("namespace %q not found in %s", namespace, r.path)
+	}
+	return response, nil
+}
+
+// reload parses the file again if it changed since it was last read.
+func (r *FileResolver) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat address file: %w", err)
+	}
+	if r.responses != nil && info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return nil
+	}
+
+	contents, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read address file: %w", err)
+	}
+
+	responses := make(map[string]Response)
+	if filepath.Ext(r.path) == ".json" {
+		err = json.Unmarshal(contents, &responses)
+	} else {
+		err = toml.Unmarshal(contents, &responses)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse address file %s: %w", r.path, err)
+	}
+
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.responses = responses
+	return nil
+}
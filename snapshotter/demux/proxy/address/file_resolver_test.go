@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package address
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileResolverTOML(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "addresses.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+["ns-1"]
+  network = "unix"
+  address = "/path/to/ns-1/firecracker.vsock"
+  snapshotter_port = "10000"
+  metrics_port = "10002"
+  [ns-1.labels]
+    VMID = "ns-1"
+`), 0600))
+
+	uut, err := NewFileResolver(path)
+	require.NoError(t, err)
+
+	actual, err := uut.Get("ns-1")
+	require.NoError(t, err)
+	assert.Equal(t, Response{
+		Network:         "unix",
+		Address:         "/path/to/ns-1/firecracker.vsock",
+		SnapshotterPort: "10000",
+		MetricsPort:     "10002",
+		Labels:          map[string]string{"VMID": "ns-1"},
+	}, actual)
+
+	_, err = uut.Get("ns-2")
+	assert.Error(t, err, "unknown namespace")
+}
+
+func TestFileResolverReload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"ns-1": {"address": "/path/to/ns-1/firecracker.vsock"}}`), 0600))
+
+	uut, err := NewFileResolver(path)
+	require.NoError(t, err)
+
+	actual, err := uut.Get("ns-1")
+	require.NoError(t, err)
+	assert.Equal(t, "/path/to/ns-1/firecracker.vsock", actual.Address)
+
+	_, err = uut.Get("ns-2")
+	assert.Error(t, err, "ns-2 is not in the file yet")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"ns-2": {"address": "/path/to/ns-2/firecracker.vsock"}}`), 0600))
+	// make sure the change is noticed on file systems with a coarse modification time
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	actual, err = uut.Get("ns-2")
+	require.NoError(t, err)
+	assert.Equal(t, "/path/to/ns-2/firecracker.vsock", actual.Address)
+
+	_, err = uut.Get("ns-1")
+	assert.Error(t, err, "ns-1 was removed from the file")
+}
+
+func TestFileResolverInvalidFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	_, err := NewFileResolver(path)
+	assert.Error(t, err, "missing file")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"ns-1": `), 0600))
+	_, err = NewFileResolver(path)
+	assert.Error(t, err, "invalid file")
+}
@@ -18,19 +18,19 @@ type Response struct {
 	// Network type used in net.Dial.
 	//
 	// Reference: https://pkg.go.dev/net#Dial
-	Network string `json:"network"`
+	Network string `json:"network" toml:"network"`
 
 	// Network address used in net.Dial.
-	Address string `json:"address"`
+	Address string `json:"address" toml:"address"`
 
 	// SnapshotterPort is the port used in vsock.DialContext for sending snapshotter API requests to the remote snapshotter.
-	SnapshotterPort string `json:"snapshotter_port"`
+	SnapshotterPort string `json:"snapshotter_port" toml:"snapshotter_port"`
 
 	// MetricsPort is the port used in vsock.DialContext for sending metrics requests to the remote snapshotter.
-	MetricsPort string `json:"metrics_port"`
+	MetricsPort string `json:"metrics_port" toml:"metrics_port"`
 
 	// Labels is a map used for applying labels to metrics.
-	Labels map[string]string `json:"labels"`
+	Labels map[string]string `json:"labels" toml:"labels"`
 }
 
 // Resolver for the proxy network address.
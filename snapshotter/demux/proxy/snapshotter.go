@@ -98,3 +98,8 @@ func (rs *RemoteSnapshotter) MetricsProxyPort() int {
 func (rs *RemoteSnapshotter) MetricsProxyLabels() map[string]string {
 	return rs.metricsProxy.Labels
 }
+
+// MetricsProxy returns the metrics proxy for a remote snapshotter, or nil if metrics are disabled.
+func (rs *RemoteSnapshotter) MetricsProxy() *metrics.Proxy {
+	return rs.metricsProxy
+}